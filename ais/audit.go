@@ -0,0 +1,426 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/syslog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Audit log: one JSON record per mutating (PUT, POST, DELETE, PATCH) request written to
+// the node's audit log (see nlog.Audit) and, optionally, to the local syslog and/or
+// remote HTTP sink (see cmn.AuditConf).
+// - gateways record control-plane operations and those of the data-plane requests
+//   that fail prior to being redirected;
+// - targets record redirected data-plane requests, and also S3 requests that clients
+//   send directly;
+// - intra-cluster requests are never recorded.
+// Bucket's `audit.mode` overrides the cluster-wide `audit.enabled` (see cmn.BckAuditConf).
+
+const (
+	auditMaxBody   = 64 * cos.KiB // (control messages only)
+	auditMaxName   = cos.KiB      // (object names get truncated)
+	auditMaxXid    = 64
+	auditBatch     = 256 // HTTP sink: max records per POST
+	auditChanSize  = 4 * auditBatch
+	auditFlushIval = time.Second
+	auditWarnIval  = time.Minute
+)
+
+type (
+	auditRec struct {
+		Time   string `json:"time"`
+		Node   string `json:"node"`
+		User   string `json:"user,omitempty"`
+		Client string `json:"client"`
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Action string `json:"action,omitempty"`
+		Bucket string `json:"bucket,omitempty"`
+		Object string `json:"object,omitempty"`
+		Xid    string `json:"xid,omitempty"`
+		Status int    `json:"status"`
+	}
+
+	// captures response status and (short) response body - the latter
+	// to extract the ID of the xaction started by the request, if any
+	auditWriter struct {
+		http.ResponseWriter
+		body   []byte
+		status int
+		skip   bool
+	}
+
+	auditor struct {
+		h      *htrun
+		userFn func(r *http.Request) string
+		slog   *syslog.Writer
+		hsink  chan []byte
+		bmd    atomic.Int64 // version of the BMD that ...
+		anyBck atomic.Bool  // ... has buckets with `audit.mode` = enabled
+		warned atomic.Int64 // mono time
+		mu     sync.Mutex
+	}
+
+	auditCtxKey struct{}
+)
+
+// interface guard
+var _ http.ResponseWriter = (*auditWriter)(nil)
+
+/////////////////
+// auditWriter //
+/////////////////
+
+func (aw *auditWriter) WriteHeader(status int) {
+	aw.status = status
+	aw.ResponseWriter.WriteHeader(status)
+}
+
+func (aw *auditWriter) Write(b []byte) (int, error) {
+	if l := len(aw.body); l+len(b) <= auditMaxXid {
+		aw.body = append(aw.body, b...)
+	}
+	return aw.ResponseWriter.Write(b)
+}
+
+// (see http.ResponseController)
+func (aw *auditWriter) Unwrap() http.ResponseWriter { return aw.ResponseWriter }
+
+// when the request is forwarded to (and gets recorded by) the primary
+func auditSkip(w http.ResponseWriter) {
+	if aw, ok := w.(*auditWriter); ok {
+		aw.skip = true
+	}
+}
+
+// user ID asserted by the proxy, to be passed along with the redirect (see redirectURL)
+func auditUser(r *http.Request) string {
+	uid, _ := r.Context().Value(auditCtxKey{}).(string)
+	return uid
+}
+
+/////////////
+// auditor //
+/////////////
+
+func (a *auditor) init(h *htrun, userFn func(r *http.Request) string) {
+	a.h, a.userFn = h, userFn
+}
+
+func (a *auditor) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch:
+			if a.h != nil && (cmn.Rom.AuditEnabled() || a.bckEnabled()) {
+				a.serve(handler, w, r)
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+func (a *auditor) serve(handler http.HandlerFunc, w http.ResponseWriter, r *http.Request) {
+	if a.h.isIntraCall(r.Header, false /*from primary*/) == nil {
+		handler(w, r)
+		return
+	}
+	rec := &auditRec{Method: r.Method, Path: r.URL.Path}
+	if !a.parse(r, rec) {
+		handler(w, r)
+		return
+	}
+	if rec.User = a.userFn(r); rec.User != "" && a.h.si.IsProxy() {
+		r = r.WithContext(context.WithValue(r.Context(), auditCtxKey{}, rec.User))
+	}
+
+	aw := &auditWriter{ResponseWriter: w, status: http.StatusOK}
+	handler(aw, r)
+
+	if aw.skip {
+		return
+	}
+	if a.h.si.IsProxy() && (aw.status == http.StatusTemporaryRedirect || aw.status == http.StatusMovedPermanently) {
+		return // (to be recorded by the target)
+	}
+	rec.Status = aw.status
+	if rec.Status == http.StatusOK && xact.IsValidUUID(string(aw.body)) {
+		rec.Xid = string(aw.body)
+	}
+	a.write(rec)
+}
+
+// parse the request to fill-in bucket, object, and action; return false if the
+// request is not to be recorded (as per bucket's audit mode)
+func (a *auditor) parse(r *http.Request, rec *auditRec) bool {
+	var (
+		bck     *meta.Bck
+		payload bool // S3 request or PUT(object)
+		path    = strings.TrimPrefix(r.URL.Path, "/")
+		enabled = cmn.Rom.AuditEnabled()
+	)
+	switch {
+	case strings.HasPrefix(path, apc.S3+"/"):
+		payload = true
+		items := strings.SplitN(path[len(apc.S3)+1:], "/", 2)
+		if items[0] != "" {
+			if bck, _, _ = meta.InitByNameOnly(items[0], a.h.owner.bmd); bck == nil {
+				bck = &meta.Bck{Name: items[0], Provider: apc.AIS}
+			}
+		}
+		if len(items) > 1 {
+			rec.Object = s3.ObjName(items)
+		}
+	case strings.HasPrefix(path, apc.URLPathObjects.S[1:]+"/"), strings.HasPrefix(path, apc.URLPathBuckets.S[1:]+"/"):
+		objects := strings.HasPrefix(path, apc.URLPathObjects.S[1:])
+		items := strings.SplitN(path, "/", 4)[2:] // (skip version and resource)
+		if len(items) > 1 {
+			rec.Object = items[1]
+		}
+		payload = objects && r.Method == http.MethodPut
+		if items[0] == "" {
+			break
+		}
+		q := r.URL.Query()
+		provider, err := cmn.NormalizeProvider(q.Get(apc.QparamProvider))
+		if err != nil {
+			break
+		}
+		bck = &meta.Bck{Name: items[0], Provider: provider, Ns: cmn.ParseNsUname(q.Get(apc.QparamNamespace))}
+		bck.Props, _ = a.h.owner.bmd.get().Get(bck)
+	}
+	if bck != nil {
+		rec.Bucket = bck.Cname("")
+		if bck.Props != nil {
+			enabled = bck.Props.Audit.Enabled(enabled)
+		}
+	}
+	if !enabled {
+		return false
+	}
+	if len(rec.Object) > auditMaxName {
+		rec.Object = rec.Object[:auditMaxName] + "..."
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		rec.Client = host
+	}
+
+	// action message, if any
+	if !payload && r.ContentLength > 0 && r.ContentLength <= auditMaxBody {
+		b, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(b))
+		if err == nil {
+			var msg apc.ActMsg
+			if jsoniter.Unmarshal(b, &msg) == nil {
+				rec.Action = msg.Action
+			}
+		}
+	}
+	return true
+}
+
+// whether BMD has any buckets with auditing explicitly enabled (cached per BMD version)
+func (a *auditor) bckEnabled() bool {
+	bmd := a.h.owner.bmd.get()
+	if a.bmd.Load() == bmd.Version {
+		return a.anyBck.Load()
+	}
+	var found bool
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		found = bck.Props.Audit.Mode == cmn.AuditEnabled
+		return found
+	})
+	a.anyBck.Store(found)
+	a.bmd.Store(bmd.Version)
+	return found
+}
+
+func (a *auditor) write(rec *auditRec) {
+	rec.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	rec.Node = a.h.si.ID()
+	b, err := jsoniter.Marshal(rec)
+	if err != nil {
+		nlog.Errorln("failed to marshal audit record:", err)
+		return
+	}
+	nlog.Audit(b)
+
+	config := cmn.GCO.Get()
+	if config.Audit.Syslog {
+		a.syslog(b)
+	}
+	if config.Audit.HTTPSink != "" {
+		a.post(b)
+	}
+}
+
+func (a *auditor) syslog(b []byte) {
+	a.mu.Lock()
+	if a.slog == nil {
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_LOCAL0, "aistore")
+		if err != nil {
+			a.mu.Unlock()
+			a.warn("failed to connect to syslog:", err)
+			return
+		}
+		a.slog = w
+	}
+	a.mu.Unlock()
+	if err := a.slog.Info(string(b)); err != nil {
+		a.warn("failed to write audit record to syslog:", err)
+	}
+}
+
+// HTTP sink: non-blocking; records get dropped when the sink cannot keep up
+func (a *auditor) post(b []byte) {
+	a.mu.Lock()
+	if a.hsink == nil {
+		a.hsink = make(chan []byte, auditChanSize)
+		go a.sink()
+	}
+	a.mu.Unlock()
+	select {
+	case a.hsink <- b:
+	default:
+		a.warn("audit HTTP sink is falling behind, dropping records")
+	}
+}
+
+func (a *auditor) sink() {
+	var (
+		buf    bytes.Buffer
+		n      int
+		client = cmn.NewClient(cmn.TransportArgs{Timeout: cmn.GCO.Get().Client.Timeout.D()})
+		ticker = time.NewTicker(auditFlushIval)
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case b := <-a.hsink:
+			buf.Write(b)
+			buf.WriteByte('\n')
+			if n++; n < auditBatch {
+				continue
+			}
+		case <-ticker.C:
+			if n == 0 {
+				continue
+			}
+		}
+		a.flush(client, &buf)
+		buf.Reset()
+		n = 0
+	}
+}
+
+func (a *auditor) flush(client *http.Client, buf *bytes.Buffer) {
+	sinkURL := cmn.GCO.Get().Audit.HTTPSink
+	if sinkURL == "" {
+		return // (disabled in the meantime)
+	}
+	req, err := http.NewRequest(http.MethodPost, sinkURL, buf)
+	if err != nil {
+		a.warn("invalid audit HTTP sink:", err)
+		return
+	}
+	req.Header.Set(cos.HdrContentType, "application/x-ndjson")
+	resp, err := client.Do(req)
+	if err != nil {
+		a.warn("failed to post audit records:", err)
+		return
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		a.warn("audit HTTP sink responded with status", resp.StatusCode)
+	}
+}
+
+// throttled warning
+func (a *auditor) warn(args ...any) {
+	now := mono.NanoTime()
+	if last := a.warned.Load(); last != 0 && time.Duration(now-last) < auditWarnIval {
+		return
+	}
+	a.warned.Store(now)
+	nlog.Warningln(append([]any{a.h.String() + ":"}, args...)...)
+}
+
+// AuthN user ID (the token's or the owner's of the S3 access key);
+// NOTE: not validating the S3 signature - the status of the request will tell
+func (p *proxy) auditUser(r *http.Request) string {
+	if !cmn.Rom.AuthEnabled() {
+		return ""
+	}
+	if token, err := tok.ExtractToken(r.Header); err == nil {
+		if tk, err := p.authn.validateToken(token); err == nil {
+			return tk.UserID
+		}
+		return ""
+	}
+	return s3user(&p.htrun, r)
+}
+
+// user ID passed along by the proxy (see redirectURL) is trusted only with verified redirects;
+// otherwise, same as above except that targets do not keep track of revoked tokens
+func (t *target) auditUser(r *http.Request) string {
+	q := r.URL.Query()
+	if uid := q.Get(apc.QparamAuditUser); uid != "" && t.isVerifiedRedirect(r, q) {
+		return uid
+	}
+	if tk := t.userToken(r); tk != nil {
+		return tk.UserID
+	}
+	return ""
+}
+
+// the request's own AuthN token or the one of the S3 access key (nil when none or AuthN is disabled)
+func (t *target) userToken(r *http.Request) *tok.Token {
+	if !cmn.Rom.AuthEnabled() {
+		return nil
+	}
+	if token, err := tok.ExtractToken(r.Header); err == nil {
+		if tk, err := t.authkeys.decrypt(token); err == nil && tk.Expires.After(time.Now()) {
+			return tk
+		}
+		return nil
+	}
+	if sig, err := s3.ParseSigV4(r); err == nil && sig != nil {
+		if key := t.owner.s3keys.lookup(sig.AccessKey); key != nil {
+			return key.tk
+		}
+	}
+	return nil
+}
+
+func s3user(h *htrun, r *http.Request) string {
+	if sig, err := s3.ParseSigV4(r); err == nil && sig != nil {
+		if key := h.owner.s3keys.lookup(sig.AccessKey); key != nil && key.tk != nil {
+			return key.tk.UserID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Smap: proxy `pid` and the (test) target; AuthN secret to sign redirects
+func auditSmap(pid string) (restore func()) {
+	prev := t.owner.smap.get()
+	smap := newSmap()
+	smap.addProxy(newSnode(pid, apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{}))
+	smap.addTarget(t.si)
+	smap.Primary = smap.GetProxy(pid)
+	t.owner.smap.put(smap)
+	secret := t.authkeys.secret
+	t.authkeys.secret = "audit-secret"
+	return func() {
+		t.authkeys.secret = secret
+		if prev != nil {
+			t.owner.smap.put(prev)
+		}
+	}
+}
+
+func TestAuditRecord(tt *testing.T) {
+	recs := make(chan auditRec, 8)
+	sink := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var rec auditRec
+			if err := jsoniter.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				tt.Error(err)
+				continue
+			}
+			recs <- rec
+		}
+	}))
+	defer sink.Close()
+
+	restore := auditSmap("p1")
+	defer restore()
+
+	rom := cmn.Rom
+	config := cmn.GCO.BeginUpdate()
+	config.Audit.Enabled = true
+	config.Audit.HTTPSink = sink.URL
+	cmn.GCO.CommitUpdate(config)
+	cmn.Rom.Set(&config.ClusterConfig)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Audit.Enabled, config.Audit.HTTPSink = false, ""
+		cmn.GCO.CommitUpdate(config)
+		cmn.Rom = rom
+	}()
+
+	var a auditor
+	a.init(&t.htrun, t.auditUser)
+	xid := cos.GenUUID()
+	handler := a.wrap(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Write([]byte(xid))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// PUT(object) redirected by a proxy in the Smap: trusted user
+	var (
+		path  = "/v1/objects/" + testBucket + "/obj"
+		ptime = cos.UnixNano2S(time.Now().UnixNano())
+		q     = url.Values{
+			apc.QparamProvider:    []string{apc.AIS},
+			apc.QparamProxyID:     []string{"p1"},
+			apc.QparamUnixTime:    []string{ptime},
+			apc.QparamAuditUser:   []string{"alice"},
+			apc.QparamRedirectSig: []string{t.redirectSig(path, "p1", ptime, "alice")},
+		}
+	)
+	req := httptest.NewRequest(http.MethodPut, path+"?"+q.Encode(), strings.NewReader("data"))
+	handler(httptest.NewRecorder(), req)
+
+	// POST(bucket) with action message
+	body := `{"action":"` + apc.ActCopyBck + `"}`
+	req = httptest.NewRequest(http.MethodPost, "/v1/buckets/"+testBucket+"?provider=ais", strings.NewReader(body))
+	handler(httptest.NewRecorder(), req)
+
+	// GET: not recorded
+	req = httptest.NewRequest(http.MethodGet, "/v1/objects/"+testBucket+"/obj?provider=ais", http.NoBody)
+	handler(httptest.NewRecorder(), req)
+
+	for i, exp := range []auditRec{
+		{User: "alice", Method: http.MethodPut, Path: "/v1/objects/" + testBucket + "/obj", Object: "obj", Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/v1/buckets/" + testBucket, Action: apc.ActCopyBck, Xid: xid, Status: http.StatusOK},
+	} {
+		var rec auditRec
+		select {
+		case rec = <-recs:
+		case <-time.After(5 * time.Second):
+			tt.Fatalf("record #%d: timed out", i)
+		}
+		if rec.Node != t.SID() || rec.Time == "" || rec.Client == "" {
+			tt.Errorf("record #%d: missing node, time, or client: %+v", i, rec)
+		}
+		if rec.Bucket != "ais://"+testBucket {
+			tt.Errorf("record #%d: expected bucket %q, got %q", i, "ais://"+testBucket, rec.Bucket)
+		}
+		rec.Node, rec.Time, rec.Client, rec.Bucket = "", "", "", ""
+		if rec != exp {
+			tt.Errorf("record #%d: expected %+v, got %+v", i, exp, rec)
+		}
+	}
+	select {
+	case rec := <-recs:
+		tt.Errorf("unexpected record %+v", rec)
+	case <-time.After(2 * auditFlushIval):
+	}
+}
+
+func TestAuditUserRedirect(tt *testing.T) {
+	restore := auditSmap("p1")
+	defer restore()
+
+	var (
+		path  = "/v1/objects/" + testBucket + "/obj"
+		ptime = cos.UnixNano2S(time.Now().UnixNano())
+		redir = func(pid, uid string) string {
+			return "audit_user=" + uid + "&pid=" + pid + "&utm=" + ptime + "&rsig=" + t.redirectSig(path, pid, ptime, uid)
+		}
+	)
+	tests := []struct {
+		path  string
+		query string
+		user  string
+	}{
+		{path, redir("p1", "alice"), "alice"},
+		{path, redir("p1", "alice") + "&audit_user=admin", "alice"},
+		{path, strings.Replace(redir("p1", "alice"), "alice", "admin", 1), ""},  // tampered with
+		{path + "2", redir("p1", "alice"), ""},                                  // signed for another object
+		{path, "audit_user=alice&pid=p1&utm=" + ptime, ""},                      // not signed
+		{path, strings.Replace(redir("p1", "alice"), "&utm="+ptime, "", 1), ""}, // not a redirect
+		{path, redir("p2", "alice"), ""},                                        // not in the Smap
+		{path, redir(t.SID(), "alice"), ""},                                     // not a proxy
+		{path, "audit_user=alice", ""},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPut, test.path+"?"+test.query, http.NoBody)
+		if user := t.auditUser(req); user != test.user {
+			tt.Errorf("%s?%s: expected user %q, got %q", test.path, test.query, test.user, user)
+		}
+	}
+
+	// without AuthN secret redirects are not verified
+	secret := t.authkeys.secret
+	t.authkeys.secret = ""
+	req := httptest.NewRequest(http.MethodPut, path+"?"+tests[0].query, http.NoBody)
+	if user := t.auditUser(req); user != "" {
+		tt.Errorf("expected no user without secret, got %q", user)
+	}
+	t.authkeys.secret = secret
+}
+
+// client-provided redirect parameters do not make it through the proxy
+func TestRedirectURL(tt *testing.T) {
+	restore := auditSmap("p1")
+	defer restore()
+
+	p := &proxy{htrun: htrun{si: t.owner.smap.get().GetProxy("p1"), authkeys: t.authkeys}}
+	forged := "pid=p1&utm=1&audit_user=admin&rsig=abc&audit%5Fuser=root&traceparent=00-abc"
+	req := httptest.NewRequest(http.MethodPut, "/v1/objects/"+testBucket+"/obj?provider=ais&"+forged, http.NoBody)
+	req = req.WithContext(context.WithValue(req.Context(), auditCtxKey{}, "alice"))
+
+	redirect := p.redirectURL(req, t.si, time.Now(), cmn.NetIntraData)
+	uri := redirect[strings.Index(redirect, "/v1/"):]
+	q, err := url.ParseQuery(uri[strings.IndexByte(uri, '?')+1:])
+	if err != nil {
+		tt.Fatal(err)
+	}
+	for k, v := range map[string]string{apc.QparamProvider: apc.AIS, apc.QparamProxyID: "p1", apc.QparamAuditUser: "alice"} {
+		if len(q[k]) != 1 || q.Get(k) != v {
+			tt.Errorf("%s: expected %q, got %v", k, v, q[k])
+		}
+	}
+	if len(q[apc.QparamUnixTime]) != 1 || len(q[apc.QparamRedirectSig]) != 1 || q.Has(apc.QparamTraceparent) {
+		tt.Errorf("unexpected redirect query %v", q)
+	}
+	treq := httptest.NewRequest(http.MethodPut, uri, http.NoBody)
+	if user := t.auditUser(treq); user != "alice" {
+		tt.Errorf("expected user %q, got %q", "alice", user)
+	}
+}
@@ -0,0 +1,155 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// AuthN token validation keys: either the shared secret (HS256) or AuthN public keys (RS256, ES256)
+// fetched from `auth.jwks_url` (see cmd/authn/keys.go) - the latter with HS256 only if `auth.allow_hs256`.
+// Public keys are fetched lazily and then refreshed:
+// - upon encountering unknown key ID (key rotation) - at most once every jwksMinRefresh;
+// - in the background, when older than jwksMaxAge (to stop trusting removed keys).
+
+const (
+	jwksMinRefresh = 10 * time.Second
+	jwksMaxAge     = time.Hour
+	jwksTimeout    = 10 * time.Second
+)
+
+type authKeys struct {
+	ks       *tok.KeySet
+	client   *http.Client
+	url      string
+	secret   string
+	hs256    bool         // with public keys: also accept HS256 (auth.allow_hs256)
+	fetched  atomic.Int64 // mono time of the last successful fetch
+	tried    int64        // mono time of the last attempt (under fmu)
+	mu       sync.RWMutex // protects ks
+	fmu      sync.Mutex   // serializes fetching
+	fetching atomic.Bool
+	onFetch  func() // upon the first successful fetch (see s3keyOwner.resolve)
+}
+
+func newAuthKeys(config *cmn.Config) *authKeys {
+	k := &authKeys{url: config.Auth.JWKSURL, secret: authSecret(config)}
+	if k.url == "" {
+		k.ks = tok.NewHMACKeySet(k.secret)
+		return k
+	}
+	cargs := cmn.TransportArgs{Timeout: jwksTimeout}
+	if strings.HasPrefix(k.url, "https://") {
+		k.client = cmn.NewClientTLS(cargs, cmn.TLSArgs{SkipVerify: config.Net.HTTP.SkipVerifyCrt}, false /*intra*/)
+	} else {
+		k.client = cmn.NewClient(cargs)
+	}
+	k.hs256 = config.Auth.AllowHS256
+	k.ks, _ = tok.NewKeySet(k.hsecret(), nil) // (no public keys yet)
+	return k
+}
+
+// (with public keys) secret to validate HS256 tokens, if allowed
+func (k *authKeys) hsecret() string {
+	if k.hs256 {
+		return k.secret
+	}
+	return ""
+}
+
+func (k *authKeys) keySet() (ks *tok.KeySet) {
+	k.mu.RLock()
+	ks = k.ks
+	k.mu.RUnlock()
+	return
+}
+
+func (k *authKeys) decrypt(token string) (*tok.Token, error) {
+	tk, err := k.keySet().DecryptToken(token)
+	if k.url == "" {
+		return tk, err
+	}
+	if err == nil {
+		if f := k.fetched.Load(); f != 0 && mono.Since(f) > jwksMaxAge && !k.fetching.Swap(true) {
+			go func() {
+				k.refresh()
+				k.fetching.Store(false)
+			}()
+		}
+		return tk, nil
+	}
+	if !errors.Is(err, tok.ErrUnknownKID) {
+		return nil, err
+	}
+	if !k.refresh() {
+		return nil, err
+	}
+	return k.keySet().DecryptToken(token)
+}
+
+// returns true if the key set has been updated
+func (k *authKeys) refresh() bool {
+	k.fmu.Lock()
+	defer k.fmu.Unlock()
+	now := mono.NanoTime()
+	if k.tried != 0 && time.Duration(now-k.tried) < jwksMinRefresh {
+		// (fetched or failed to fetch only a moment ago)
+		return k.fetched.Load() >= k.tried
+	}
+	k.tried = now
+	jwks, err := k.fetch()
+	if err == nil {
+		var ks *tok.KeySet
+		if ks, err = tok.NewKeySet(k.hsecret(), jwks); err == nil {
+			k.mu.Lock()
+			k.ks = ks
+			k.mu.Unlock()
+			first := k.fetched.Swap(now) == 0
+			nlog.Infoln("fetched", ks.Len(), "AuthN public key(s) from", k.url)
+			if first && k.onFetch != nil {
+				go k.onFetch()
+			}
+			return true
+		}
+	}
+	nlog.Errorln("failed to fetch AuthN public keys from", k.url+":", err)
+	return false
+}
+
+func (k *authKeys) fetch() (*tok.JWKS, error) {
+	req, err := http.NewRequest(http.MethodGet, k.url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: status %d", k.url, resp.StatusCode)
+	}
+	jwks := &tok.JWKS{}
+	if err := jsoniter.NewDecoder(resp.Body).Decode(jwks); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
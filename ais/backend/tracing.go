@@ -0,0 +1,69 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tracing"
+)
+
+// traced backend: spans around the (remote) calls that are made in the context
+// of a (traced) user request; see also t.Backend()
+type traced struct {
+	core.Backend
+}
+
+// interface guard
+var _ core.Backend = (*traced)(nil)
+
+func Traced(bp core.Backend) core.Backend { return &traced{bp} }
+
+func (tb *traced) name(op string) string { return "backend." + tb.Provider() + "." + op }
+
+func (tb *traced) HeadBucket(ctx context.Context, bck *meta.Bck) (cos.StrKVs, int, error) {
+	ctx, span := tracing.StartChild(ctx, tb.name("HeadBucket"))
+	props, ecode, err := tb.Backend.HeadBucket(ctx, bck)
+	tracing.End(span, err)
+	return props, ecode, err
+}
+
+func (tb *traced) HeadObj(ctx context.Context, lom *core.LOM, oreq *http.Request) (*cmn.ObjAttrs, int, error) {
+	ctx, span := tracing.StartObj(ctx, tb.name("HeadObj"), lom)
+	oa, ecode, err := tb.Backend.HeadObj(ctx, lom, oreq)
+	tracing.End(span, err)
+	return oa, ecode, err
+}
+
+func (tb *traced) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, oreq *http.Request) (int, error) {
+	ctx, span := tracing.StartObj(ctx, tb.name("GetObj"), lom)
+	ecode, err := tb.Backend.GetObj(ctx, lom, owt, oreq)
+	tracing.End(span, err)
+	return ecode, err
+}
+
+// NOTE: measures the time to get the reader, not to read it
+func (tb *traced) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int64) core.GetReaderResult {
+	ctx, span := tracing.StartObj(ctx, tb.name("GetObjReader"), lom)
+	res := tb.Backend.GetObjReader(ctx, lom, offset, length)
+	tracing.End(span, res.Err)
+	return res
+}
+
+func (tb *traced) PutObj(r io.ReadCloser, lom *core.LOM, oreq *http.Request) (int, error) {
+	if oreq == nil {
+		return tb.Backend.PutObj(r, lom, oreq)
+	}
+	ctx, span := tracing.StartObj(oreq.Context(), tb.name("PutObj"), lom)
+	ecode, err := tb.Backend.PutObj(r, lom, oreq.WithContext(ctx))
+	tracing.End(span, err)
+	return ecode, err
+}
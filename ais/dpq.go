@@ -49,6 +49,9 @@ type dpq struct {
 
 var _except = map[string]bool{
 	apc.QparamProxyID:        false,
+	apc.QparamRedirectSig:    false,
+	apc.QparamAuditUser:      false,
+	apc.QparamTraceparent:    false,
 	apc.QparamDontHeadRemote: false,
 
 	// flows that utilize the following query parameters perform conventional r.URL.Query()
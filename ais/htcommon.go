@@ -398,6 +398,7 @@ type (
 
 	cresLso   struct{} // -> cmn.LsoRes
 	cresBsumm struct{} // -> cmn.AllBsummResults
+	cresBU    struct{} // -> bucket ID => [size, number of objects] (see tgtquota.go)
 )
 
 var (
@@ -412,6 +413,7 @@ var (
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
+	_ cresv = cresBU{}
 )
 
 func (res *callResult) read(body io.Reader, size int64) {
@@ -463,6 +465,9 @@ func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresBU) newV() any                              { return &map[uint64][2]int64{} }
+func (c cresBU) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 ////////////////
 // nlogWriter //
 ////////////////
@@ -7,7 +7,10 @@ package ais
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -36,8 +39,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -74,8 +79,12 @@ type htrun struct {
 		rmd    *rmdOwner
 		config *configOwner
 		etl    etlOwner // ditto
+		s3keys *s3keyOwner
 	}
-	startup struct {
+	authkeys *authKeys // AuthN token validation
+	rlim     rlimiters // request rate limiting
+	audit    auditor   // audit log
+	startup  struct {
 		cluster atomic.Int64 // mono.NanoTime() since cluster startup, zero prior to that
 		node    atomic.Int64 // ditto - for the node
 	}
@@ -220,6 +229,14 @@ func (h *htrun) regNetHandlers(networkHandlers []networkHandler) {
 	// node type specific
 	for _, nh := range networkHandlers {
 		var reg bool
+		// intra-cluster transport sends no caller headers: long-lived stream sessions
+		// are neither audited (as user PUTs) nor traced (as root spans)
+		if nh.r != apc.ObjStream {
+			nh.h = h.audit.wrap(nh.h)
+		}
+		if nh.r != apc.Health && nh.r != apc.ObjStream {
+			nh.h = tracing.NewHandler(nh.h)
+		}
 		if nh.r[0] == '/' { // absolute path
 			path = nh.r
 		} else {
@@ -296,6 +313,9 @@ func (h *htrun) init(config *cmn.Config) {
 	h.owner.smap = newSmapOwner(config)
 	h.owner.rmd = newRMDOwner(config)
 	h.owner.rmd.load()
+	h.authkeys = newAuthKeys(config)
+	h.owner.s3keys = newS3keyOwner(config)
+	h.owner.s3keys.load(h.authkeys)
 
 	h.gmm = memsys.PageMM()
 	h.gmm.RegWithHK()
@@ -424,6 +444,15 @@ func (h *htrun) initSnode(config *cmn.Config) {
 		copy(h.si.PubExtra, pubExtra)
 		nlog.Infof("%s (multihome) access: %v and %v", cmn.NetPublic, pubAddr, h.si.PubExtra)
 	}
+
+	// 5. failure domain (environment takes precedence)
+	h.si.Domain = config.Domain
+	if domain := os.Getenv(env.AIS.FailureDomain); domain != "" {
+		h.si.Domain = domain
+	}
+	if h.si.Domain != "" {
+		nlog.Infoln("failure domain:", h.si.Domain)
+	}
 }
 
 func mustDiffer(ip1 meta.NetInfo, port1 int, use1 bool, ip2 meta.NetInfo, port2 int, use2 bool, tag string) {
@@ -563,6 +592,20 @@ func (h *htrun) pubAddrAny(config *cmn.Config) (inaddrAny bool) {
 	return inaddrAny
 }
 
+const tracingIval = 10 * time.Second
+
+// start tracing if configured, and keep checking the configuration
+// (to (re)start or stop tracing when it changes - see tracing.Update)
+func (h *htrun) initTracing() {
+	tracing.Init(&cmn.GCO.Get().Tracing, h.si, nil)
+	hk.Reg("tracing"+hk.NameSuffix, h.tracingHK, tracingIval)
+}
+
+func (*htrun) tracingHK(int64) time.Duration {
+	tracing.Update(&cmn.GCO.Get().Tracing)
+	return tracingIval
+}
+
 // remove self from Smap (if required), terminate http, and wait (w/ timeout)
 // for running xactions to abort
 func (h *htrun) stop(wg *sync.WaitGroup, rmFromSmap bool) {
@@ -580,6 +623,7 @@ func (h *htrun) stop(wg *sync.WaitGroup, rmFromSmap bool) {
 	go func() {
 		time.Sleep(sleep)
 		shuthttp()
+		tracing.Shutdown()
 		wg.Done()
 	}()
 	entry := xreg.GetRunning(xreg.Flt{})
@@ -698,14 +742,17 @@ func (h *htrun) call(args *callArgs, smap *smapX) (res *callResult) {
 	req.Header.Set(apc.HdrCallerName, h.si.Name())
 	req.Header.Set(cos.HdrUserAgent, ua)
 
+	span := tracing.StartClient(req)
 	resp, res.err = client.Do(req)
 	if res.err != nil {
+		tracing.EndHTTP(span, 0, res.err)
 		res.details = dfltDetail // tcp level, e.g.: connection refused
 		return res
 	}
 
 	_doResp(args, req, resp, res)
 	resp.Body.Close()
+	tracing.EndHTTP(span, res.status, nil)
 
 	if sid != unknownDaemonID {
 		h.keepalive.heardFrom(sid)
@@ -1324,6 +1371,8 @@ func sev2Logname(severity string) (log string, err error) {
 		log = filepath.Join(dir, nlog.InfoLogName())
 	case apc.LogWarn[0], apc.LogErr[0]:
 		log = filepath.Join(dir, nlog.ErrLogName())
+	case apc.LogAudit[0]:
+		log = filepath.Join(dir, nlog.AuditLogName())
 	default:
 		err = fmt.Errorf("unknown log severity %q", severity)
 	}
@@ -2246,6 +2295,39 @@ func isRedirect(q url.Values) (ptime string) {
 	return q.Get(apc.QparamUnixTime)
 }
 
+// redirected and signed by a proxy that is a member of the current cluster map
+// (as opposed to merely carrying the query parameters - see isRedirect)
+// NOTE: without AuthN secret redirects cannot be signed and are never verified
+func (h *htrun) isVerifiedRedirect(r *http.Request, q url.Values) bool {
+	ptime := isRedirect(q)
+	if ptime == "" {
+		return false
+	}
+	pid, rsig := q.Get(apc.QparamProxyID), q.Get(apc.QparamRedirectSig)
+	if rsig == "" {
+		return false
+	}
+	if smap := h.owner.smap.get(); smap == nil || smap.GetProxy(pid) == nil {
+		return false
+	}
+	sig := h.redirectSig(r.URL.Path, pid, ptime, q.Get(apc.QparamAuditUser))
+	return sig != "" && hmac.Equal(cos.UnsafeB(sig), cos.UnsafeB(rsig))
+}
+
+// HMAC-SHA256 (keyed with AuthN secret) of the redirected request's path
+// and the query parameters the redirecting proxy adds (see redirectURL)
+func (h *htrun) redirectSig(path, pid, ptime, uid string) string {
+	if h.authkeys == nil || h.authkeys.secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, cos.UnsafeB(h.authkeys.secret))
+	for _, s := range []string{path, pid, ptime, uid} {
+		mac.Write(cos.UnsafeB(s))
+		mac.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
 func ptLatency(tts int64, ptime, isPrimary string) (dur int64) {
 	pts, err := cos.S2UnixNano(ptime)
 	if err != nil {
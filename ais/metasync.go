@@ -80,14 +80,15 @@ import (
 // with additional information that includes the per-replica action message.
 
 const (
-	revsSmapTag  = "Smap"
-	revsRMDTag   = "RMD"
-	revsBMDTag   = "BMD"
-	revsConfTag  = "Conf"
-	revsTokenTag = "token"
-	revsEtlMDTag = "EtlMD"
-
-	revsMaxTags   = 6         // NOTE
+	revsSmapTag   = "Smap"
+	revsRMDTag    = "RMD"
+	revsBMDTag    = "BMD"
+	revsConfTag   = "Conf"
+	revsTokenTag  = "token"
+	revsEtlMDTag  = "EtlMD"
+	revsS3KeysTag = "S3keys"
+
+	revsMaxTags   = 7         // NOTE
 	revsActionTag = "-action" // prefix revs tag
 )
 
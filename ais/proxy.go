@@ -38,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	jsoniter "github.com/json-iterator/go"
@@ -195,13 +196,16 @@ func (p *proxy) Run() error {
 	// startup sequence - see earlystart.go for the steps and commentary
 	p.bootstrap()
 
-	p.authn = newAuthManager(config)
+	p.authn = newAuthManager(p.authkeys)
 
 	p.rproxy.init()
 
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.rlim.init(p.statsT)
+	p.audit.init(&p.htrun, p.auditUser)
+	p.initTracing()
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -215,6 +219,7 @@ func (p *proxy) Run() error {
 		{r: apc.Download, h: p.dloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.GetBatch, h: p.getBatchHandler, net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
@@ -965,6 +970,10 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *ap
 			p.reverseRemAis(w, r, msg, bck.Bucket(), apireq.query)
 			return
 		}
+		if err := checkBckObjLock(bck, msg.Action); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
 		if err := p.destroyBucket(msg, bck); err != nil {
 			if cmn.IsErrBckNotFound(err) {
 				// TODO: return http.StatusNoContent
@@ -980,6 +989,13 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *ap
 				return
 			}
 		}
+		if msg.Action == apc.ActDeleteObjects {
+			// bucket-wide legal hold (per-object retention is enforced by targets)
+			if err := bck.Props.ObjLock.Check(bck.Cname(""), nil, time.Time{}); err != nil {
+				p.writeErr(w, r, err, http.StatusForbidden)
+				return
+			}
+		}
 		xid, err := p.listrange(r.Method, bck.Name, msg, apireq.query)
 		if err != nil {
 			p.writeErr(w, r, err)
@@ -1037,6 +1053,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		newRMD, msgRMD, errRMD       = p.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = p.extractEtlMD(payload, caller)
 		revokedTokens, errTokens     = p.extractRevokedTokenList(payload, caller)
+		s3keys, errS3keys            = p.extractS3Keys(payload, caller)
 	)
 	// 2. apply
 	if errConf == nil && newConf != nil {
@@ -1057,12 +1074,16 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	if errTokens == nil && revokedTokens != nil {
 		_ = p.authn.updateRevokedList(revokedTokens)
 	}
+	if errS3keys == nil && s3keys != nil {
+		p.receiveS3Keys(s3keys)
+	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil &&
+		errS3keys == nil {
 		return
 	}
 	p.fillNsti(nsti)
-	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens)
+	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens, errS3keys)
 	p.writeErr(w, r, retErr, http.StatusConflict)
 }
 
@@ -1322,6 +1343,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 		if err := p.checkAccess(w, r, nil, apc.AceMoveBucket); err != nil {
 			return
 		}
+		if err := checkBckObjLock(bckFrom, msg.Action); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
 		nlog.Infof("%s bucket %s => %s", msg.Action, bckFrom, bckTo)
 		if xid, err = p.renameBucket(bckFrom, bckTo, msg); err != nil {
 			p.writeErr(w, r, err)
@@ -1828,7 +1853,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActObjLock {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1855,6 +1880,15 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		p.redirectAction(w, r, bck, apireq.items[1], msg)
 		p.statsT.Inc(stats.RenameCount)
+	case apc.ActObjLock:
+		if err := p.checkAccess(w, r, bck, apc.AceObjUpdate); err != nil {
+			return
+		}
+		if !bck.Props.ObjLock.Enabled {
+			p.writeErrf(w, r, "%s: object lock is not enabled", bck.Cname(""))
+			return
+		}
+		p.redirectAction(w, r, bck, apireq.items[1], msg)
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
 			p.statsT.IncErr(stats.ErrRenameCount)
@@ -2277,18 +2311,54 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 		}
 	}
 	redirect = nodeURL + r.URL.Path + "?"
-	if r.URL.RawQuery != "" {
-		redirect += r.URL.RawQuery + "&"
+	if rawQuery := redirectQuery(r.URL.RawQuery); rawQuery != "" {
+		redirect += rawQuery + "&"
 	}
 
-	query := url.Values{
-		apc.QparamProxyID:  []string{p.SID()},
-		apc.QparamUnixTime: []string{cos.UnixNano2S(ts.UnixNano())},
+	var (
+		pid   = p.SID()
+		ptime = cos.UnixNano2S(ts.UnixNano())
+		query = url.Values{
+			apc.QparamProxyID:  []string{pid},
+			apc.QparamUnixTime: []string{ptime},
+		}
+		uid = auditUser(r)
+	)
+	if conf := cmn.Rom.BckMetrics(); uid == "" && conf.Enabled && conf.PerUser {
+		uid = p.auditUser(r) // (to label per-bucket metrics)
+	}
+	if uid != "" {
+		query.Set(apc.QparamAuditUser, uid)
+	}
+	if sig := p.redirectSig(r.URL.Path, pid, ptime, uid); sig != "" {
+		query.Set(apc.QparamRedirectSig, sig)
+	}
+	if tp := tracing.Traceparent(r.Context()); tp != "" {
+		query.Set(apc.QparamTraceparent, tp)
 	}
 	redirect += query.Encode()
 	return
 }
 
+// query parameters that only the redirecting proxy may set (see isVerifiedRedirect)
+var redirectQparams = [...]string{apc.QparamProxyID, apc.QparamUnixTime, apc.QparamAuditUser, apc.QparamRedirectSig, apc.QparamTraceparent}
+
+// remove client-provided redirect parameters, if any
+func redirectQuery(rawQuery string) string {
+	found := strings.IndexByte(rawQuery, '%') >= 0 // (percent-encoded keys)
+	for i := 0; i < len(redirectQparams) && !found; i++ {
+		found = strings.Contains(rawQuery, redirectQparams[i])
+	}
+	if !found {
+		return rawQuery
+	}
+	q, _ := url.ParseQuery(rawQuery) // (dropping malformed pairs, if any)
+	for _, k := range redirectQparams {
+		q.Del(k)
+	}
+	return q.Encode()
+}
+
 // lsObjsA reads object list from all targets, combines, sorts and returns
 // the final list. Excess of object entries from each target is remembered in the
 // buffer (see: `queryBuffers`) so we won't request the same objects again.
@@ -8,16 +8,18 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/api/env"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
@@ -34,8 +36,8 @@ type (
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
 		version       int64
-		// signing key secret
-		secret string
+		// validation keys (HS256 secret and/or AuthN public keys)
+		keys *authKeys
 	}
 )
 
@@ -43,15 +45,20 @@ type (
 // authManager //
 /////////////////
 
-func newAuthManager(config *cmn.Config) *authManager {
+func newAuthManager(keys *authKeys) *authManager {
 	return &authManager{
 		tkList:        make(tkList),
 		revokedTokens: make(map[string]bool), // TODO: preallocate
 		version:       1,
-		secret:        cos.Right(config.Auth.Secret, os.Getenv(env.AuthN.SecretKey)), // environment override
+		keys:          keys,
 	}
 }
 
+// AuthN signing secret (environment override)
+func authSecret(config *cmn.Config) string {
+	return cos.Right(config.Auth.Secret, os.Getenv(env.AuthN.SecretKey))
+}
+
 // Add tokens to the list of invalid ones and clean up the list from expired tokens.
 func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *tokenList) {
 	a.Lock()
@@ -82,9 +89,10 @@ func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *toke
 	now := time.Now()
 
 	for token := range a.revokedTokens {
-		tk, err := tok.DecryptToken(token, a.secret)
-		debug.AssertNoErr(err)
-		if tk.Expires.Before(now) {
+		// (keeping tokens that cannot be validated at the moment - e.g., signed by the key
+		// that hasn't been fetched yet)
+		tk, err := a.keys.decrypt(token)
+		if err == nil && tk.Expires.Before(now) {
 			delete(a.revokedTokens, token)
 		} else {
 			allRevoked.Tokens = append(allRevoked.Tokens, token)
@@ -134,7 +142,7 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
 		var err error
-		if tk, err = tok.DecryptToken(token, a.secret); err != nil {
+		if tk, err = a.keys.decrypt(token); err != nil {
 			nlog.Errorln(err)
 			return nil, tok.ErrInvalidToken
 		}
@@ -172,8 +180,10 @@ func (p *proxy) tokenHandler(w http.ResponseWriter, r *http.Request) {
 		p.validateSecret(w, r)
 	case http.MethodDelete:
 		p.delToken(w, r)
+	case http.MethodPut:
+		p.putS3Keys(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodPost, http.MethodPut)
 	}
 }
 
@@ -181,14 +191,25 @@ func (p *proxy) validateSecret(w http.ResponseWriter, r *http.Request) {
 	if _, err := p.parseURL(w, r, apc.URLPathTokens.L, 0, false); err != nil {
 		return
 	}
-	cksum := cos.NewCksumHash(cos.ChecksumSHA256)
-	cksum.H.Write([]byte(p.authn.secret))
-	cksum.Finalize()
-
 	cluConf := &authn.ServerConf{}
 	if err := cmn.ReadJSON(w, r, cluConf); err != nil {
 		return
 	}
+	// AuthN signing with a private key (RS256, ES256): validate the (admin) token
+	if cluConf.Secret == "" {
+		tk, err := p.validateToken(r.Header)
+		if err != nil {
+			p.writeErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if !tk.IsAdmin {
+			p.writeErrf(w, r, "%s: expecting AuthN admin token, got %s", p, tk)
+		}
+		return
+	}
+	cksum := cos.NewCksumHash(cos.ChecksumSHA256)
+	cksum.H.Write([]byte(p.authkeys.secret))
+	cksum.Finalize()
 	if cksum.Val() != cluConf.Secret {
 		p.writeErrf(w, r, "%s: invalid secret sha256(%q)", p, cos.SHead(cluConf.Secret))
 	}
@@ -249,16 +270,16 @@ func aceErrToCode(err error) (status int) {
 	case tok.ErrNoToken, tok.ErrInvalidToken:
 		status = http.StatusUnauthorized
 	default:
+		if cmn.IsErrRateLimited(err) {
+			return http.StatusTooManyRequests
+		}
 		status = http.StatusForbidden
 	}
 	return status
 }
 
 func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
-	var (
-		tk     *tok.Token
-		bucket *cmn.Bck
-	)
+	var tk *tok.Token
 	if p.isIntraCall(hdr, false /*from primary*/) == nil {
 		return nil
 	}
@@ -271,7 +292,22 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 			}
 			return err
 		}
-		uid := p.owner.smap.Get().UUID
+	}
+	if err = p.checkPermissions(tk, bck, ace); err != nil {
+		return err
+	}
+	// (PUT and APPEND: bytes per second are checked against the Content-Length)
+	size, _ := strconv.ParseInt(hdr.Get(cos.HdrContentLength), 10, 64)
+	return p.rlim.allow(bck, tk, size)
+}
+
+// given validated token (nil when AuthN is disabled)
+func (p *proxy) checkPermissions(tk *tok.Token, bck *meta.Bck, ace apc.AccessAttrs) error {
+	if tk != nil {
+		var (
+			bucket *cmn.Bck
+			uid    = p.owner.smap.Get().UUID
+		)
 		if bck != nil {
 			bucket = bck.Bucket()
 		}
@@ -297,3 +333,59 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 	}
 	return bck.Allow(ace)
 }
+
+// S3 API: with AuthN enabled, the request must carry either a token (as in: native API)
+// or AWS Signature V4 computed with one of the AuthN-managed S3 access keys (see s3keys.go)
+func (p *proxy) s3checkAccess(w http.ResponseWriter, r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
+	if err = p.s3access(r, bck, ace); err != nil {
+		ecode := aceErrToCode(err)
+		if e, ok := err.(*s3.ErrAuth); ok {
+			ecode = e.Status()
+		}
+		s3.WriteErr(w, r, err, ecode)
+	}
+	return err
+}
+
+func (p *proxy) s3access(r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) error {
+	if !cmn.Rom.AuthEnabled() {
+		if bck == nil {
+			return nil
+		}
+		if err := bck.Allow(ace); err != nil {
+			return err
+		}
+		return p.rlim.allow(bck, nil, r.ContentLength)
+	}
+	if p.isIntraCall(r.Header, false /*from primary*/) == nil {
+		if bck == nil {
+			return nil
+		}
+		return bck.Allow(ace)
+	}
+	sig, err := s3.ParseSigV4(r)
+	if err != nil {
+		return err
+	}
+	if sig == nil {
+		return p.access(r.Header, bck, ace)
+	}
+	key := p.owner.s3keys.lookup(sig.AccessKey)
+	if key == nil {
+		// not ours - pass-through to be authenticated by AWS (see feat.S3PresignedRequest)
+		if bck != nil && bck.IsRemoteS3() && bck.Props.Features.IsSet(feat.S3PresignedRequest) {
+			if err := bck.Allow(ace); err != nil {
+				return err
+			}
+			return p.rlim.allow(bck, nil, r.ContentLength)
+		}
+		return s3.NewErrAuth(s3.ErrCodeInvalidAccessKeyID, "access key ID %q does not exist", sig.AccessKey)
+	}
+	if err := sig.Verify(r, key.secret); err != nil {
+		return err
+	}
+	if err := p.checkPermissions(key.tk, bck, ace); err != nil {
+		return err
+	}
+	return p.rlim.allow(bck, key.tk, r.ContentLength)
+}
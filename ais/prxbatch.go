@@ -0,0 +1,136 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// get-batch (see apc.GetBatchMsg and xs.XactGetBatch):
+// proxy validates the request and redirects it to a (randomly chosen) designated target (DT)
+
+// GET /v1/get-batch[/bucket-name]
+func (p *proxy) getBatchHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.cluStartedWithRetry() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	items, err := p.parseURL(w, r, apc.URLPathGetBatch.L, 0, true)
+	if err != nil {
+		return
+	}
+	msg := &apc.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	query := r.URL.Query()
+	if err := gbNormalize(msg, items, query); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// entries' buckets
+	bcks := make(map[string]struct{}, 2)
+	for i := range msg.In {
+		in := &msg.In[i]
+		key := in.Provider + apc.BckProviderSeparator + in.Bucket
+		if _, ok := bcks[key]; ok {
+			continue
+		}
+		bcks[key] = struct{}{}
+		bckArgs := allocBctx()
+		{
+			bckArgs.p = p
+			bckArgs.w = w
+			bckArgs.r = r
+			bckArgs.bck = meta.NewBck(in.Bucket, in.Provider, cmn.NsGlobal)
+			bckArgs.query = query
+			bckArgs.perms = apc.AceGET
+			bckArgs.createAIS = false
+		}
+		_, err := bckArgs.initAndTry()
+		freeBctx(bckArgs)
+		if err != nil {
+			p.statsT.IncErr(stats.ErrGetCount)
+			return
+		}
+	}
+
+	// designated target
+	var (
+		started = time.Now()
+		smap    = p.owner.smap.get()
+		uuid    = cos.GenUUID()
+	)
+	tsi, err := smap.HrwName2T(cos.UnsafeB(uuid))
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(apc.ActGetBatch, uuid, len(msg.In), "=>", tsi.StringEx())
+	}
+	query.Set(apc.QparamUUID, uuid)
+	r.URL.RawQuery = query.Encode()
+
+	// (307 to preserve the request body)
+	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+// validate and fill-in defaults (proxy and DT)
+func gbNormalize(msg *apc.GetBatchMsg, items []string, query url.Values) (err error) {
+	if len(msg.In) == 0 {
+		return errors.New(apc.ActGetBatch + ": empty request")
+	}
+	if len(msg.In) > apc.GetBatchMaxEntries {
+		return fmt.Errorf("%s: too many entries (%d) - the maximum is %d", apc.ActGetBatch, len(msg.In), apc.GetBatchMaxEntries)
+	}
+	if ns := query.Get(apc.QparamNamespace); ns != "" && !cmn.ParseNsUname(ns).IsGlobal() {
+		return fmt.Errorf("%s: buckets in namespaces (%q) are not supported", apc.ActGetBatch, ns)
+	}
+	if msg.Mime == "" {
+		msg.Mime = archive.ExtTar
+	} else if msg.Mime, err = archive.Mime(msg.Mime, ""); err != nil {
+		return err
+	}
+	var bck cmn.Bck
+	if len(items) > 0 {
+		bck.Name = items[0]
+		bck.Provider = query.Get(apc.QparamProvider)
+	}
+	for i := range msg.In {
+		in := &msg.In[i]
+		if in.Bucket == "" {
+			if bck.Name == "" {
+				return fmt.Errorf("%s: bucket is not specified for %q (entry #%d)", apc.ActGetBatch, in.ObjName, i)
+			}
+			in.Bucket, in.Provider = bck.Name, bck.Provider
+		}
+		if in.Provider, err = cmn.NormalizeProvider(in.Provider); err != nil {
+			return err
+		}
+		if err := cmn.ValidOname(in.ObjName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
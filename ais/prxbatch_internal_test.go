@@ -0,0 +1,46 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+)
+
+func TestGetBatchNormalize(t *testing.T) {
+	newMsg := func() *apc.GetBatchMsg {
+		return &apc.GetBatchMsg{In: []apc.GetBatchIn{{ObjName: "o1"}, {ObjName: "o2", Bucket: "other", Provider: "ais"}}}
+	}
+
+	msg := newMsg()
+	if err := gbNormalize(msg, []string{"bck"}, url.Values{}); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Mime != archive.ExtTar || msg.In[0].Bucket != "bck" || msg.In[0].Provider != apc.AIS {
+		t.Fatalf("unexpected defaults: %+v", msg)
+	}
+
+	msg = newMsg()
+	msg.In[1].ObjName = "../o2"
+	if err := gbNormalize(msg, []string{"bck"}, url.Values{}); err == nil {
+		t.Fatal("expected invalid object name to be rejected")
+	}
+
+	// global namespace is fine
+	q := url.Values{apc.QparamNamespace: []string{cmn.NsGlobal.Uname()}}
+	if err := gbNormalize(newMsg(), []string{"bck"}, q); err != nil {
+		t.Fatal(err)
+	}
+	for _, ns := range []cmn.Ns{{Name: "ns"}, {UUID: "remais"}} {
+		q := url.Values{apc.QparamNamespace: []string{ns.Uname()}}
+		if err := gbNormalize(newMsg(), []string{"bck"}, q); err == nil {
+			t.Fatalf("expected namespace %q to be rejected", ns.Uname())
+		}
+	}
+}
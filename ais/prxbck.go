@@ -198,7 +198,7 @@ func (bctx *bctx) initAndTry() (bck *meta.Bck, err error) {
 		// user GET and PUT requests: making a _silent_ exception for assorted error codes
 		// (counting them via stats.IncErr though)
 		if bctx.perms == apc.AceGET || bctx.perms == apc.AcePUT {
-			if ecode == http.StatusUnauthorized || ecode == http.StatusForbidden {
+			if ecode == http.StatusUnauthorized || ecode == http.StatusForbidden || ecode == http.StatusTooManyRequests {
 				bctx.p.writeErr(bctx.w, bctx.r, err, ecode, Silent)
 				return
 			}
@@ -138,6 +138,14 @@ func (p *proxy) bsummCollect(qbck *cmn.QueryBcks, msg *apc.BsummCtrlMsg) (_ cmn.
 	summaries.Finalize(dsize, cmn.Rom.TestingEnv())
 	freeBcastRes(results)
 
+	// bucket quotas (see cmn/quota.go)
+	bmd := p.owner.bmd.get()
+	for _, summ := range summaries {
+		if props, present := bmd.Get(meta.CloneBck(&summ.Bck)); present && props.Quota.IsSet() {
+			summ.Quota = props.Quota.Usage(int64(summ.TotalSize.PresentObjs), int64(summ.ObjCount.Present))
+		}
+	}
+
 	switch {
 	case numPartial == 0 && numAccepted == 0:
 		status = http.StatusOK
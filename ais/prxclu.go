@@ -885,6 +885,7 @@ func (p *proxy) _joinedPost(ctx *smapModifier, clone *smapX) {
 func (p *proxy) _joinedFinal(ctx *smapModifier, clone *smapX) {
 	var (
 		tokens = p.authn.revokedTokenList()
+		s3keys = p.owner.s3keys.get()
 		bmd    = p.owner.bmd.get()
 		etlMD  = p.owner.etl.get()
 		aisMsg = p.newAmsg(ctx.msg, bmd)
@@ -921,6 +922,9 @@ func (p *proxy) _joinedFinal(ctx *smapModifier, clone *smapX) {
 	if tokens != nil {
 		pairs = append(pairs, revsPair{tokens, aisMsg})
 	}
+	if s3keys != nil {
+		pairs = append(pairs, revsPair{s3keys, aisMsg})
+	}
 	_ = p.metasyncer.sync(pairs...)
 	p.syncNewICOwners(ctx.smap, clone)
 }
@@ -2265,8 +2269,16 @@ func mustRebalance(ctx *smapModifier, cur *smapX) bool {
 	// active <=> inactive transition
 	debug.Assert(prev.version() < cur.version())
 	for _, tsi := range cur.Tmap {
+		if tsi.InMaintOrDecomm() {
+			continue
+		}
 		// added an active one or activated previously inactive
-		if !tsi.InMaintOrDecomm() && prev.GetActiveNode(tsi.ID()) == nil {
+		psi := prev.GetActiveNode(tsi.ID())
+		if psi == nil {
+			return true
+		}
+		// changed failure domain (EC placement - see meta.HrwTargetList)
+		if psi.Domain != tsi.Domain {
 			return true
 		}
 	}
@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -115,6 +116,10 @@ func (p *proxy) httpetlput(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := etl.CheckPlatform(initMsg); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
 
 	// must be new
 	etlMD := p.owner.etl.get()
@@ -122,6 +127,13 @@ func (p *proxy) httpetlput(w http.ResponseWriter, r *http.Request) {
 		p.writeErrf(w, r, "%s: etl[%s] already exists", p, initMsg.Name())
 		return
 	}
+	// pipeline's stages must be running
+	if msg, ok := initMsg.(*etl.InitPipelineMsg); ok {
+		if err := msg.ValidateStages(etlMD.ETLs); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	}
 
 	// add to cluster MD and start running
 	if err := p.startETL(w, initMsg, true /*add to etlMD*/); err != nil {
@@ -191,6 +203,11 @@ func (p *proxy) httpetldel(w http.ResponseWriter, r *http.Request) {
 
 func (p *proxy) _deleteETLPre(ctx *etlMDModifier, clone *etlMD) (err error) {
 	debug.AssertNoErr(k8s.ValidateEtlName(ctx.etlName))
+	for _, msg := range clone.ETLs {
+		if pmsg, ok := msg.(*etl.InitPipelineMsg); ok && cos.StringInSlice(ctx.etlName, pmsg.Stages) {
+			return fmt.Errorf("%s: cannot delete etl[%s] - used by pipeline %q", p, ctx.etlName, pmsg.Name())
+		}
+	}
 	if exists := clone.del(ctx.etlName); !exists {
 		err = cos.NewErrNotFound(p, "etl job "+ctx.etlName)
 	}
@@ -105,6 +105,8 @@ func (p *proxy) forwardCP(w http.ResponseWriter, r *http.Request, msg *apc.ActMs
 			nlog.Infoln(p.String(), "forwarding [", s, "] to the primary", pname)
 		}
 	}
+	delCORS(w, r)
+	auditSkip(w) // (the primary will)
 	primary.rp.ServeHTTP(w, r)
 	return true // forwarded
 }
@@ -152,6 +154,7 @@ func (p *proxy) reverseNodeRequest(w http.ResponseWriter, r *http.Request, si *m
 // 2. primary => remais
 func (p *proxy) reverseRequest(w http.ResponseWriter, r *http.Request, nodeID string, parsedURL *url.URL) {
 	rproxy := p.rproxy.loadOrStore(nodeID, parsedURL, p.rpErrHandler)
+	delCORS(w, r)
 	rproxy.ServeHTTP(w, r)
 }
 
@@ -26,8 +26,6 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
-// TODO: `checkAccess` permissions (see ais/proxy.go)
-
 var (
 	errS3Req    = errors.New("invalid s3 request")
 	errS3Obj    = errors.New("missing or empty object name")
@@ -46,6 +44,9 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if p.s3cors(w, r, apiItems) {
+		return // preflight
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -62,7 +63,7 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		if len(apiItems) == 0 {
 			// list all buckets; NOTE: compare with `p.easyURLHandler` and see
 			// "list buckets for a given provider" comment there
-			p.bckNamesFromBMD(w)
+			p.bckNamesFromBMD(w, r)
 			return
 		}
 		var (
@@ -72,6 +73,18 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
 		)
+		if lifecycle && len(apiItems) == 1 {
+			p.getBckLifecycleS3(w, r, apiItems[0])
+			return
+		}
+		if cors && len(apiItems) == 1 {
+			p.getBckCORSS3(w, r, apiItems[0])
+			return
+		}
+		if q.Has(s3.QparamObjectLock) && len(apiItems) == 1 {
+			p.getBckObjLockS3(w, r, apiItems[0])
+			return
+		}
 		if lifecycle || policy || cors || acl {
 			p.unsupported(w, r, apiItems[0])
 			return
@@ -83,6 +96,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamVersions) {
+				p.listObjectVersionsS3(w, r, apiItems[0], q)
+				return
+			}
 			p.listObjectsS3(w, r, apiItems[0], q)
 			return
 		}
@@ -100,6 +117,18 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				p.putBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, cors := q[s3.QparamCORS]; cors {
+				p.putBckCORSS3(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamObjectLock) {
+				p.putBckObjLockS3(w, r, apiItems[0])
+				return
+			}
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
@@ -131,6 +160,14 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				p.delBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, cors := q[s3.QparamCORS]; cors {
+				p.delBckCORSS3(w, r, apiItems[0])
+				return
+			}
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
@@ -143,7 +180,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 
 // GET /s3
 // NOTE: unlike native API, this one is limited to list only those that are currently present in the BMD.
-func (p *proxy) bckNamesFromBMD(w http.ResponseWriter) {
+func (p *proxy) bckNamesFromBMD(w http.ResponseWriter, r *http.Request) {
+	if err := p.s3checkAccess(w, r, nil, apc.AceListBuckets); err != nil {
+		return
+	}
 	var (
 		bmd  = p.owner.bmd.get()
 		resp = s3.NewListBucketResult() // https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html
@@ -161,6 +201,9 @@ func (p *proxy) bckNamesFromBMD(w http.ResponseWriter) {
 
 // PUT /s3/<bucket-name> (i.e., create bucket)
 func (p *proxy) putBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	if err := p.s3checkAccess(w, r, nil, apc.AceCreateBucket); err != nil {
+		return
+	}
 	msg := apc.ActMsg{Action: apc.ActCreateBck}
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
@@ -181,14 +224,17 @@ func (p *proxy) delBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceDestroyBucket); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AceDestroyBucket); err != nil {
 		return
 	}
 	msg := apc.ActMsg{Action: apc.ActDestroyBck}
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
 	}
+	if err := checkBckObjLock(bck, msg.Action); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	if err := p.destroyBucket(&msg, bck); err != nil {
 		ecode := http.StatusInternalServerError
 		if _, ok := err.(*cmn.ErrBucketAlreadyExists); ok {
@@ -209,8 +255,7 @@ func (p *proxy) handleMptUpload(w http.ResponseWriter, r *http.Request, parts []
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AcePUT); err != nil {
 		return
 	}
 	objName := s3.ObjName(parts)
@@ -237,8 +282,7 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AceObjDELETE); err != nil {
 		return
 	}
 	decoder := xml.NewDecoder(r.Body)
@@ -299,8 +343,7 @@ func (p *proxy) headBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceBckHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AceBckHEAD); err != nil {
 		return
 	}
 	// From https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadBucket.html:
@@ -323,6 +366,9 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	if bck == nil {
 		return
 	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
 	amsg := &apc.ActMsg{Action: apc.ActList}
 
 	// currently, always forwarding
@@ -381,6 +427,97 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	lst = nil
 }
 
+// GET /s3/<bucket-name>?versions
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// current versions (via list-objects) plus prior versions (see s3.ListVersionsResult)
+// aggregated from all targets; each source contributes at most max-keys + 1 versions
+// that follow the markers
+func (p *proxy) listObjectVersionsS3(w http.ResponseWriter, r *http.Request, bucket string, q url.Values) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
+	amsg := &apc.ActMsg{Action: apc.ActList}
+	if p.forwardCP(w, r, amsg, lsotag+" "+bck.String()) {
+		return
+	}
+	var (
+		resp  = s3.NewListVersionsResult(bucket, q)
+		limit = resp.MaxKeys + 1
+		lsmsg = &apc.LsoMsg{TimeFormat: cos.ISO8601, Prefix: resp.Prefix, PageSize: int64(limit)}
+	)
+	if bck.IsAIS() {
+		lsmsg.StartAfter = resp.KeyMarker // (remote buckets: from the beginning)
+	}
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsVersion)
+	amsg.Value = lsmsg
+	if err := p.lsVersionsS3(bck, amsg, lsmsg, r.Header, resp, limit); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+
+	if bck.IsAIS() && bck.Props.Versioning.KeepPrior > 0 {
+		smap := p.owner.smap.get()
+		for _, si := range smap.Tmap {
+			if si.InMaintOrDecomm() {
+				continue
+			}
+			cargs := allocCargs()
+			cargs.si = si
+			cargs.req = cmn.HreqArgs{Method: http.MethodGet, Base: si.URL(cmn.NetPublic), Path: r.URL.Path, Query: q}
+			res := p.call(cargs, smap)
+			b, err := res.bytes, res.err
+			freeCargs(cargs)
+			freeCR(res)
+			if err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			priors := &s3.ListVersionsResult{}
+			if err := xml.Unmarshal(b, priors); err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			resp.Versions = append(resp.Versions, priors.Versions...)
+		}
+	}
+	resp.Finalize()
+
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// current versions: list pages until there's enough
+func (p *proxy) lsVersionsS3(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr http.Header,
+	resp *s3.ListVersionsResult, limit int) error {
+	smap := p.owner.smap.get()
+	for {
+		beg := mono.NanoTime()
+		page, err := p.lsPage(bck, amsg, lsmsg, hdr, smap)
+		if err != nil {
+			return err
+		}
+		p.statsT.AddMany(
+			cos.NamedVal64{Name: stats.ListCount, Value: 1},
+			cos.NamedVal64{Name: stats.ListLatency, Value: mono.SinceNano(beg)},
+		)
+		resp.FromLsoResult(page, lsmsg)
+		if page.ContinuationToken == "" || len(resp.Versions) >= limit {
+			return nil
+		}
+		lsmsg.UUID = page.UUID
+		lsmsg.ContinuationToken = page.ContinuationToken
+		lsmsg.StartAfter = ""
+		amsg.Value = lsmsg
+	}
+}
+
 func (p *proxy) lsAllPagesS3(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr http.Header) (lst *cmn.LsoRes, _ error) {
 	smap := p.owner.smap.get()
 	for pageNum := 1; ; pageNum++ {
@@ -436,8 +573,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bckSrc == nil {
 		return
 	}
-	if err := bckSrc.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bckSrc, apc.AceGET); err != nil {
 		return
 	}
 	// dst
@@ -445,8 +581,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bckDst == nil {
 		return
 	}
-	if err := bckDst.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bckDst, apc.AcePUT); err != nil {
 		return
 	}
 
@@ -465,15 +600,18 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	p.s3Redirect(w, r, si, redirectURL, bckDst.Name)
 }
 
-// PUT /s3/<bucket-name>/<object-name> - with empty `cos.S3HdrObjSrc`
+// PUT /s3/<bucket-name>/<object-name>[?tagging] - with empty `cos.S3HdrObjSrc`
 // (compare with p.copyObjS3)
 func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	bck := p.initByNameOnly(w, r, items[0] /*bucket*/)
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	ace := apc.AcePUT
+	if q := r.URL.Query(); q.Has(s3.QparamTagging) || q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold) {
+		ace = apc.AceObjUpdate // (compare w/ p.httpobjpatch)
+	}
+	if err := p.s3checkAccess(w, r, bck, ace); err != nil {
 		return
 	}
 	if len(items) < 2 {
@@ -507,8 +645,7 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AceGET); err != nil {
 		return
 	}
 	if listMultipart {
@@ -597,8 +734,7 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceObjHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.s3checkAccess(w, r, bck, apc.AceObjHEAD); err != nil {
 		return
 	}
 	objName := s3.ObjName(items)
@@ -619,7 +755,7 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 	p.reverseNodeRequest(w, r, si)
 }
 
-// DELETE /s3/<bucket-name>/<object-name>
+// DELETE /s3/<bucket-name>/<object-name>[?tagging]
 func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if len(items) < 2 {
 		s3.WriteErr(w, r, errS3BckObj, 0)
@@ -629,8 +765,11 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 	if bck == nil {
 		return
 	}
-	if err := bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	ace := apc.AceObjDELETE
+	if r.URL.Query().Has(s3.QparamTagging) {
+		ace = apc.AceObjUpdate
+	}
+	if err := p.s3checkAccess(w, r, bck, ace); err != nil {
 		return
 	}
 	objName := s3.ObjName(items)
@@ -659,6 +798,9 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	if bck == nil {
 		return
 	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
 	resp := s3.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	sgl := p.gmm.NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -667,7 +809,7 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// GET /s3/<bucket-name>?policy|acl
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, ecode)
@@ -686,6 +828,9 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	if bck == nil {
 		return
 	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	vconf := &s3.VersioningConfiguration{}
 	if err := decoder.Decode(vconf); err != nil {
@@ -707,6 +852,219 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	}
 }
 
+// GET /s3/<bucket-name>?lifecycle
+func (p *proxy) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		s3.WriteErr(w, r, s3.ErrNoSuchLifecycle, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewLifecycleConfiguration(&bck.Props.Lifecycle)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?lifecycle
+func (p *proxy) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	if !bck.IsAIS() {
+		err := fmt.Errorf("bucket lifecycle is not supported for %s buckets", bck.Provider)
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
+	decoder := xml.NewDecoder(r.Body)
+	lconf := &s3.LifecycleConfiguration{}
+	if err := decoder.Decode(lconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := lconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{Lifecycle: &cmn.LifecycleConfToSet{Rules: &conf.Rules}})
+}
+
+// DELETE /s3/<bucket-name>?lifecycle
+func (p *proxy) delBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) > 0 {
+		rules := []cmn.LifecycleRule{}
+		if !p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{Lifecycle: &cmn.LifecycleConfToSet{Rules: &rules}}) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?cors
+func (p *proxy) getBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if len(bck.Props.CORS.Rules) == 0 {
+		s3.WriteErr(w, r, s3.ErrNoSuchCORS, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewCORSConfiguration(&bck.Props.CORS)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?cors
+func (p *proxy) putBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	decoder := xml.NewDecoder(r.Body)
+	cconf := &s3.CORSConfiguration{}
+	if err := decoder.Decode(cconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := cconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{CORS: &cmn.CORSConfToSet{Rules: &conf.Rules}})
+}
+
+// DELETE /s3/<bucket-name>?cors
+func (p *proxy) delBckCORSS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	if len(bck.Props.CORS.Rules) > 0 {
+		rules := []cmn.CORSRule{}
+		if !p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{CORS: &cmn.CORSConfToSet{Rules: &rules}}) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?object-lock
+func (p *proxy) getBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if !bck.Props.ObjLock.Enabled {
+		s3.WriteErr(w, r, s3.ErrNoSuchObjLockConf, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewObjectLockConfiguration(&bck.Props.ObjLock)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?object-lock
+func (p *proxy) putBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.s3checkAccess(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	if !bck.IsAIS() {
+		err := fmt.Errorf("object lock is not supported for %s buckets", bck.Provider)
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
+	decoder := xml.NewDecoder(r.Body)
+	oconf := &s3.ObjectLockConfiguration{}
+	if err := decoder.Decode(oconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	toSet, err := oconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{ObjLock: toSet})
+}
+
+// (common for PUT and DELETE bucket lifecycle, CORS, and object lock)
+func (p *proxy) setBpropsS3(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bck *meta.Bck,
+	propsToUpdate *cmn.BpropsToSet) bool {
+	nprops, err := p.makeNewBckProps(bck, propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	return true
+}
+
 //
 // misc. utils
 //
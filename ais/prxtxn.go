@@ -402,6 +402,9 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		if err := bprops.ObjLock.ValidateUpdate(bck.Bucket(), &nprops.ObjLock); err != nil {
+			return "", err
+		}
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -807,19 +810,27 @@ func (p *proxy) validateECConf(bck *meta.Bck, confToSet *cmn.ECConfToSet, currCo
 	if confToSet.ObjSizeLimit != nil {
 		newConf.ObjSizeLimit = *confToSet.ObjSizeLimit
 	}
+	if confToSet.DomainTolerance != nil {
+		newConf.DomainTolerance = *confToSet.DomainTolerance
+	}
 
 	if currConf.Enabled {
-		err := fmt.Errorf("%s: EC is already enabled on the bucket %s", p, bck.Cname(""))
 		if newConf.DataSlices != currConf.DataSlices || newConf.ParitySlices != currConf.ParitySlices {
-			// Changing data or parity slice count on the fly is unsupported
-			return err
+			// re-encode existing objects in place (see ec.XactBckEncode)
+			nlog.Infof("%s: re-encoding %s from (D=%d, P=%d) to (D=%d, P=%d)", p, bck.Cname(""),
+				currConf.DataSlices, currConf.ParitySlices, newConf.DataSlices, newConf.ParitySlices)
+		} else {
+			err := fmt.Errorf("%s: EC is already enabled on the bucket %s", p, bck.Cname(""))
+			nlog.Warningf("%v: old %+v, new %+v", err, currConf, newConf)
 		}
-		nlog.Warningf("%v: old %+v, new %+v", err, currConf, newConf)
 	}
 
 	smap := p.owner.smap.get()
 	numTs := smap.CountActiveTs()
-	return newConf.ValidateAsProps(numTs)
+	if err := newConf.ValidateAsProps(numTs); err != nil {
+		return err
+	}
+	return newConf.ValidateDomains(smap.CountDomains())
 }
 
 // compare w/ bmodSetProps
@@ -863,6 +874,14 @@ func (p *proxy) beginRmTarget(si *meta.Snode, msg *apc.ActMsg) error {
 	return c.begin(si)
 }
 
+// buckets with object lock enabled cannot be destroyed or renamed (see cmn/objlock.go)
+func checkBckObjLock(bck *meta.Bck, action string) error {
+	if bck.Props == nil || !bck.Props.ObjLock.Enabled {
+		return nil
+	}
+	return cmn.NewErrObjLocked(bck.Cname(""), "object lock is enabled (cannot "+action+")")
+}
+
 // destroy bucket: { begin -- commit }
 func (p *proxy) destroyBucket(msg *apc.ActMsg, bck *meta.Bck) error {
 	nlp := newBckNLP(bck)
@@ -1095,6 +1114,9 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 	)
 	nprops = bprops.Clone()
 	nprops.Apply(propsToUpdate)
+	if err = bprops.ObjLock.ValidateUpdate(bck.Bucket(), &nprops.ObjLock); err != nil {
+		return
+	}
 	if bck.IsCloud() {
 		bv, nv := bck.VersionConf().Enabled, nprops.Versioning.Enabled
 		if bv != nv {
@@ -1105,10 +1127,10 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 		}
 	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
-		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
+		// NOTE: changing (D, P) is supported - existing objects get re-encoded (see _reEC)
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
-		if !sameSlices || (!sameLimit && !propsToUpdate.Force) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
+		if !sameLimit && !propsToUpdate.Force {
+			err = fmt.Errorf("%s: once enabled, EC object size limit cannot change (use force to override)", p.si)
 			return
 		}
 	} else if nprops.EC.Enabled {
@@ -1144,6 +1166,9 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 		nlog.Warningln("Ignoring soft error:", err)
 		err = nil
 	}
+	if err == nil {
+		err = nprops.EC.ValidateDomains(p.owner.smap.get().CountDomains())
+	}
 	return
 }
 
@@ -0,0 +1,205 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Request rate limiting (see cmn/ratelimit.go): each node maintains its own token buckets
+// - one per rate-limited bucket and one per rate-limited AuthN user - created on demand
+// and removed once idle.
+// NOTE: the limits are enforced per node - with N gateways (or targets) that clients
+// load-balance across, the cluster-wide rate may reach N times the configured one.
+
+const (
+	rlimIval = time.Minute      // housekeeping
+	rlimIdle = 10 * time.Minute // remove limiters not used for so long
+)
+
+type (
+	rlimiter struct {
+		reqs  *cos.TokenBucket // nil when unlimited
+		bytes *cos.TokenBucket // ditto
+		conf  cmn.RateLimitConf
+		used  atomic.Int64 // mono time
+	}
+	rlimiters struct {
+		statsT stats.Tracker
+		m      sync.Map // "b" + bucket ID | "u" + user ID => *rlimiter
+	}
+)
+
+func newRlimiter(conf *cmn.RateLimitConf) *rlimiter {
+	rl := &rlimiter{conf: *conf}
+	if conf.RPS > 0 {
+		rl.reqs = cos.NewTokenBucket(conf.RPS)
+	}
+	if conf.BPS > 0 {
+		rl.bytes = cos.NewTokenBucket(int64(conf.BPS))
+	}
+	return rl
+}
+
+// (zero size: not charging the bytes but still failing when in debt - see take)
+func (rl *rlimiter) allow(size int64) (ok, bps bool) {
+	if rl.reqs != nil && !rl.reqs.TryTake(1) {
+		return false, false
+	}
+	if rl.bytes != nil && !rl.bytes.TryTake(size) {
+		if rl.reqs != nil {
+			rl.reqs.Put(1)
+		}
+		return false, true
+	}
+	return true, false
+}
+
+// give back what's been taken by allow
+func (rl *rlimiter) undo(size int64) {
+	if rl.reqs != nil {
+		rl.reqs.Put(1)
+	}
+	if rl.bytes != nil && size > 0 {
+		rl.bytes.Put(size)
+	}
+}
+
+///////////////
+// rlimiters //
+///////////////
+
+func (rls *rlimiters) init(statsT stats.Tracker) {
+	rls.statsT = statsT
+	hk.Reg("rate-limit"+hk.NameSuffix, rls.housekeep, rlimIval)
+}
+
+// get or create; (re)create when the limits change
+func (rls *rlimiters) get(key string, conf *cmn.RateLimitConf) *rlimiter {
+	v, loaded := rls.m.Load(key)
+	if !loaded {
+		v, loaded = rls.m.LoadOrStore(key, newRlimiter(conf))
+	}
+	rl := v.(*rlimiter)
+	if loaded && rl.conf != *conf {
+		nrl := newRlimiter(conf)
+		if rls.m.CompareAndSwap(key, rl, nrl) {
+			rl = nrl
+		} else if v, ok := rls.m.Load(key); ok {
+			rl = v.(*rlimiter) // (raced with another update)
+		}
+	}
+	rl.used.Store(mono.NanoTime())
+	return rl
+}
+
+func bckRlimKey(bck *meta.Bck) string { return "b" + strconv.FormatUint(bck.Props.BID, 36) }
+
+// allow returns cmn.ErrRateLimited if the request - of `size` bytes when known in advance,
+// zero otherwise - exceeds the bucket's or the user's (when tk != nil) limits;
+// when rejected, no tokens are consumed
+func (rls *rlimiters) allow(bck *meta.Bck, tk *tok.Token, size int64) error {
+	var brl *rlimiter
+	if bck != nil && bck.Props != nil && bck.Props.RateLimit.IsSet() {
+		brl = rls.get(bckRlimKey(bck), &bck.Props.RateLimit)
+		if ok, bps := brl.allow(size); !ok {
+			rls.statsT.Inc(stats.RateLimitBckCount)
+			return cmn.NewErrRateLimited("bucket", bck.Cname(""), bps)
+		}
+	}
+	if tk != nil && tk.RateLimit != nil && tk.RateLimit.IsSet() && !tk.IsAdmin {
+		if ok, bps := rls.get("u"+tk.UserID, tk.RateLimit).allow(size); !ok {
+			if brl != nil {
+				brl.undo(size)
+			}
+			rls.statsT.Inc(stats.RateLimitUserCount)
+			return cmn.NewErrRateLimited("user", tk.UserID, bps)
+		}
+	}
+	return nil
+}
+
+// GET that's been redirected by a gateway: the latter accounts for the request
+// but not for the bytes - fail while the bucket or the user (when tk != nil)
+// is in (bytes) debt (see take)
+func (rls *rlimiters) allowGet(bck *meta.Bck, tk *tok.Token) error {
+	if bck.Props != nil && bck.Props.RateLimit.BPS > 0 {
+		if rl := rls.get(bckRlimKey(bck), &bck.Props.RateLimit); rl.bytes != nil && !rl.bytes.TryTake(0) {
+			rls.statsT.Inc(stats.RateLimitBckCount)
+			return cmn.NewErrRateLimited("bucket", bck.Cname(""), true)
+		}
+	}
+	if userBPS(tk) {
+		if rl := rls.get("u"+tk.UserID, tk.RateLimit); rl.bytes != nil && !rl.bytes.TryTake(0) {
+			rls.statsT.Inc(stats.RateLimitUserCount)
+			return cmn.NewErrRateLimited("user", tk.UserID, true)
+		}
+	}
+	return nil
+}
+
+// charge the bucket and the user (when tk != nil) for the bytes that were not known in advance (e.g., GET)
+func (rls *rlimiters) take(bck *meta.Bck, tk *tok.Token, size int64) {
+	if size <= 0 {
+		return
+	}
+	if bck.Props != nil && bck.Props.RateLimit.BPS > 0 {
+		if rl := rls.get(bckRlimKey(bck), &bck.Props.RateLimit); rl.bytes != nil {
+			rl.bytes.Take(size)
+		}
+	}
+	if userBPS(tk) {
+		if rl := rls.get("u"+tk.UserID, tk.RateLimit); rl.bytes != nil {
+			rl.bytes.Take(size)
+		}
+	}
+}
+
+func userBPS(tk *tok.Token) bool {
+	return tk != nil && tk.RateLimit != nil && tk.RateLimit.BPS > 0 && !tk.IsAdmin
+}
+
+func (rls *rlimiters) housekeep(now int64) time.Duration {
+	rls.m.Range(func(k, v any) bool {
+		if time.Duration(now-v.(*rlimiter).used.Load()) > rlimIdle {
+			rls.m.Delete(k)
+		}
+		return true
+	})
+	return rlimIval
+}
+
+// S3 requests that clients send directly to targets (i.e., not redirected by any gateway)
+// get rate-limited by the targets themselves (compare w/ p.access and p.s3access)
+func (t *target) s3ratelim(r *http.Request, bname string) error {
+	if t.isVerifiedRedirect(r, r.URL.Query()) || t.isIntraCall(r.Header, false /*from primary*/) == nil {
+		return nil
+	}
+	bck, err, _ := meta.InitByNameOnly(bname, t.owner.bmd)
+	if err != nil {
+		return nil // (to be handled by the respective handler)
+	}
+	var tk *tok.Token
+	if sig, err := s3.ParseSigV4(r); err == nil && sig != nil {
+		// charge the user only when the signature checks out
+		if key := t.owner.s3keys.lookup(sig.AccessKey); key != nil && sig.Verify(r, key.secret) == nil {
+			tk = key.tk
+		}
+	}
+	return t.rlim.allow(bck, tk, r.ContentLength)
+}
@@ -0,0 +1,85 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+)
+
+func TestRateLimitUserReject(tt *testing.T) {
+	var (
+		rls = rlimiters{statsT: mock.NewStatsTracker()}
+		bck = meta.NewBck("rlim", apc.AIS, cmn.NsGlobal, &cmn.Bprops{BID: 1, RateLimit: cmn.RateLimitConf{RPS: 10}})
+		tk  = &tok.Token{UserID: "u", RateLimit: &cmn.RateLimitConf{RPS: 1}}
+	)
+	if err := rls.allow(bck, tk, 0); err != nil {
+		tt.Fatal(err)
+	}
+	// the user's limit is exhausted - the bucket's must remain intact
+	for range 20 {
+		if err := rls.allow(bck, tk, 0); !cmn.IsErrRateLimited(err) {
+			tt.Fatalf("expected user rate-limited, got %v", err)
+		}
+	}
+	for i := range 9 {
+		if err := rls.allow(bck, nil, 0); err != nil {
+			tt.Fatalf("request #%d: %v", i, err)
+		}
+	}
+	if err := rls.allow(bck, nil, 0); !cmn.IsErrRateLimited(err) {
+		tt.Fatalf("expected bucket rate-limited, got %v", err)
+	}
+}
+
+func TestRateLimitGet(tt *testing.T) {
+	var (
+		rls = rlimiters{statsT: mock.NewStatsTracker()}
+		bck = meta.NewBck("rlim", apc.AIS, cmn.NsGlobal, &cmn.Bprops{BID: 2, RateLimit: cmn.RateLimitConf{BPS: cos.MiB}})
+	)
+	if err := rls.allowGet(bck, nil); err != nil {
+		tt.Fatal(err)
+	}
+	rls.take(bck, nil, 4*cos.MiB)
+	if err := rls.allowGet(bck, nil); !cmn.IsErrRateLimited(err) {
+		tt.Fatalf("expected bucket rate-limited (in debt), got %v", err)
+	}
+	if err := rls.allow(bck, nil, 0); !cmn.IsErrRateLimited(err) {
+		tt.Fatalf("expected bucket rate-limited (in debt), got %v", err)
+	}
+}
+
+func TestRateLimitGetUser(tt *testing.T) {
+	var (
+		rls   = rlimiters{statsT: mock.NewStatsTracker()}
+		bck   = meta.NewBck("rlim", apc.AIS, cmn.NsGlobal, &cmn.Bprops{BID: 3})
+		tk    = &tok.Token{UserID: "u", RateLimit: &cmn.RateLimitConf{BPS: cos.MiB}}
+		admin = &tok.Token{UserID: "a", RateLimit: &cmn.RateLimitConf{BPS: cos.MiB}, IsAdmin: true}
+	)
+	if err := rls.allowGet(bck, tk); err != nil {
+		tt.Fatal(err)
+	}
+	rls.take(bck, tk, 4*cos.MiB)
+	rls.take(bck, admin, 4*cos.MiB)
+	if err := rls.allowGet(bck, tk); !cmn.IsErrRateLimited(err) {
+		tt.Fatalf("expected user rate-limited (in debt), got %v", err)
+	}
+	if err := rls.allow(bck, tk, 0); !cmn.IsErrRateLimited(err) {
+		tt.Fatalf("expected user rate-limited (in debt), got %v", err)
+	}
+	// neither the bucket nor other users
+	if err := rls.allowGet(bck, nil); err != nil {
+		tt.Fatal(err)
+	}
+	if err := rls.allowGet(bck, admin); err != nil {
+		tt.Fatal(err)
+	}
+}
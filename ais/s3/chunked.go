@@ -0,0 +1,221 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Payload readers:
+// - chunkedReader decodes (and optionally verifies) "aws-chunked" streaming payload, where each chunk
+//   is formatted as follows:
+//   hex(chunk-size);chunk-signature=<signature>\r\n<chunk-data>\r\n
+//   and the last chunk has zero size
+//   (see https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html)
+// - shaReader validates signed (non-streaming) payload against its x-amz-content-sha256
+
+const (
+	chunkSigPrefix   = "chunk-signature="
+	chunkSigAlg      = "AWS4-HMAC-SHA256-PAYLOAD"
+	chunkMaxHdrLen   = 4 * cos.KiB
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type (
+	chunkedReader struct {
+		body      io.ReadCloser
+		br        *bufio.Reader
+		sig       *SigV4
+		h         hash.Hash
+		key       []byte // nil when not verifying
+		prevSig   string
+		chunkSig  string
+		err       error
+		remaining int64
+		done      bool
+	}
+	shaReader struct {
+		body     io.ReadCloser
+		h        hash.Hash
+		expected string
+	}
+)
+
+// DecodeChunked replaces aws-chunked request body with the decoding reader and sets
+// the request's content length to the decoded (ie., actual object) size.
+// Chunk signatures are verified only when both `sig` and `secret` are provided.
+func DecodeChunked(r *http.Request, sig *SigV4, secret string) error {
+	if r.Header.Get(cos.S3HdrContentSHA256) != cos.S3StreamingPayload {
+		return nil
+	}
+	val := r.Header.Get(cos.S3HdrDecodedContentLength)
+	size, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || size < 0 {
+		return NewErrAuth(ErrCodeMalformedAuth, "invalid or missing %q: %q", cos.S3HdrDecodedContentLength, val)
+	}
+	cr := &chunkedReader{
+		body: r.Body,
+		br:   bufio.NewReaderSize(r.Body, chunkMaxHdrLen),
+	}
+	if sig != nil && secret != "" {
+		cr.sig, cr.key, cr.prevSig = sig, sig.SigningKey(secret), sig.Signature
+		cr.h = sha256.New()
+	}
+	r.Body = cr
+	r.ContentLength = size
+	r.Header.Set(cos.HdrContentLength, val)
+
+	// strip "aws-chunked" from the content encoding
+	if enc := r.Header.Get(cos.HdrContentEncoding); enc != "" {
+		var out []string
+		for _, e := range strings.Split(enc, ",") {
+			if e = strings.TrimSpace(e); e != "" && e != cos.S3ContentEncodingAWSChunked {
+				out = append(out, e)
+			}
+		}
+		if len(out) == 0 {
+			r.Header.Del(cos.HdrContentEncoding)
+		} else {
+			r.Header.Set(cos.HdrContentEncoding, strings.Join(out, ","))
+		}
+	}
+	return nil
+}
+
+// VerifyPayload wraps signed payload with the reader that validates its sha256 upon EOF.
+func VerifyPayload(r *http.Request, sig *SigV4) {
+	if sig == nil || !sig.IsSignedPayload() || r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	r.Body = &shaReader{body: r.Body, h: sha256.New(), expected: sig.PayloadHash}
+}
+
+///////////////////
+// chunkedReader //
+///////////////////
+
+func (cr *chunkedReader) Read(p []byte) (n int, err error) {
+	if cr.err != nil {
+		return 0, cr.err
+	}
+	for cr.remaining == 0 {
+		if cr.done {
+			return 0, io.EOF
+		}
+		if err = cr.next(); err != nil {
+			cr.err = err
+			return 0, err
+		}
+	}
+	if int64(len(p)) > cr.remaining {
+		p = p[:cr.remaining]
+	}
+	n, err = cr.br.Read(p)
+	cr.remaining -= int64(n)
+	if cr.h != nil {
+		cr.h.Write(p[:n])
+	}
+	switch {
+	case err == io.EOF:
+		err = io.ErrUnexpectedEOF
+	case err == nil && cr.remaining == 0:
+		err = cr.endChunk()
+	}
+	if err != nil {
+		cr.err = err
+	}
+	return n, err
+}
+
+func (cr *chunkedReader) Close() error { return cr.body.Close() }
+
+// parse chunk header: hex(size)[;chunk-signature=<signature>]\r\n
+func (cr *chunkedReader) next() error {
+	line, err := cr.br.ReadSlice('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	line = bytes.TrimRight(line, "\r\n")
+	sizeStr, ext, _ := strings.Cut(string(line), ";")
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeStr), 16, 64)
+	if err != nil || size < 0 {
+		return NewErrAuth(ErrCodeMalformedAuth, "invalid aws-chunked header %q", line)
+	}
+	cr.chunkSig = strings.TrimPrefix(strings.TrimSpace(ext), chunkSigPrefix)
+	if cr.h != nil {
+		cr.h.Reset()
+	}
+	if size > 0 {
+		cr.remaining = size
+		return nil
+	}
+
+	// last (zero-size) chunk followed by optional trailing CRLF
+	cr.done = true
+	if err := cr.verify(); err != nil {
+		return err
+	}
+	if b, err := cr.br.Peek(2); err == nil && string(b) == "\r\n" {
+		cr.br.Discard(2) //nolint:errcheck // peeked above
+	}
+	return nil
+}
+
+func (cr *chunkedReader) endChunk() error {
+	var crlf [2]byte
+	if _, err := io.ReadFull(cr.br, crlf[:]); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	if crlf[0] != '\r' || crlf[1] != '\n' {
+		return NewErrAuth(ErrCodeMalformedAuth, "invalid aws-chunked encoding: missing CRLF")
+	}
+	return cr.verify()
+}
+
+func (cr *chunkedReader) verify() error {
+	if cr.key == nil {
+		return nil
+	}
+	sts := chunkSigAlg + "\n" + cr.sig.Timestamp + "\n" + cr.sig.scope() + "\n" + cr.prevSig + "\n" +
+		emptyPayloadHash + "\n" + hex.EncodeToString(cr.h.Sum(nil))
+	expected := cr.sig.sign(cr.key, sts)
+	if !hmac.Equal([]byte(expected), []byte(cr.chunkSig)) {
+		return NewErrAuth(ErrCodeSignatureDoesNotMatch, "chunk signature does not match (access key %q)", cr.sig.AccessKey)
+	}
+	cr.prevSig = cr.chunkSig
+	return nil
+}
+
+///////////////
+// shaReader //
+///////////////
+
+func (sr *shaReader) Read(p []byte) (n int, err error) {
+	n, err = sr.body.Read(p)
+	sr.h.Write(p[:n])
+	if err == io.EOF {
+		if hex.EncodeToString(sr.h.Sum(nil)) != sr.expected {
+			err = NewErrAuth(ErrCodeSHA256Mismatch, "the provided %q does not match the computed payload checksum",
+				cos.S3HdrContentSHA256)
+		}
+	}
+	return n, err
+}
+
+func (sr *shaReader) Close() error { return sr.body.Close() }
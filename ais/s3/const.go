@@ -13,6 +13,10 @@ const (
 	QparamCORS              = "cors"
 	QparamPolicy            = "policy"
 	QparamACL               = "acl"
+	QparamTagging           = "tagging"
+	QparamObjectLock        = "object-lock"
+	QparamRetention         = "retention"
+	QparamLegalHold         = "legal-hold"
 	QparamMultiDelete       = "delete"
 	QparamMaxKeys           = "max-keys"
 	QparamPrefix            = "prefix"
@@ -32,6 +36,14 @@ const (
 	QparamSignature   = "Signature"
 	QparamXID         = "x-id"
 
+	// signature V4, query string (presigned) variant
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+	QparamAlgorithm     = "X-Amz-Algorithm"
+	QparamAmzDate       = "X-Amz-Date"
+	QparamAmzExpires    = "X-Amz-Expires"
+	QparamSignedHeaders = "X-Amz-SignedHeaders"
+	QparamAmzSignature  = "X-Amz-Signature"
+
 	HeaderPrefix      = "X-Amz-"
 	HeaderCredentials = "X-Amz-Credential" //nolint:gosec // This is just a header name definition...
 
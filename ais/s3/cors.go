@@ -0,0 +1,144 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket CORS configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+// Rules are stored in the bucket properties (see cmn/cors.go) and enforced
+// by both proxies and targets, including OPTIONS preflight (see SetCORS below).
+
+const ErrCodeNoSuchCORS = "NoSuchCORSConfiguration"
+
+type (
+	CORSConfiguration struct {
+		XMLName xml.Name    `xml:"CORSConfiguration"`
+		Rules   []*CORSRule `xml:"CORSRule"`
+	}
+	CORSRule struct {
+		ID             string   `xml:"ID,omitempty"`
+		AllowedOrigins []string `xml:"AllowedOrigin"`
+		AllowedMethods []string `xml:"AllowedMethod"`
+		AllowedHeaders []string `xml:"AllowedHeader"`
+		ExposeHeaders  []string `xml:"ExposeHeader"`
+		MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+	}
+)
+
+// ErrNoSuchCORS is returned upon GET (cors) when there are no rules
+var ErrNoSuchCORS = errors.New("the CORS configuration does not exist")
+
+func NewCORSConfiguration(conf *cmn.CORSConf) *CORSConfiguration {
+	out := &CORSConfiguration{Rules: make([]*CORSRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		out.Rules = append(out.Rules, &CORSRule{
+			ID:             rule.ID,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  rule.MaxAge,
+		})
+	}
+	return out
+}
+
+func (r *CORSConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert to bucket props (validation via cmn.CORSConf.ValidateAsProps)
+func (r *CORSConfiguration) Conf() (*cmn.CORSConf, error) {
+	if len(r.Rules) == 0 {
+		return nil, errors.New("CORS configuration must contain at least one rule")
+	}
+	conf := &cmn.CORSConf{Rules: make([]cmn.CORSRule, 0, len(r.Rules))}
+	for _, cr := range r.Rules {
+		conf.Rules = append(conf.Rules, cmn.CORSRule{
+			ID:             cr.ID,
+			AllowedOrigins: cr.AllowedOrigins,
+			AllowedMethods: cr.AllowedMethods,
+			AllowedHeaders: cr.AllowedHeaders,
+			ExposeHeaders:  cr.ExposeHeaders,
+			MaxAge:         cr.MaxAgeSeconds,
+		})
+	}
+	return conf, conf.ValidateAsProps()
+}
+
+//
+// enforcement
+//
+
+// IsPreflight returns true for CORS preflight (OPTIONS) request
+func IsPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get(cos.HdrOrigin) != "" &&
+		r.Header.Get(cos.HdrACRequestMethod) != ""
+}
+
+// SetCORS sets CORS response headers if the request's origin is allowed by one of the
+// bucket's rules; returns false otherwise (including when the request is not cross-origin).
+// Handles both preflight and actual requests.
+func SetCORS(hdr http.Header, r *http.Request, conf *cmn.CORSConf) bool {
+	origin := r.Header.Get(cos.HdrOrigin)
+	if origin == "" || len(conf.Rules) == 0 {
+		return false
+	}
+	var (
+		method     = r.Method
+		reqHeaders []string
+		preflight  = IsPreflight(r)
+	)
+	if preflight {
+		method = r.Header.Get(cos.HdrACRequestMethod)
+		if s := r.Header.Get(cos.HdrACRequestHeaders); s != "" {
+			for _, h := range strings.Split(s, ",") {
+				if h = strings.TrimSpace(h); h != "" {
+					reqHeaders = append(reqHeaders, h)
+				}
+			}
+		}
+	}
+	rule := conf.Match(origin, method, reqHeaders)
+	if rule == nil {
+		return false
+	}
+	if cos.StringInSlice("*", rule.AllowedOrigins) {
+		hdr.Set(cos.HdrACAllowOrigin, "*")
+	} else {
+		hdr.Set(cos.HdrACAllowOrigin, origin)
+		hdr.Set(cos.HdrACAllowCredentials, "true")
+	}
+	hdr.Add(cos.HdrVary, cos.HdrOrigin)
+	if preflight {
+		hdr.Set(cos.HdrACAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if len(reqHeaders) > 0 {
+			hdr.Set(cos.HdrACAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		if rule.MaxAge > 0 {
+			hdr.Set(cos.HdrACMaxAge, strconv.Itoa(rule.MaxAge))
+		}
+		return true
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		hdr.Set(cos.HdrACExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+const corsXML = `<CORSConfiguration>
+  <CORSRule>
+    <AllowedOrigin>https://*.example.com</AllowedOrigin>
+    <AllowedMethod>GET</AllowedMethod>
+    <AllowedMethod>HEAD</AllowedMethod>
+    <AllowedHeader>x-amz-*</AllowedHeader>
+    <ExposeHeader>ETag</ExposeHeader>
+    <MaxAgeSeconds>600</MaxAgeSeconds>
+  </CORSRule>
+  <CORSRule>
+    <AllowedOrigin>*</AllowedOrigin>
+    <AllowedMethod>PUT</AllowedMethod>
+  </CORSRule>
+</CORSConfiguration>`
+
+func TestCORS(t *testing.T) {
+	in := &CORSConfiguration{}
+	if err := xml.Unmarshal([]byte(corsXML), in); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := in.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, origin, acMethod, acHeaders string
+		allowOrigin                         string
+		preflight, allowed                  bool
+	}{
+		{http.MethodOptions, "https://viewer.example.com", http.MethodGet, "X-Amz-Date, x-amz-content-sha256",
+			"https://viewer.example.com", true, true},
+		{http.MethodOptions, "https://viewer.example.com", http.MethodGet, "Authorization", "", true, false},
+		{http.MethodOptions, "https://example.org", http.MethodGet, "", "", true, false},
+		{http.MethodOptions, "https://example.org", http.MethodPut, "", "*", true, true},
+		{http.MethodGet, "https://viewer.example.com", "", "", "https://viewer.example.com", false, true},
+		{http.MethodDelete, "https://viewer.example.com", "", "", "", false, false},
+		{http.MethodGet, "", "", "", "", false, false},
+	}
+	for i, test := range tests {
+		r := httptest.NewRequest(test.method, "/s3/bucket/obj", http.NoBody)
+		if test.origin != "" {
+			r.Header.Set(cos.HdrOrigin, test.origin)
+		}
+		if test.acMethod != "" {
+			r.Header.Set(cos.HdrACRequestMethod, test.acMethod)
+		}
+		if test.acHeaders != "" {
+			r.Header.Set(cos.HdrACRequestHeaders, test.acHeaders)
+		}
+		if IsPreflight(r) != test.preflight {
+			t.Errorf("#%d: expected preflight=%t", i, test.preflight)
+		}
+		hdr := http.Header{}
+		if allowed := SetCORS(hdr, r, conf); allowed != test.allowed {
+			t.Fatalf("#%d: expected allowed=%t, got %t", i, test.allowed, allowed)
+		}
+		if got := hdr.Get(cos.HdrACAllowOrigin); got != test.allowOrigin {
+			t.Errorf("#%d: allow-origin %q, expected %q", i, got, test.allowOrigin)
+		}
+		if i == 0 && (hdr.Get(cos.HdrACMaxAge) != "600" || hdr.Get(cos.HdrACAllowMethods) != "GET, HEAD") {
+			t.Errorf("#%d: unexpected preflight response %v", i, hdr)
+		}
+		if i == 4 && hdr.Get(cos.HdrACExposeHeaders) != "ETag" {
+			t.Errorf("#%d: unexpected response %v", i, hdr)
+		}
+	}
+
+	// invalid
+	bad := []string{
+		`<CORSConfiguration></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+		`<CORSConfiguration><CORSRule><AllowedOrigin>*.*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+	}
+	for _, s := range bad {
+		in := &CORSConfiguration{}
+		if err := xml.Unmarshal([]byte(s), in); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := in.Conf(); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
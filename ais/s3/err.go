@@ -61,6 +61,22 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 	}
 	out.Message = in.Message
 	switch {
+	case IsErrAuth(err):
+		out.Code = err.(*ErrAuth).Code()
+	case err == ErrNoSuchLifecycle:
+		out.Code = ErrCodeNoSuchLifecycle
+	case err == ErrNoSuchCORS:
+		out.Code = ErrCodeNoSuchCORS
+	case err == ErrNoSuchVersion:
+		out.Code = ErrCodeNoSuchVersion
+	case err == ErrNoSuchObjLockConf:
+		out.Code = ErrCodeNoSuchObjLockConf
+	case cmn.IsErrObjLocked(err):
+		out.Code = "AccessDenied"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
+	case cmn.IsErrRateLimited(err):
+		out.Code = "SlowDown"
 	case cmn.IsErrBucketAlreadyExists(err):
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
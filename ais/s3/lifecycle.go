@@ -0,0 +1,177 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket lifecycle configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+// Supported actions: Expiration (Days) and AbortIncompleteMultipartUpload.
+// Rules are stored in the bucket properties (see cmn/lifecycle.go).
+
+const (
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+
+	ErrCodeNoSuchLifecycle = "NoSuchLifecycleConfiguration"
+
+	day = 24 * time.Hour
+)
+
+type (
+	LifecycleConfiguration struct {
+		XMLName xml.Name         `xml:"LifecycleConfiguration"`
+		Rules   []*LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		ID         string               `xml:"ID,omitempty"`
+		Prefix     *string              `xml:"Prefix"` // deprecated (but still used) alternative to Filter
+		Filter     *LifecycleFilter     `xml:"Filter"`
+		Status     string               `xml:"Status"`
+		Expiration *LifecycleExpiration `xml:"Expiration"`
+		AbortMpt   *LifecycleAbortMpt   `xml:"AbortIncompleteMultipartUpload"`
+	}
+	LifecycleFilter struct {
+		Prefix *string       `xml:"Prefix"`
+		Tag    *Tag          `xml:"Tag"`
+		And    *LifecycleAnd `xml:"And"`
+	}
+	LifecycleAnd struct {
+		Prefix string `xml:"Prefix,omitempty"`
+		Tags   []Tag  `xml:"Tag"`
+	}
+	LifecycleExpiration struct {
+		Days int64 `xml:"Days"`
+	}
+	LifecycleAbortMpt struct {
+		Days int64 `xml:"DaysAfterInitiation"`
+	}
+)
+
+// ErrNoSuchLifecycle is returned upon GET (lifecycle) when there are no rules
+var ErrNoSuchLifecycle = errors.New("the lifecycle configuration does not exist")
+
+func NewLifecycleConfiguration(conf *cmn.LifecycleConf) *LifecycleConfiguration {
+	out := &LifecycleConfiguration{Rules: make([]*LifecycleRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		var (
+			rule = &conf.Rules[i]
+			r    = &LifecycleRule{ID: rule.ID, Status: lifecycleDisabled, Filter: &LifecycleFilter{}}
+		)
+		if rule.Enabled {
+			r.Status = lifecycleEnabled
+		}
+		switch {
+		case len(rule.Tags) == 0:
+			prefix := rule.Prefix
+			r.Filter.Prefix = &prefix
+		case len(rule.Tags) == 1 && rule.Prefix == "":
+			for k, v := range rule.Tags {
+				r.Filter.Tag = &Tag{Key: k, Value: v}
+			}
+		default:
+			r.Filter.And = &LifecycleAnd{Prefix: rule.Prefix, Tags: make([]Tag, 0, len(rule.Tags))}
+			for k, v := range rule.Tags {
+				r.Filter.And.Tags = append(r.Filter.And.Tags, Tag{Key: k, Value: v})
+			}
+		}
+		if rule.Expire > 0 {
+			r.Expiration = &LifecycleExpiration{Days: toDays(rule.Expire.D())}
+		}
+		if rule.AbortMpt > 0 {
+			r.AbortMpt = &LifecycleAbortMpt{Days: toDays(rule.AbortMpt.D())}
+		}
+		out.Rules = append(out.Rules, r)
+	}
+	return out
+}
+
+func toDays(d time.Duration) int64 { return int64((d + day - 1) / day) }
+
+func (r *LifecycleConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert to bucket props
+func (r *LifecycleConfiguration) Conf() (*cmn.LifecycleConf, error) {
+	if len(r.Rules) == 0 {
+		return nil, errors.New("lifecycle configuration must contain at least one rule")
+	}
+	conf := &cmn.LifecycleConf{Rules: make([]cmn.LifecycleRule, 0, len(r.Rules))}
+	for i, lr := range r.Rules {
+		rule := cmn.LifecycleRule{ID: lr.ID}
+		switch lr.Status {
+		case lifecycleEnabled:
+			rule.Enabled = true
+		case lifecycleDisabled:
+		default:
+			return nil, fmt.Errorf("lifecycle rule #%d: invalid status %q", i+1, lr.Status)
+		}
+		if err := lr.filter(&rule); err != nil {
+			return nil, fmt.Errorf("lifecycle rule #%d: %v", i+1, err)
+		}
+		if lr.Expiration != nil {
+			if lr.Expiration.Days <= 0 {
+				return nil, fmt.Errorf("lifecycle rule #%d: expiration days must be positive", i+1)
+			}
+			rule.Expire = cos.Duration(time.Duration(lr.Expiration.Days) * day)
+		}
+		if lr.AbortMpt != nil {
+			if lr.AbortMpt.Days <= 0 {
+				return nil, fmt.Errorf("lifecycle rule #%d: days after initiation must be positive", i+1)
+			}
+			rule.AbortMpt = cos.Duration(time.Duration(lr.AbortMpt.Days) * day)
+		}
+		conf.Rules = append(conf.Rules, rule)
+	}
+	return conf, nil
+}
+
+func (r *LifecycleRule) filter(rule *cmn.LifecycleRule) error {
+	if r.Prefix != nil {
+		if r.Filter != nil {
+			return errors.New("cannot specify both prefix and filter")
+		}
+		rule.Prefix = *r.Prefix
+		return nil
+	}
+	f := r.Filter
+	if f == nil {
+		return nil
+	}
+	var n int
+	if f.Prefix != nil {
+		rule.Prefix = *f.Prefix
+		n++
+	}
+	if f.Tag != nil {
+		rule.Tags = cos.StrKVs{f.Tag.Key: f.Tag.Value}
+		n++
+	}
+	if f.And != nil {
+		rule.Prefix = f.And.Prefix
+		rule.Tags = make(cos.StrKVs, len(f.And.Tags))
+		for _, tag := range f.And.Tags {
+			rule.Tags[tag.Key] = tag.Value
+		}
+		n++
+	}
+	if n > 1 {
+		return errors.New("filter must specify exactly one of: prefix, tag, and")
+	}
+	return nil
+}
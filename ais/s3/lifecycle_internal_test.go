@@ -0,0 +1,115 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+const lcXML = `<LifecycleConfiguration>
+  <Rule>
+    <ID>logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+  <Rule>
+    <ID>tmp</ID>
+    <Filter><And><Prefix>data/</Prefix><Tag><Key>class</Key><Value>tmp</Value></Tag></And></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>off</ID>
+    <Prefix></Prefix>
+    <Status>Disabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+</LifecycleConfiguration>`
+
+func TestLifecycleConf(t *testing.T) {
+	in := &LifecycleConfiguration{}
+	if err := xml.NewDecoder(strings.NewReader(lcXML)).Decode(in); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := in.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.Rules) != 3 || !conf.IsEnabled() {
+		t.Fatalf("unexpected %+v", conf)
+	}
+
+	tests := []struct {
+		objName string
+		tags    cos.StrKVs
+		expire  time.Duration
+		abort   time.Duration
+	}{
+		{"logs/a", nil, 30 * day, 7 * day},
+		{"data/a", nil, 0, 0},
+		{"data/a", cos.StrKVs{"class": "tmp", "other": "x"}, day, 0},
+		{"data/a", cos.StrKVs{"class": "prod"}, 0, 0},
+		{"other", nil, 0, 0}, // disabled
+	}
+	for _, test := range tests {
+		if d := conf.Expire(test.objName, test.tags); d != test.expire {
+			t.Errorf("%s %v: expire %v, expected %v", test.objName, test.tags, d, test.expire)
+		}
+		if d := conf.AbortMpt(test.objName); d != test.abort {
+			t.Errorf("%s: abort %v, expected %v", test.objName, d, test.abort)
+		}
+	}
+
+	// round-trip
+	out := NewLifecycleConfiguration(conf)
+	b, err := xml.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again := &LifecycleConfiguration{}
+	if err := xml.Unmarshal(b, again); err != nil {
+		t.Fatal(err)
+	}
+	conf2, err := again.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range conf.Rules {
+		r1, r2 := &conf.Rules[i], &conf2.Rules[i]
+		if r1.ID != r2.ID || r1.Prefix != r2.Prefix || r1.Expire != r2.Expire || r1.AbortMpt != r2.AbortMpt ||
+			r1.Enabled != r2.Enabled || len(r1.Tags) != len(r2.Tags) {
+			t.Fatalf("rule #%d: %+v != %+v", i, r1, r2)
+		}
+	}
+
+	// invalid
+	bad := []*LifecycleConfiguration{
+		{},
+		{Rules: []*LifecycleRule{{Status: "enabled"}}},
+		{Rules: []*LifecycleRule{{Status: lifecycleEnabled, Expiration: &LifecycleExpiration{Days: 0}}}},
+	}
+	for _, lc := range bad {
+		if _, err := lc.Conf(); err == nil {
+			t.Errorf("expected error for %+v", lc)
+		}
+	}
+	tagAbort := cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+		{Tags: cos.StrKVs{"k": "v"}, AbortMpt: cos.Duration(day), Enabled: true},
+	}}
+	if err := tagAbort.ValidateAsProps(); err == nil {
+		t.Error("expected error: tag filter with abort-incomplete-multipart")
+	}
+}
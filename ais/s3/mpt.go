@@ -1,38 +1,61 @@
 // Package s3 provides Amazon S3 compatibility layer
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package s3
 
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
 )
 
+// Active uploads are kept in memory and, for crash safety, persisted on the
+// respective mountpaths (see fs.MptType): every upload has its manifest
+// that gets rewritten upon each added part and removed upon completion (or abort).
+// Upon restart, targets reload all manifests (see LoadUploads) while abandoned
+// uploads get eventually removed by space cleanup (see `space.mpt_abandon_time`).
+
 // NOTE: xattr stores only the (*) marked attributes
 type (
 	MptPart struct {
-		MD5  string // MD5 of the part (*)
-		FQN  string // FQN of the corresponding workfile
-		Size int64  // part size in bytes (*)
-		Num  int32  // part number (*)
+		MD5  string `json:"md5"`  // MD5 of the part (*)
+		FQN  string `json:"-"`    // FQN of the corresponding part file
+		Size int64  `json:"size"` // part size in bytes (*)
+		Num  int32  `json:"num"`  // part number (*)
 	}
 	mpt struct {
 		bckName string
 		objName string
+		fqn     string     // manifest FQN
 		parts   []*MptPart // by part number
 		ctime   time.Time  // InitUpload time
+		smu     sync.Mutex // serializes manifest updates
 	}
 	uploads map[string]*mpt // by upload ID
+
+	// persistent state of an active upload
+	manifest struct {
+		ID      string     `json:"upload_id"`
+		ObjName string     `json:"obj_name"`
+		Parts   []*MptPart `json:"parts"`
+		Ctime   int64      `json:"ctime,string"`
+	}
 )
 
 var (
@@ -40,34 +63,63 @@ var (
 	mu  sync.RWMutex
 )
 
+var mptJspOpts = jsp.CCSign(cmn.MetaverMpt)
+
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
+func InitUpload(id string, lom *core.LOM) error {
+	mpt := &mpt{
+		bckName: lom.Bck().Name,
+		objName: lom.ObjName,
+		fqn:     fs.CSM.Gen(lom, fs.MptType, mptPrefix(id, fs.MptManifest)),
+		parts:   make([]*MptPart, 0, iniCapParts),
+		ctime:   time.Now(),
+	}
 	mu.Lock()
 	if ups == nil {
 		ups = make(uploads, 8)
 	}
-	ups[id] = &mpt{
-		bckName: bckName,
-		objName: objName,
-		parts:   make([]*MptPart, 0, iniCapParts),
-		ctime:   time.Now(),
-	}
+	ups[id] = mpt
 	mu.Unlock()
+
+	if err := mpt.persist(id); err != nil {
+		mu.Lock()
+		delete(ups, id)
+		mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// PartFQN returns the pathname of the given upload part, which is always
+// located on the same mountpath as the upload's manifest.
+func PartFQN(lom *core.LOM, id string, partNum int32) string {
+	return fs.CSM.Gen(lom, fs.MptType, mptPrefix(id, strconv.FormatInt(int64(partNum), 10)))
+}
+
+// "<upload-digest>.<suffix>" (see fs.MptContentResolver)
+func mptPrefix(id, sfx string) string {
+	digest := xxhash.Checksum64S(cos.UnsafeB(id), cos.MLCG32)
+	return strconv.FormatUint(digest, 16) + "." + sfx
 }
 
 // Add part to an active upload.
 // Some clients may omit size and md5. Only partNum is must-have.
 // md5 and fqn is filled by a target after successful saving the data to a workfile.
-func AddPart(id string, npart *MptPart) (err error) {
+// Re-uploading a part with the same number replaces the previous one.
+func AddPart(id string, npart *MptPart) error {
 	mu.Lock()
 	mpt, ok := ups[id]
 	if !ok {
-		err = fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
+		mu.Unlock()
+		return fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
+	}
+	if i := mpt.partIdx(npart.Num); i >= 0 {
+		mpt.parts[i] = npart
 	} else {
 		mpt.parts = append(mpt.parts, npart)
 	}
 	mu.Unlock()
-	return
+	return mpt.persist(id)
 }
 
 // TODO: compare non-zero sizes (note: s3cmd sends 0) and part.ETag as well, if specified
@@ -137,6 +189,11 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 			nlog.Warningln("failed to xattr [", fqn, id, err, "]")
 		}
 	}
+	mpt.smu.Lock()
+	if err := cos.RemoveFile(mpt.fqn); err != nil {
+		nlog.Errorln("failed to remove manifest [", fqn, id, err, "]")
+	}
+	mpt.smu.Unlock()
 	for _, part := range mpt.parts {
 		if err := cos.RemoveFile(part.FQN); err != nil {
 			nlog.Errorln("failed to remove part [", fqn, id, err, "]")
@@ -145,13 +202,47 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	return true
 }
 
-func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
+// abort (and cleanup) bucket's uploads initiated earlier than the
+// respective (per object name) lifecycle time; returns the number aborted
+func AbortStale(bckName string, olderThan func(objName string) time.Duration) (n int) {
+	var (
+		now   = time.Now()
+		stale []string
+	)
 	mu.RLock()
+	for id, mpt := range ups {
+		if mpt.bckName != bckName {
+			continue
+		}
+		if d := olderThan(mpt.objName); d > 0 && now.Sub(mpt.ctime) > d {
+			stale = append(stale, id)
+		}
+	}
+	mu.RUnlock()
+	for _, id := range stale {
+		if CleanupUpload(id, "", true /*aborted*/) {
+			n++
+		}
+	}
+	return n
+}
+
+func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
+	mu.Lock()
 	results := make([]UploadInfoResult, 0, len(ups))
 	for id, mpt := range ups {
+		if mpt.bckName != bckName {
+			continue
+		}
+		// abandoned and removed by space cleanup
+		if err := cos.Stat(mpt.fqn); err != nil && os.IsNotExist(err) {
+			nlog.Infoln("upload", id, "["+mpt.objName+"] is gone (abandoned)")
+			delete(ups, id)
+			continue
+		}
 		results = append(results, UploadInfoResult{Key: mpt.objName, UploadID: id, Initiated: mpt.ctime})
 	}
-	mu.RUnlock()
+	mu.Unlock()
 
 	sort.Slice(results, func(i int, j int) bool {
 		return results[i].Initiated.Before(results[j].Initiated)
@@ -196,3 +287,103 @@ func ListParts(id string, lom *core.LOM) (parts []*PartInfo, ecode int, err erro
 	mu.RUnlock()
 	return parts, ecode, err
 }
+
+// LoadUploads restores active uploads from their persisted manifests.
+// Called once upon target startup.
+func LoadUploads(bmd *meta.BMD) {
+	var (
+		avail = fs.GetAvail()
+		n     int
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if !bck.IsAIS() && !bck.IsRemoteS3() {
+			return false
+		}
+		for _, mi := range avail {
+			opts := &fs.WalkOpts{
+				Mi:  mi,
+				Bck: *bck.Bucket(),
+				CTs: []string{fs.MptType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() || !strings.HasSuffix(fqn, "."+fs.MptManifest) {
+						return nil // skip parts
+					}
+					if loadUpload(fqn, bck.Name) {
+						n++
+					}
+					return nil
+				},
+			}
+			if err := fs.Walk(opts); err != nil {
+				nlog.Errorln("failed to load", bck.Cname(""), "multipart uploads:", err)
+			}
+		}
+		return false
+	})
+	if n > 0 {
+		nlog.Infoln("loaded", n, "active multipart upload(s)")
+	}
+}
+
+func loadUpload(fqn, bckName string) bool {
+	m := &manifest{}
+	if _, err := jsp.Load(fqn, m, mptJspOpts); err != nil {
+		nlog.Errorln("failed to load multipart upload manifest", fqn+":", err)
+		return false
+	}
+	// <obj-name>.<upload-digest>.<part-number> (see PartFQN)
+	pfx := strings.TrimSuffix(fqn, fs.MptManifest)
+	for _, part := range m.Parts {
+		part.FQN = pfx + strconv.FormatInt(int64(part.Num), 10)
+	}
+	mpt := &mpt{
+		bckName: bckName,
+		objName: m.ObjName,
+		fqn:     fqn,
+		parts:   m.Parts,
+		ctime:   time.Unix(0, m.Ctime),
+	}
+	mu.Lock()
+	if ups == nil {
+		ups = make(uploads, 8)
+	}
+	ups[m.ID] = mpt
+	mu.Unlock()
+	return true
+}
+
+/////////
+// mpt //
+/////////
+
+// (re)write the manifest
+func (mpt *mpt) persist(id string) (err error) {
+	mpt.smu.Lock()
+	mu.RLock()
+	if ups[id] != mpt { // completed or aborted
+		mu.RUnlock()
+		mpt.smu.Unlock()
+		return nil
+	}
+	m := &manifest{
+		ID:      id,
+		ObjName: mpt.objName,
+		Parts:   append([]*MptPart(nil), mpt.parts...),
+		Ctime:   mpt.ctime.UnixNano(),
+	}
+	mu.RUnlock()
+	if err = jsp.Save(mpt.fqn, m, mptJspOpts, nil /*wto*/); err != nil {
+		err = fmt.Errorf("upload %q: failed to persist manifest: %w", id, err)
+	}
+	mpt.smu.Unlock()
+	return err
+}
+
+func (mpt *mpt) partIdx(num int32) int {
+	for i, part := range mpt.parts {
+		if part.Num == num {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/trand"
+)
+
+func TestMptManifest(t *testing.T) {
+	const (
+		id      = "upload-id"
+		bckName = "bucket"
+		objName = "dir/obj.tar"
+		nump    = 5
+	)
+	var (
+		base  = filepath.Join(t.TempDir(), objName)
+		fqn   = base + "." + mptPrefix(id, fs.MptManifest)
+		ctime = time.Now()
+	)
+	mu.Lock()
+	ups = uploads{id: &mpt{bckName: bckName, objName: objName, fqn: fqn, ctime: ctime}}
+	mu.Unlock()
+	if err := ups[id].persist(id); err != nil {
+		t.Fatal(err)
+	}
+
+	// add parts (re-uploading the last one)
+	for i := 1; i <= nump+1; i++ {
+		num := int32(min(i, nump))
+		pfqn := base + "." + mptPrefix(id, strconv.Itoa(int(num)))
+		if err := cos.CreateDir(filepath.Dir(pfqn)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pfqn, []byte(trand.String(8)), cos.PermRWR); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddPart(id, &MptPart{MD5: trand.String(8), FQN: pfqn, Size: int64(i), Num: num}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := AddPart("unknown", &MptPart{Num: 1}); err == nil {
+		t.Fatal("expected error adding part to unknown upload")
+	}
+	in := ups[id]
+
+	// restart
+	mu.Lock()
+	ups = nil
+	mu.Unlock()
+	if !loadUpload(fqn, bckName) {
+		t.Fatal("failed to load manifest")
+	}
+	out, ok := ups[id]
+	if !ok {
+		t.Fatalf("upload %q not found", id)
+	}
+	if out.bckName != bckName || out.objName != objName || !out.ctime.Equal(ctime) || out.fqn != fqn {
+		t.Fatalf("loaded %+v, expected %+v", out, in)
+	}
+	if len(out.parts) != nump || len(in.parts) != nump {
+		t.Fatalf("expected %d parts, got %d (in: %d)", nump, len(out.parts), len(in.parts))
+	}
+	for i := range nump {
+		if *in.parts[i] != *out.parts[i] {
+			t.Fatalf("in %v != out %v", *in.parts[i], *out.parts[i])
+		}
+	}
+	if res := ListUploads(bckName, "", 0); len(res.Uploads) != 1 || res.Uploads[0].UploadID != id {
+		t.Fatalf("expected upload %q, got %+v", id, res.Uploads)
+	}
+	if res := ListUploads("other", "", 0); len(res.Uploads) != 0 {
+		t.Fatalf("expected no uploads, got %+v", res.Uploads)
+	}
+
+	// abort
+	if !CleanupUpload(id, "", true /*aborted*/) {
+		t.Fatalf("upload %q not found", id)
+	}
+	for _, fqn := range append([]string{fqn}, in.parts[0].FQN, in.parts[nump-1].FQN) {
+		if err := cos.Stat(fqn); !os.IsNotExist(err) {
+			t.Fatalf("expected %q to be removed, err: %v", fqn, err)
+		}
+	}
+}
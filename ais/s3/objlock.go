@@ -0,0 +1,217 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object lock (WORM)
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+// Bucket configuration is stored in the bucket properties, per-object retention
+// and legal hold - in the object's custom metadata (see cmn/objlock.go).
+// Per-object retention mode is always the bucket's mode.
+
+const (
+	ErrCodeNoSuchObjLockConf = "ObjectLockConfigurationNotFoundError"
+
+	objLockEnabled = "Enabled"
+	legalHoldOff   = "OFF"
+)
+
+type (
+	ObjectLockConfiguration struct {
+		XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+		ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+		Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+	}
+	ObjectLockRule struct {
+		DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+	}
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  int    `xml:"Days,omitempty"`
+		Years int    `xml:"Years,omitempty"`
+	}
+
+	Retention struct {
+		XMLName         xml.Name `xml:"Retention"`
+		Mode            string   `xml:"Mode,omitempty"`
+		RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+	}
+	LegalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Status  string   `xml:"Status"`
+	}
+)
+
+// ErrNoSuchObjLockConf is returned when the bucket has no object lock configuration
+// (or the object has no retention)
+var ErrNoSuchObjLockConf = errors.New("object lock configuration does not exist")
+
+/////////////////////////////
+// ObjectLockConfiguration //
+/////////////////////////////
+
+func NewObjectLockConfiguration(conf *cmn.ObjLockConf) *ObjectLockConfiguration {
+	out := &ObjectLockConfiguration{ObjectLockEnabled: objLockEnabled}
+	if d := conf.Retention.D(); d > 0 {
+		out.Rule = &ObjectLockRule{DefaultRetention: DefaultRetention{Mode: conf.Mode, Days: int((d + day - 1) / day)}}
+	}
+	return out
+}
+
+func (r *ObjectLockConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert to bucket props (the bucket-wide legal hold, if any, remains unchanged)
+func (r *ObjectLockConfiguration) Conf() (*cmn.ObjLockConfToSet, error) {
+	if r.ObjectLockEnabled != objLockEnabled {
+		return nil, fmt.Errorf("invalid ObjectLockEnabled %q (expecting %q)", r.ObjectLockEnabled, objLockEnabled)
+	}
+	var (
+		enabled   = true
+		mode      = cmn.ObjLockGovernance
+		retention cos.Duration
+	)
+	if r.Rule != nil {
+		dr := &r.Rule.DefaultRetention
+		switch {
+		case dr.Days > 0 && dr.Years > 0:
+			return nil, errors.New("default retention: cannot specify both Days and Years")
+		case dr.Days > 0:
+			retention = cos.Duration(time.Duration(dr.Days) * day)
+		case dr.Years > 0:
+			retention = cos.Duration(time.Duration(dr.Years) * 365 * day)
+		default:
+			return nil, errors.New("default retention: expecting positive Days or Years")
+		}
+		mode = dr.Mode
+	}
+	conf := &cmn.ObjLockConf{Enabled: enabled, Mode: mode, Retention: retention}
+	if err := conf.ValidateAsProps(); err != nil {
+		return nil, err
+	}
+	return &cmn.ObjLockConfToSet{Enabled: &enabled, Mode: &mode, Retention: &retention}, nil
+}
+
+//
+// retention and legal hold
+//
+
+func NewRetention(conf *cmn.ObjLockConf, custom cos.StrKVs) (*Retention, bool) {
+	until, ok := cmn.RetainUntil(custom)
+	if !ok {
+		return nil, false
+	}
+	return &Retention{Mode: conf.Mode, RetainUntilDate: cmn.FmtRetainUntil(until)}, true
+}
+
+// returns zero time when RetainUntilDate is empty (i.e., remove retention)
+func (r *Retention) RetainUntil() (until time.Time, err error) {
+	if r.RetainUntilDate == "" {
+		return until, nil
+	}
+	if until, err = time.Parse(time.RFC3339, r.RetainUntilDate); err != nil {
+		err = fmt.Errorf("invalid RetainUntilDate %q: %v", r.RetainUntilDate, err)
+	}
+	return until, err
+}
+
+func NewLegalHold(custom cos.StrKVs) *LegalHold {
+	if custom[cmn.LegalHoldObjMD] == cmn.LegalHoldOn {
+		return &LegalHold{Status: cmn.LegalHoldOn}
+	}
+	return &LegalHold{Status: legalHoldOff}
+}
+
+func (r *LegalHold) On() (bool, error) {
+	switch r.Status {
+	case cmn.LegalHoldOn:
+		return true, nil
+	case legalHoldOff:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid legal hold status %q", r.Status)
+	}
+}
+
+func (r *Retention) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func (r *LegalHold) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+//
+// PUT, GET, and HEAD object headers
+//
+
+// ParseObjLockHdrs parses (PUT) object lock request headers into custom metadata
+func ParseObjLockHdrs(hdr http.Header, conf *cmn.ObjLockConf, custom cos.StrKVs) error {
+	var (
+		mode  = hdr.Get(cos.S3HdrObjLockMode)
+		until = hdr.Get(cos.S3HdrObjLockRetainUntil)
+		hold  = hdr.Get(cos.S3HdrObjLockLegalHold)
+	)
+	if mode == "" && until == "" && hold == "" {
+		return nil
+	}
+	if !conf.Enabled {
+		return errors.New("object lock is not enabled for the bucket")
+	}
+	if (mode == "") != (until == "") {
+		return fmt.Errorf("%s and %s must be specified together", cos.S3HdrObjLockMode, cos.S3HdrObjLockRetainUntil)
+	}
+	if until != "" {
+		if mode != conf.Mode {
+			return fmt.Errorf("object lock mode %q does not match the bucket's mode %q", mode, conf.Mode)
+		}
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", cos.S3HdrObjLockRetainUntil, until, err)
+		}
+		custom[cmn.RetainUntilObjMD] = cmn.FmtRetainUntil(t)
+	}
+	if hold != "" {
+		lh := &LegalHold{Status: hold}
+		on, err := lh.On()
+		if err != nil {
+			return err
+		}
+		if on {
+			custom[cmn.LegalHoldObjMD] = cmn.LegalHoldOn
+		}
+	}
+	return nil
+}
+
+func SetObjLockHdrs(hdr http.Header, conf *cmn.ObjLockConf, custom cos.StrKVs) {
+	if !conf.Enabled {
+		return
+	}
+	if until, ok := cmn.RetainUntil(custom); ok {
+		hdr.Set(cos.S3HdrObjLockMode, conf.Mode)
+		hdr.Set(cos.S3HdrObjLockRetainUntil, cmn.FmtRetainUntil(until))
+	}
+	hdr.Set(cos.S3HdrObjLockLegalHold, NewLegalHold(custom).Status)
+}
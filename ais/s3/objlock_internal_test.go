@@ -0,0 +1,109 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+const objLockXML = `<ObjectLockConfiguration>
+  <ObjectLockEnabled>Enabled</ObjectLockEnabled>
+  <Rule>
+    <DefaultRetention>
+      <Mode>COMPLIANCE</Mode>
+      <Days>30</Days>
+    </DefaultRetention>
+  </Rule>
+</ObjectLockConfiguration>`
+
+func TestObjLock(t *testing.T) {
+	in := &ObjectLockConfiguration{}
+	if err := xml.Unmarshal([]byte(objLockXML), in); err != nil {
+		t.Fatal(err)
+	}
+	toSet, err := in.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &cmn.ObjLockConf{Enabled: *toSet.Enabled, Mode: *toSet.Mode, Retention: *toSet.Retention}
+	if conf.Mode != cmn.ObjLockCompliance || conf.Retention.D() != 30*day {
+		t.Fatalf("unexpected conf %+v", conf)
+	}
+	if out := NewObjectLockConfiguration(conf); out.Rule == nil || out.Rule.DefaultRetention.Days != 30 {
+		t.Fatalf("unexpected round-trip %+v", out)
+	}
+
+	// object headers
+	var (
+		now    = time.Now()
+		until  = now.Add(time.Hour)
+		custom = cos.StrKVs{}
+		hdr    = http.Header{}
+	)
+	hdr.Set(cos.S3HdrObjLockMode, cmn.ObjLockGovernance)
+	hdr.Set(cos.S3HdrObjLockRetainUntil, cmn.FmtRetainUntil(until))
+	if err := ParseObjLockHdrs(hdr, conf, custom); err == nil {
+		t.Error("expecting mode mismatch error")
+	}
+	hdr.Set(cos.S3HdrObjLockMode, cmn.ObjLockCompliance)
+	hdr.Set(cos.S3HdrObjLockLegalHold, cmn.LegalHoldOn)
+	if err := ParseObjLockHdrs(hdr, conf, custom); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseObjLockHdrs(hdr, &cmn.ObjLockConf{}, cos.StrKVs{}); err == nil {
+		t.Error("expecting error: object lock not enabled")
+	}
+
+	// enforcement
+	if err := conf.Check("obj", custom, now); !cmn.IsErrObjLocked(err) {
+		t.Errorf("expecting legal hold, got %v", err)
+	}
+	delete(custom, cmn.LegalHoldObjMD)
+	if err := conf.Check("obj", custom, now); !cmn.IsErrObjLocked(err) {
+		t.Errorf("expecting retention, got %v", err)
+	}
+	if err := conf.Check("obj", custom, until.Add(time.Second)); err != nil {
+		t.Errorf("expecting retention to expire, got %v", err)
+	}
+	if err := conf.CheckRetention("obj", custom, now.Add(time.Minute), now); !cmn.IsErrObjLocked(err) {
+		t.Errorf("compliance mode: expecting failure to shorten retention, got %v", err)
+	}
+	if err := conf.CheckRetention("obj", custom, time.Time{}, now); !cmn.IsErrObjLocked(err) {
+		t.Errorf("compliance mode: expecting failure to remove retention, got %v", err)
+	}
+	if err := conf.CheckRetention("obj", custom, until.Add(time.Hour), now); err != nil {
+		t.Errorf("expecting to extend retention, got %v", err)
+	}
+	gov := &cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockGovernance}
+	if err := gov.CheckRetention("obj", custom, time.Time{}, now); err != nil {
+		t.Errorf("governance mode: expecting to remove retention, got %v", err)
+	}
+	aisBck := &cmn.Bck{Name: "b", Provider: apc.AIS}
+	if err := conf.ValidateUpdate(aisBck, gov); err == nil {
+		t.Error("expecting failure to change compliance mode")
+	}
+	if err := (&cmn.ObjLockConf{}).ValidateUpdate(aisBck, gov); err != nil {
+		t.Errorf("expecting to enable object lock, got %v", err)
+	}
+	for _, bck := range []*cmn.Bck{{Name: "b", Provider: apc.AWS}, {Name: "b", Provider: apc.AIS, Ns: cmn.Ns{UUID: "remote"}}} {
+		if err := (&cmn.ObjLockConf{}).ValidateUpdate(bck, gov); err == nil {
+			t.Errorf("%s: expecting object lock not supported", bck)
+		}
+	}
+
+	// response headers
+	rhdr := http.Header{}
+	SetObjLockHdrs(rhdr, conf, custom)
+	if rhdr.Get(cos.S3HdrObjLockRetainUntil) != cmn.FmtRetainUntil(until) || rhdr.Get(cos.S3HdrObjLockLegalHold) != legalHoldOff {
+		t.Errorf("unexpected response headers %v", rhdr)
+	}
+}
@@ -0,0 +1,89 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+// Tags are stored in the object's custom metadata (see cmn/objtags.go).
+
+type (
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		TagSet  TagSet   `xml:"TagSet"`
+	}
+	TagSet struct {
+		Tags []Tag `xml:"Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+func NewTagging(tags cos.StrKVs) *Tagging {
+	out := &Tagging{TagSet: TagSet{Tags: make([]Tag, 0, len(tags))}}
+	for k, v := range tags {
+		out.TagSet.Tags = append(out.TagSet.Tags, Tag{Key: k, Value: v})
+	}
+	sort.Slice(out.TagSet.Tags, func(i, j int) bool { return out.TagSet.Tags[i].Key < out.TagSet.Tags[j].Key })
+	return out
+}
+
+func (r *Tagging) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// validate and convert
+func (r *Tagging) Tags() (cos.StrKVs, error) {
+	tags := make(cos.StrKVs, len(r.TagSet.Tags))
+	for _, tag := range r.TagSet.Tags {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		tags[tag.Key] = tag.Value
+	}
+	if err := cmn.ValidateObjTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// PUT(object) with "x-amz-tagging" header
+func ParseTaggingHdr(hdr http.Header) (tags cos.StrKVs, err error) {
+	s := hdr.Get(cos.S3HdrTagging)
+	if s == "" {
+		return nil, nil
+	}
+	if tags, err = cmn.ParseObjTags(s); err != nil {
+		return nil, err
+	}
+	return tags, cmn.ValidateObjTags(tags)
+}
+
+// GET and HEAD(object)
+func SetTaggingCount(hdr http.Header, custom cos.StrKVs) {
+	s, ok := custom[cmn.TaggingObjMD]
+	if !ok || s == "" {
+		return
+	}
+	if tags, err := cmn.ParseObjTags(s); err == nil && len(tags) > 0 {
+		hdr.Set(cos.S3HdrTaggingCount, strconv.Itoa(len(tags)))
+	}
+}
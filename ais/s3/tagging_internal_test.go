@@ -0,0 +1,68 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+func TestTagging(t *testing.T) {
+	hdr := http.Header{}
+	hdr.Set(cos.S3HdrTagging, "project=ais&class=tmp%20data")
+	tags, err := ParseTaggingHdr(hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags["class"] != "tmp data" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+
+	// stored as custom metadata and counted
+	custom := cos.StrKVs{cmn.TaggingObjMD: cmn.EncodeObjTags(tags)}
+	rhdr := http.Header{}
+	SetTaggingCount(rhdr, custom)
+	if rhdr.Get(cos.S3HdrTaggingCount) != "2" {
+		t.Fatalf("expected tagging count 2, got %q", rhdr.Get(cos.S3HdrTaggingCount))
+	}
+
+	// XML round-trip
+	b, err := xml.Marshal(NewTagging(tags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := &Tagging{}
+	if err := xml.Unmarshal(b, in); err != nil {
+		t.Fatal(err)
+	}
+	out, err := in.Tags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmn.MatchObjTags(out, tags) || !cmn.MatchObjTags(tags, out) {
+		t.Fatalf("in %v != out %v", tags, out)
+	}
+
+	// invalid
+	for _, s := range []string{"k=v1&k=v2", "=v", "k=" + strings.Repeat("v", cmn.MaxObjTagValueLen+1)} {
+		hdr.Set(cos.S3HdrTagging, s)
+		if _, err := ParseTaggingHdr(hdr); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+	many := &Tagging{}
+	for i := range cmn.MaxObjTags + 1 {
+		many.TagSet.Tags = append(many.TagSet.Tags, Tag{Key: strconv.Itoa(i)})
+	}
+	if _, err := many.Tags(); err == nil {
+		t.Error("expected error: too many tags")
+	}
+}
@@ -0,0 +1,200 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object versions: ais buckets configured to retain prior object versions
+// (see cmn.VersionConf.KeepPrior and core/lom_prior.go)
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// Notable differences:
+// - prior versions are stored by (and listed from) the target that stores the object;
+// - no delete markers: deleting the current (latest) version does not remove prior ones.
+
+const (
+	QparamVersions        = "versions"
+	QparamVersionID       = "versionId"
+	QparamKeyMarker       = "key-marker"
+	QparamVersionIDMarker = "version-id-marker"
+
+	ErrCodeNoSuchVersion = "NoSuchVersion"
+
+	nullVersion = "null" // (unversioned object)
+)
+
+type (
+	ListVersionsResult struct {
+		XMLName             xml.Name      `xml:"ListVersionsResult"`
+		Ns                  string        `xml:"xmlns,attr"`
+		Name                string        `xml:"Name"`
+		Prefix              string        `xml:"Prefix"`
+		KeyMarker           string        `xml:"KeyMarker"`
+		VersionIDMarker     string        `xml:"VersionIdMarker"`
+		NextKeyMarker       string        `xml:"NextKeyMarker,omitempty"`
+		NextVersionIDMarker string        `xml:"NextVersionIdMarker,omitempty"`
+		MaxKeys             int           `xml:"MaxKeys"`
+		IsTruncated         bool          `xml:"IsTruncated"`
+		Versions            []*ObjVersion `xml:"Version"`
+	}
+	ObjVersion struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+	}
+)
+
+var ErrNoSuchVersion = errors.New("the specified version does not exist")
+
+func NewListVersionsResult(bucket string, q url.Values) *ListVersionsResult {
+	r := &ListVersionsResult{
+		Ns:              s3Namespace,
+		Name:            bucket,
+		Prefix:          q.Get(QparamPrefix),
+		KeyMarker:       q.Get(QparamKeyMarker),
+		VersionIDMarker: q.Get(QparamVersionIDMarker),
+		MaxKeys:         1000,
+		Versions:        make([]*ObjVersion, 0),
+	}
+	if v, err := strconv.Atoi(q.Get(QparamMaxKeys)); err == nil && v > 0 && v < r.MaxKeys {
+		r.MaxKeys = v
+	}
+	return r
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// add current (latest) versions that follow the markers
+func (r *ListVersionsResult) FromLsoResult(lst *cmn.LsoRes, lsmsg *apc.LsoMsg) {
+	for _, e := range lst.Entries {
+		if e.Flags&apc.EntryIsDir != 0 || !r.AfterMarker(e.Name, e.Version) {
+			continue
+		}
+		ov := &ObjVersion{
+			Key:          e.Name,
+			VersionID:    e.Version,
+			IsLatest:     true,
+			LastModified: e.Atime,
+			ETag:         e.Checksum,
+			Size:         e.Size,
+		}
+		if ov.VersionID == "" {
+			ov.VersionID = nullVersion
+		}
+		if ov.LastModified == "" {
+			ov.LastModified = cos.FormatNanoTime(defaultLastModified, lsmsg.TimeFormat)
+		}
+		r.Versions = append(r.Versions, ov)
+	}
+}
+
+// whether a given version follows the (exclusive) key and version-id markers
+func (r *ListVersionsResult) AfterMarker(key, ver string) bool {
+	if r.KeyMarker == "" || key > r.KeyMarker {
+		return true
+	}
+	return key == r.KeyMarker && r.VersionIDMarker != "" && verNum(ver) < verNum(r.VersionIDMarker)
+}
+
+// by key and, within the same key, most recent version first
+func (r *ListVersionsResult) Sort() {
+	sort.Slice(r.Versions, func(i, j int) bool {
+		vi, vj := r.Versions[i], r.Versions[j]
+		return Before(vi.Key, vi.VersionID, vj.Key, vj.VersionID)
+	})
+}
+
+// apply key and version-id markers and max-keys (the listing may contain up to
+// max-keys + 1 versions from each source - enough to tell whether it's truncated)
+func (r *ListVersionsResult) Finalize() {
+	r.Sort()
+	if r.KeyMarker != "" {
+		vers := r.Versions[:0]
+		for _, v := range r.Versions {
+			if r.AfterMarker(v.Key, v.VersionID) {
+				vers = append(vers, v)
+			}
+		}
+		r.Versions = vers
+	}
+	if len(r.Versions) > r.MaxKeys {
+		r.Versions = r.Versions[:r.MaxKeys]
+		last := r.Versions[r.MaxKeys-1]
+		r.IsTruncated = true
+		r.NextKeyMarker, r.NextVersionIDMarker = last.Key, last.VersionID
+	}
+}
+
+// listing order (see Sort)
+func Before(ki, vi, kj, vj string) bool {
+	if ki != kj {
+		return ki < kj
+	}
+	return verNum(vi) > verNum(vj)
+}
+
+// ais versions are numeric (see core.LOM.IncVersion); "null" sorts last
+func verNum(ver string) int64 {
+	n, err := strconv.ParseInt(ver, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// ValidVersionID returns true if the `versionId` query parameter is a valid ais version
+func ValidVersionID(ver string) bool {
+	if ver == nullVersion {
+		return true
+	}
+	n, err := strconv.ParseInt(ver, 10, 64)
+	return err == nil && n > 0
+}
+
+// IsCurrent returns true if the version ID refers to the current version of the object
+func IsCurrent(ver, current string) bool {
+	return ver == current || (ver == nullVersion && current == "")
+}
+
+// SetVersionID sets `x-amz-version-id` response header
+// (only for ais buckets configured to retain prior versions)
+func SetVersionID(hdr http.Header, lom *core.LOM) {
+	if lom.Bck().IsAIS() && lom.VersionConf().KeepPrior > 0 {
+		if v := lom.Version(); v != "" {
+			hdr.Set(cos.S3VersionHeader, v)
+		}
+	}
+}
+
+// ETag of a prior version (compare w/ SetEtag)
+func PriorETag(oa *cmn.ObjAttrs) string {
+	if v, ok := oa.GetCustomKey(cmn.ETag); ok && !cmn.IsS3MultipartEtag(v) {
+		return v
+	}
+	if oa.Cksum != nil {
+		return oa.Cksum.Value()
+	}
+	return ""
+}
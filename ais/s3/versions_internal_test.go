@@ -0,0 +1,61 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestListVersions(t *testing.T) {
+	versions := func() []*ObjVersion {
+		return []*ObjVersion{
+			{Key: "b", VersionID: "2"},
+			{Key: "a", VersionID: "3", IsLatest: true},
+			{Key: "b", VersionID: "10", IsLatest: true},
+			{Key: "a", VersionID: "1"},
+			{Key: "c", VersionID: nullVersion, IsLatest: true},
+			{Key: "a", VersionID: "2"},
+		}
+	}
+	str := func(vs []*ObjVersion) (s string) {
+		for _, v := range vs {
+			s += v.Key + ":" + v.VersionID + " "
+		}
+		return s
+	}
+	tests := []struct {
+		q    url.Values
+		want string
+		next string
+	}{
+		{url.Values{}, "a:3 a:2 a:1 b:10 b:2 c:null ", ""},
+		{url.Values{QparamMaxKeys: {"2"}}, "a:3 a:2 ", "a:2"},
+		{url.Values{QparamKeyMarker: {"a"}}, "b:10 b:2 c:null ", ""},
+		{url.Values{QparamKeyMarker: {"a"}, QparamVersionIDMarker: {"2"}}, "a:1 b:10 b:2 c:null ", ""},
+		{url.Values{QparamKeyMarker: {"b"}, QparamVersionIDMarker: {"10"}, QparamMaxKeys: {"1"}}, "b:2 ", "b:2"},
+	}
+	for _, test := range tests {
+		r := NewListVersionsResult("bck", test.q)
+		r.Versions = versions()
+		r.Finalize()
+		if got := str(r.Versions); got != test.want {
+			t.Errorf("%v: expected %q, got %q", test.q, test.want, got)
+		}
+		next := ""
+		if r.IsTruncated {
+			next = r.NextKeyMarker + ":" + r.NextVersionIDMarker
+		}
+		if next != test.next {
+			t.Errorf("%v: expected next marker %q, got %q", test.q, test.next, next)
+		}
+	}
+
+	for ver, valid := range map[string]bool{"1": true, "123": true, nullVersion: true, "0": false, "abc": false, "": false} {
+		if ValidVersionID(ver) != valid {
+			t.Errorf("version ID %q: expected valid=%t", ver, valid)
+		}
+	}
+}
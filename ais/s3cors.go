@@ -0,0 +1,56 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Bucket CORS rules (see cmn/cors.go) are enforced by each node (proxy or target)
+// that handles a given cross-origin S3 request, including:
+// - redirected requests (the target sets its own CORS response headers), and
+// - reverse-proxied (and forwarded) requests - see delCORS below.
+
+var errCORSForbidden = errors.New("CORS request is not allowed by the bucket's CORS configuration")
+
+// returns true when the request has been handled (preflight)
+func (h *htrun) s3cors(w http.ResponseWriter, r *http.Request, items []string) bool {
+	if len(items) == 0 || r.Header.Get(cos.HdrOrigin) == "" {
+		return false
+	}
+	preflight := s3.IsPreflight(r)
+	bck, err, ecode := meta.InitByNameOnly(items[0], h.owner.bmd)
+	if err != nil {
+		if preflight {
+			s3.WriteErr(w, r, err, ecode)
+		}
+		return preflight
+	}
+	allowed := s3.SetCORS(w.Header(), r, &bck.Props.CORS)
+	if !preflight {
+		return false
+	}
+	if !allowed {
+		s3.WriteErr(w, r, errCORSForbidden, http.StatusForbidden)
+	}
+	return true
+}
+
+// before reverse-proxying (or forwarding) a request to another node
+// that will set the same CORS response headers - avoid duplicates
+func delCORS(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(cos.HdrOrigin) == "" {
+		return
+	}
+	hdr := w.Header()
+	for _, k := range []string{cos.HdrACAllowOrigin, cos.HdrACAllowCredentials, cos.HdrACExposeHeaders, cos.HdrVary} {
+		hdr.Del(k)
+	}
+}
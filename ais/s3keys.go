@@ -0,0 +1,227 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// S3 access keys are generated and managed by AuthN, which pushes the entire (versioned)
+// list to the primary that, in turn, metasyncs it to all nodes.
+// Every key carries a (never-expiring) token with the permissions of the key's owner -
+// the token is used to authorize S3 requests signed with AWS Signature V4 (see s3/sigv4.go).
+// Keys whose tokens cannot be validated for a retryable reason - AuthN public key not (yet)
+// fetched, e.g. upon startup - are kept pending and validated once the key becomes available
+// (see resolve) or upon first use (see lookup).
+
+type (
+	s3KeyList authn.S3KeyList
+
+	s3key struct {
+		secret string
+		token  string
+		tk     *tok.Token // nil when pending (immutable otherwise)
+	}
+	s3keyOwner struct {
+		list     *s3KeyList
+		keys     map[string]*s3key // by access key ID
+		authkeys *authKeys
+		fpath    string
+		sync.RWMutex
+	}
+)
+
+var s3keysJspOpts = jsp.CCSign(cmn.MetaverS3Keys)
+
+// interface guard
+var _ revs = (*s3KeyList)(nil)
+
+// as revs
+func (*s3KeyList) tag() string          { return revsS3KeysTag }
+func (l *s3KeyList) version() int64     { return l.Version }
+func (l *s3KeyList) marshal() []byte    { return cos.MustMarshal(l) }
+func (*s3KeyList) jit(p *proxy) revs    { return p.owner.s3keys.get() }
+func (*s3KeyList) sgl() *memsys.SGL     { return nil }
+func (l *s3KeyList) String() string     { return fmt.Sprintf("S3KeyList v%d(%d)", l.Version, len(l.Keys)) }
+func (*s3KeyList) JspOpts() jsp.Options { return s3keysJspOpts }
+
+////////////////
+// s3keyOwner //
+////////////////
+
+func newS3keyOwner(config *cmn.Config) *s3keyOwner {
+	return &s3keyOwner{
+		keys:  make(map[string]*s3key),
+		fpath: filepath.Join(config.ConfigDir, fname.S3Keys),
+	}
+}
+
+func (o *s3keyOwner) get() (l *s3KeyList) {
+	o.RLock()
+	l = o.list
+	o.RUnlock()
+	return
+}
+
+func (o *s3keyOwner) load(authkeys *authKeys) {
+	o.authkeys = authkeys
+	authkeys.onFetch = o.resolve
+	l := &s3KeyList{}
+	if _, err := jsp.LoadMeta(o.fpath, l); err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Errorln("failed to load S3 keys:", err)
+		}
+		return
+	}
+	o.put(l, authkeys, false /*persist*/)
+}
+
+// put replaces the current list of keys if the new one has a greater version
+func (o *s3keyOwner) put(l *s3KeyList, authkeys *authKeys, persist bool) (updated bool) {
+	keys := make(map[string]*s3key, len(l.Keys))
+	for _, key := range l.Keys {
+		tk, err := authkeys.decrypt(key.Token)
+		switch {
+		case err == nil:
+			keys[key.ID] = &s3key{secret: key.Secret, token: key.Token, tk: tk}
+		case errors.Is(err, tok.ErrUnknownKID):
+			nlog.Warningf("S3 key %q (user %q): pending AuthN public key: %v", key.ID, key.UserID, err)
+			keys[key.ID] = &s3key{secret: key.Secret, token: key.Token}
+		default:
+			nlog.Errorf("S3 key %q (user %q): invalid token: %v", key.ID, key.UserID, err)
+		}
+	}
+
+	o.Lock()
+	defer o.Unlock()
+	if o.list != nil && l.Version <= o.list.Version {
+		return false
+	}
+	o.list, o.keys = l, keys
+	if persist {
+		if err := jsp.SaveMeta(o.fpath, l, nil /*wto*/); err != nil {
+			nlog.Errorln("failed to persist", l.String()+":", err)
+		}
+	}
+	return true
+}
+
+// returns validated keys only
+func (o *s3keyOwner) lookup(id string) *s3key {
+	o.RLock()
+	key := o.keys[id]
+	o.RUnlock()
+	if key == nil || key.tk != nil {
+		return key
+	}
+	return o.validate(id, key)
+}
+
+// validate pending key (compare w/ put)
+func (o *s3keyOwner) validate(id string, key *s3key) *s3key {
+	tk, err := o.authkeys.decrypt(key.token)
+	if err != nil {
+		return nil
+	}
+	nkey := &s3key{secret: key.secret, token: key.token, tk: tk}
+	o.Lock()
+	if o.keys[id] == key {
+		o.keys[id] = nkey
+	}
+	o.Unlock()
+	return nkey
+}
+
+// upon (first) successful fetch of AuthN public keys (see authKeys.onFetch)
+func (o *s3keyOwner) resolve() {
+	pending := make(map[string]*s3key, 4)
+	o.RLock()
+	for id, key := range o.keys {
+		if key.tk == nil {
+			pending[id] = key
+		}
+	}
+	o.RUnlock()
+	for id, key := range pending {
+		if o.validate(id, key) == nil {
+			nlog.Errorf("S3 key %q: failed to validate token", id)
+		}
+	}
+}
+
+//
+// htrun: receive
+//
+
+func (h *htrun) extractS3Keys(payload msPayload, caller string) (*s3KeyList, error) {
+	bytes, ok := payload[revsS3KeysTag]
+	if !ok {
+		return nil, nil
+	}
+	l := &s3KeyList{}
+	if err := jsoniter.Unmarshal(bytes, l); err != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, h, "S3 key list", cos.BHead(bytes), err)
+		return nil, err
+	}
+	nlog.Infof("extract %s from %q", l, caller)
+	return l, nil
+}
+
+func (h *htrun) receiveS3Keys(l *s3KeyList) {
+	_ = h.owner.s3keys.put(l, h.authkeys, true /*persist*/)
+}
+
+//
+// proxy: PUT /v1/tokens/s3keys (AuthN => primary)
+//
+
+func (p *proxy) putS3Keys(w http.ResponseWriter, r *http.Request) {
+	items, err := p.parseURL(w, r, apc.URLPathTokens.L, 1, false)
+	if err != nil {
+		return
+	}
+	if items[0] != apc.S3Keys {
+		p.writeErrURL(w, r)
+		return
+	}
+	tk, err := p.validateToken(r.Header)
+	if err != nil {
+		p.writeErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	if !tk.IsAdmin {
+		p.writeErrf(w, r, "%s: S3 access keys can be updated only by AuthN (admin)", p)
+		return
+	}
+	if p.forwardCP(w, r, nil, "update S3 keys") {
+		return
+	}
+	l := &s3KeyList{}
+	if err := cmn.ReadJSON(w, r, l); err != nil {
+		return
+	}
+	if !p.owner.s3keys.put(l, p.authkeys, true /*persist*/) {
+		nlog.Warningln(p.String(), "ignoring", l.String(), "- have newer or same")
+		return
+	}
+	msg := p.newAmsgStr("update-s3keys", nil)
+	_ = p.metasyncer.sync(revsPair{l, msg})
+}
@@ -0,0 +1,87 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// S3 keys loaded prior to fetching AuthN public keys must not get lost
+func TestS3KeysPending(tt *testing.T) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		tt.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(pk)
+	if err != nil {
+		tt.Fatal(err)
+	}
+	sk, err := tok.ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		tt.Fatal(err)
+	}
+	token, err := tok.JWT(time.Now().Add(time.Hour), "alice", nil, nil, nil, sk)
+	if err != nil {
+		tt.Fatal(err)
+	}
+
+	var available atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		jsoniter.NewEncoder(w).Encode(&tok.JWKS{Keys: []*tok.JWK{sk.JWK()}})
+	}))
+	defer srv.Close()
+
+	config := &cmn.Config{}
+	config.Auth.JWKSURL = srv.URL
+	ak := newAuthKeys(config)
+	o := &s3keyOwner{keys: make(map[string]*s3key), fpath: filepath.Join(tt.TempDir(), "s3keys")}
+	o.load(ak)
+
+	l := &s3KeyList{Version: 1, Keys: []*authn.S3Key{{ID: "AKID", Secret: "secret", UserID: "alice", Token: token}}}
+	if !o.put(l, ak, false /*persist*/) {
+		tt.Fatal("expected the list to be updated")
+	}
+	if key := o.lookup("AKID"); key != nil {
+		tt.Fatalf("expected pending (not validated) key, got %+v", key)
+	}
+	if len(o.keys) != 1 {
+		tt.Fatalf("expected the key to be kept, got %d", len(o.keys))
+	}
+
+	available.Store(true)
+	ak.fmu.Lock()
+	ak.tried = 0 // (skip jwksMinRefresh)
+	ak.fmu.Unlock()
+
+	key := o.lookup("AKID")
+	if key == nil || key.tk == nil || key.tk.UserID != "alice" {
+		tt.Fatalf("expected validated key, got %+v", key)
+	}
+	o.RLock()
+	resolved := o.keys["AKID"].tk != nil
+	o.RUnlock()
+	if !resolved {
+		tt.Fatal("expected the key to be resolved in place")
+	}
+}
@@ -38,11 +38,13 @@ import (
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/volume"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -328,9 +330,12 @@ func (t *target) Run() error {
 		nlog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object type, workfile type, S3 multipart, prior versions, and tar indexes
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.MptType, &fs.MptContentResolver{})
+	fs.CSM.Reg(fs.PriorVerType, &fs.PriorVerContentResolver{})
+	fs.CSM.Reg(fs.TarIdxType, &fs.TarIdxContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -379,6 +384,9 @@ func (t *target) Run() error {
 
 	// end target metrics -----------------------
 
+	// active S3 multipart uploads (that survived restart)
+	s3.LoadUploads(t.owner.bmd.Get())
+
 	db, err := kvdb.NewBuntDB(filepath.Join(config.ConfigDir, dbName))
 	if err != nil {
 		nlog.Errorln(t.String(), "failed to initialize kvdb:", err)
@@ -397,6 +405,12 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	hk.Reg(apc.ActLifecycle+hk.NameSuffix, t.lifecycleHK, lifecycleIval)
+	hk.Reg("quota"+hk.NameSuffix, t.quotaHK, quotaIval)
+	hk.Reg(apc.ActECScrub+hk.NameSuffix, (&ecScrubSched{t: t}).housekeep, ecScrubIval)
+	t.rlim.init(t.statsT)
+	t.audit.init(&t.htrun, t.auditUser)
+	t.initTracing()
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -500,6 +514,7 @@ func (t *target) initRecvHandlers() {
 		{r: apc.Download, h: t.downloadHandler, net: accessNetIntraControl},
 		{r: apc.Sort, h: dsort.TargetHandler, net: accessControlData},
 		{r: apc.ETL, h: t.etlHandler, net: accessNetAll},
+		{r: apc.GetBatch, h: t.getBatchHandler, net: accessNetAll},
 
 		{r: "/" + apc.S3, h: t.s3Handler, net: accessNetPublicData},
 		{r: "/", h: t.errURL, net: accessNetAll},
@@ -722,6 +737,14 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		return lom, err
 	}
 
+	// (see s3ratelim for direct S3 GETs)
+	tk := t.userToken(r)
+	if dpq.ptime != "" {
+		if err := t.rlim.allowGet(lom.Bck(), tk); err != nil {
+			return lom, err
+		}
+	}
+
 	// GET: regular | archive | range
 	goi := allocGOI()
 	{
@@ -736,8 +759,9 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		goi.lom = lom
 		goi.dpq = dpq
 		goi.req = r
+		goi.tk = tk
 		goi.w = w
-		goi.ctx = context.Background()
+		goi.ctx = tracing.Detach(r.Context()) // (not to cancel cold GET)
 		goi.ranges = byteRanges{Range: r.Header.Get(cos.HdrRange), Size: 0}
 		goi.latestVer = _validateWarmGet(goi.lom, dpq.latestVer) // apc.QparamLatestVer || versioning.*_warm_get
 	}
@@ -855,7 +879,11 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		// do
 		lom.Lock(true)
-		ecode, err = t.putApndArch(r, lom, started, apireq.dpq)
+		if err = lom.CheckObjLock(); err != nil {
+			ecode = http.StatusForbidden
+		} else {
+			ecode, err = t.putApndArch(r, lom, started, apireq.dpq)
+		}
 		lom.Unlock(true)
 	case apireq.dpq.apnd.ty != "": // apc.QparamAppendType
 		a := &apndOI{
@@ -870,6 +898,13 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 			t.writeErr(w, r, err)
 			return
 		}
+		lom.Lock(false)
+		err = lom.CheckObjLock()
+		lom.Unlock(false)
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
 		handle, ecode, err = a.do(r)
 		if err == nil && handle != "" {
 			w.Header().Set(apc.HdrAppendHandle, handle)
@@ -924,8 +959,12 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request, apireq *a
 		return
 	}
 
+	started := mono.NanoTime()
 	ecode, err := t.DeleteObject(lom, evict)
 	if err == nil && ecode == 0 {
+		if !evict {
+			t.statsBck(stats.BckDelete, lom, r, 0, mono.SinceNano(started))
+		}
 		// EC cleanup if EC is enabled
 		ec.ECM.CleanupObject(lom)
 	} else {
@@ -968,6 +1007,24 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 		} else {
 			t.statsT.IncErr(stats.ErrRenameCount)
 		}
+	case apc.ActObjLock:
+		var (
+			lockMsg apc.ObjLockMsg
+			ecode   int
+		)
+		if err = cos.MorphMarshal(msg.Value, &lockMsg); err != nil {
+			err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+			break
+		}
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if ecode, err = t.setObjLock(lom, &lockMsg); err != nil {
+			t.writeErr(w, r, err, ecode)
+		}
+		core.FreeLOM(lom)
+		return
 	case apc.ActBlobDl:
 		// TODO: add stats.GetBlobCount and *ErrCount
 		var (
@@ -1196,6 +1253,12 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		return
 	}
 	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
+	if lom.Bprops().ObjLock.Enabled {
+		if err := objLockCustom(lom, custom, delOldSetNew); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
 	if delOldSetNew {
 		lom.SetCustomMD(custom)
 	} else {
@@ -1242,8 +1305,10 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 			return http.StatusNotFound, err
 		}
 		a.put = true
+		a.osize = -1
 	} else {
 		a.put = (flags == 0)
+		a.osize = lom.Lsize()
 	}
 	if s := r.Header.Get(cos.HdrContentLength); s != "" {
 		if size, err := strconv.ParseInt(s, 10, 64); err == nil {
@@ -1254,15 +1319,31 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 		return http.StatusBadRequest, fmt.Errorf("failed to archive %s: missing %q in the request",
 			lom.Cname(), cos.HdrContentLength)
 	}
-	return a.do()
+	// (approximately - not counting archive headers)
+	rsv, err := t.reserveQuota(lom, a.osize, max(a.osize, 0)+a.size, true /*enforce*/)
+	if err != nil {
+		return http.StatusInsufficientStorage, err
+	}
+	a.rsv = rsv
+	ecode, err := a.do()
+	rsv.rollback() // (no-op if committed)
+	return ecode, err
 }
 
-func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
-	var isback bool
+func (t *target) DeleteObject(lom *core.LOM, evict bool) (int, error) {
 	lom.Lock(true)
-	code, err, isback = t.delobj(lom, evict)
+	code, err, isback := t.delobj(lom, evict)
 	lom.Unlock(true)
+	return t.delobjFin(lom, evict, code, err, isback)
+}
 
+// same as above with the caller holding the write lock
+func (t *target) delObjLocked(lom *core.LOM, evict bool) (int, error) {
+	code, err, isback := t.delobj(lom, evict)
+	return t.delobjFin(lom, evict, code, err, isback)
+}
+
+func (t *target) delobjFin(lom *core.LOM, evict bool, code int, err error, isback bool) (int, error) {
 	// special corner-case retry (quote):
 	// - googleapi: "Error 503: We encountered an internal error. Please try again."
 	// - aws-error[InternalError: We encountered an internal error. Please try again.]
@@ -1310,6 +1391,10 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 		}
 	} else {
 		delFromAIS = true
+		// object lock (WORM)
+		if err := lom.Bprops().ObjLock.Check(lom.Cname(), lom.GetCustomMD(), time.Now()); err != nil {
+			return http.StatusForbidden, err, false
+		}
 	}
 
 	// do
@@ -1354,6 +1439,14 @@ func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 	if msg.Name == lom.ObjName {
 		return fmt.Errorf("%s: cannot rename/move object %s onto itself", t.si, lom)
 	}
+	if lom.Bprops().ObjLock.Enabled {
+		lom.Lock(false)
+		err = lom.CheckObjLock()
+		lom.Unlock(false)
+		if err != nil {
+			return err
+		}
+	}
 
 	buf, slab := t.gmm.Alloc()
 	coiParams := core.AllocCOI()
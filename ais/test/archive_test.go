@@ -6,7 +6,9 @@ package integration_test
 
 import (
 	"archive/tar"
+	"bytes"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"net/url"
 	"os"
@@ -90,6 +92,12 @@ func TestGetFromArch(t *testing.T) {
 				{
 					ext: archive.ExtTarLz4, nested: true, autodetect: true, mime: true,
 				},
+				{
+					ext: archive.ExtTarZst, nested: false, autodetect: false, mime: false,
+				},
+				{
+					ext: archive.ExtTarZst, nested: true, autodetect: true, mime: true,
+				},
 			}
 		)
 		if testing.Short() {
@@ -268,6 +276,9 @@ func testArch(t *testing.T, bck *meta.Bck) {
 			{
 				ext: archive.ExtTarLz4, list: false,
 			},
+			{
+				ext: archive.ExtTarZst, list: true, apnd: true,
+			},
 		}
 	)
 	if testing.Short() {
@@ -494,6 +505,9 @@ func TestAppendToArch(t *testing.T) {
 			{
 				ext: archive.ExtTarLz4, multi: true,
 			},
+			{
+				ext: archive.ExtTarZst, multi: false,
+			},
 		}
 	)
 	if !testing.Short() { // test-long, and see one other Skip below
@@ -611,3 +625,70 @@ func TestAppendToArch(t *testing.T) {
 		})
 	}
 }
+
+//
+// get-batch
+//
+
+func TestGetBatch(t *testing.T) {
+	var (
+		bck = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		m   = ioContext{
+			t:         t,
+			bck:       bck,
+			num:       50,
+			fileSize:  4 * cos.KiB,
+			fixedSize: true,
+			prefix:    "get-batch/",
+		}
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+		missing    = "get-batch/does-not-exist"
+	)
+	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
+	m.init(true /*cleanup*/)
+	m.puts()
+
+	for _, coer := range []bool{false, true} {
+		t.Run(fmt.Sprintf("cont-on-err=%t", coer), func(t *testing.T) {
+			msg := &apc.GetBatchMsg{ContinueOnError: coer}
+			for i, objName := range m.objNames {
+				if i == m.num/2 {
+					msg.In = append(msg.In, apc.GetBatchIn{ObjName: missing})
+				}
+				msg.In = append(msg.In, apc.GetBatchIn{ObjName: objName})
+			}
+
+			buf := &bytes.Buffer{}
+			_, err := api.GetBatch(baseParams, bck, msg, buf)
+			if !coer {
+				tassert.Fatalf(t, err != nil, "expecting GET of a missing entry (%s) to fail", missing)
+				return
+			}
+			tassert.CheckFatal(t, err)
+
+			// expecting all entries, in order
+			var (
+				tr = tar.NewReader(buf)
+				i  int
+			)
+			for ; ; i++ {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				tassert.CheckFatal(t, err)
+				tassert.Fatalf(t, i < len(msg.In), "unexpected entry %q", hdr.Name)
+				expected := path.Join(bck.Name, msg.In[i].ObjName)
+				if msg.In[i].ObjName == missing {
+					expected = path.Join(apc.GetBatchMissingDir, expected)
+					tassert.Errorf(t, hdr.Size == 0, "expecting empty %q, got size %d", hdr.Name, hdr.Size)
+				} else {
+					tassert.Errorf(t, hdr.Size == int64(m.fileSize), "%q: expecting size %d, got %d", hdr.Name, m.fileSize, hdr.Size)
+				}
+				tassert.Fatalf(t, hdr.Name == expected, "entry #%d: expecting %q, got %q", i, expected, hdr.Name)
+			}
+			tassert.Errorf(t, i == len(msg.In), "expecting %d entries, got %d", len(msg.In), i)
+		})
+	}
+}
@@ -545,8 +545,8 @@ func damageMetadataCksum(t *testing.T, slicePath string) {
 	tassert.CheckFatal(t, err)
 }
 
-// Short test to make sure that EC options cannot be changed after
-// EC is enabled
+// Short test to make sure that EC options (except data and parity slices)
+// cannot be changed after EC is enabled
 func TestECChange(t *testing.T) {
 	tools.CheckSkip(t, &tools.SkipTestArgs{MinTargets: 3})
 
@@ -961,6 +961,76 @@ func createECFile(t *testing.T, baseParams api.BaseParams, bck cmn.Bck, objName
 	return foundParts, mainObjPath
 }
 
+// Removes one slice and corrupts another, and then runs ec-scrub
+// to make sure that the object's redundancy gets fully restored
+func TestECScrub(t *testing.T) {
+	tools.CheckSkip(t, &tools.SkipTestArgs{RequiredDeployment: tools.ClusterTypeLocal})
+	if docker.IsRunning() {
+		t.Skipf("test %q requires xattrs to be set, doesn't work with docker", t.Name())
+	}
+
+	var (
+		proxyURL = tools.RandomProxyURL()
+		bck      = cmn.Bck{
+			Name:     testBucketName + "-ec-scrub",
+			Provider: apc.AIS,
+		}
+	)
+
+	o := ecOptions{
+		minTargets:   4,
+		dataCnt:      1,
+		parityCnt:    2,
+		pattern:      "obj-scrub-%04d",
+		objSizeLimit: ecObjLimit,
+	}.init(t, proxyURL)
+	baseParams := tools.BaseAPIParams(proxyURL)
+	initMountpaths(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+
+	objName := fmt.Sprintf(o.pattern, 1)
+	foundParts, _ := createECFile(t, baseParams, bck, objName, o)
+
+	var removed, damaged bool
+	for fqn := range foundParts {
+		ct, err := core.NewCTFromFQN(fqn, nil)
+		tassert.CheckFatal(t, err)
+		if ct.ContentType() != fs.ECSliceType {
+			continue
+		}
+		if !removed {
+			tlog.Logf("Removing slice %q\n", fqn)
+			tassert.CheckFatal(t, os.Remove(fqn))
+			tassert.CheckFatal(t, os.Remove(ct.Make(fs.ECMetaType)))
+			removed = true
+		} else if !damaged {
+			tlog.Logf("Damaging slice %q\n", fqn)
+			damageMetadataCksum(t, fqn)
+			damaged = true
+		}
+	}
+	tassert.Fatalf(t, removed && damaged, "expected at least two slices, got %+v", foundParts)
+
+	// first pass removes the corrupted slice (and may or may not rebuild it right away)
+	for range 2 {
+		xid, err := api.StartXaction(baseParams, &xact.ArgsMsg{Kind: apc.ActECScrub, Bck: bck}, "")
+		tassert.CheckFatal(t, err)
+		xargs := xact.ArgsMsg{ID: xid, Kind: apc.ActECScrub, Timeout: tools.RebalanceTimeout}
+		_, err = api.WaitForXactionIC(baseParams, &xargs)
+		tassert.CheckFatal(t, err)
+	}
+
+	var (
+		totalCnt  = 2 + o.sliceTotal()*2
+		objSize   = int64(ecMinBigSize * 2)
+		sliceSize = ec.SliceSize(objSize, o.dataCnt)
+	)
+	foundParts, mainObjPath := waitForECFinishes(t, totalCnt, objSize, sliceSize, true, bck, ecTestDir+objName)
+	tassert.Fatalf(t, mainObjPath != "", "Full copy %s was not found", mainObjPath)
+	ecCheckSlices(t, foundParts, bck, ecTestDir+objName, objSize, sliceSize, totalCnt)
+}
+
 // Creates 2 EC files and then corrupts their slices
 // Checks that after corrupting one slice it is still possible to recover an object
 // Checks that after corrupting all slices it is not possible to recover an object
@@ -2256,6 +2326,66 @@ func TestECBucketEncode(t *testing.T) {
 	//
 }
 
+// Erasure-codes a bucket and then changes its (D, P) - to re-encode all
+// the objects in place while making sure they remain readable
+func TestECBucketReencode(t *testing.T) {
+	const (
+		dataCnt, parityCnt       = 1, 1
+		newDataCnt, newParityCnt = 2, 1
+	)
+	var (
+		proxyURL = tools.RandomProxyURL()
+		m        = ioContext{
+			t:               t,
+			num:             100,
+			numGetsEachFile: 1,
+			proxyURL:        proxyURL,
+		}
+	)
+
+	m.initAndSaveState(true /*cleanup*/)
+	baseParams := tools.BaseAPIParams(proxyURL)
+
+	if nt := m.smap.CountActiveTs(); nt < newParityCnt+newDataCnt+1 {
+		t.Skipf("%s: not enough targets (%d): (d=%d, p=%d) requires at least %d",
+			t.Name(), nt, newDataCnt, newParityCnt, newParityCnt+newDataCnt+1)
+	}
+
+	tools.CreateBucket(t, proxyURL, m.bck, nil, true /*cleanup*/)
+	m.puts()
+
+	tlog.Logf("Erasure-coding %s (d=%d, p=%d)\n", m.bck, dataCnt, parityCnt)
+	xid, err := api.ECEncodeBucket(baseParams, m.bck, dataCnt, parityCnt)
+	tassert.CheckFatal(t, err)
+	xargs := xact.ArgsMsg{ID: xid, Kind: apc.ActECEncode, Timeout: tools.RebalanceTimeout}
+	_, err = api.WaitForXactionIC(baseParams, &xargs)
+	tassert.CheckFatal(t, err)
+
+	tlog.Logf("Re-encoding %s (d=%d, p=%d)\n", m.bck, newDataCnt, newParityCnt)
+	_, err = api.SetBucketProps(baseParams, m.bck, &cmn.BpropsToSet{
+		EC: &cmn.ECConfToSet{
+			DataSlices:   apc.Ptr(newDataCnt),
+			ParitySlices: apc.Ptr(newParityCnt),
+		},
+	})
+	tassert.CheckFatal(t, err)
+
+	// concurrently with re-encoding
+	m.gets(nil, false)
+
+	xargs = xact.ArgsMsg{Kind: apc.ActECEncode, Bck: m.bck, Timeout: tools.RebalanceTimeout}
+	_, err = api.WaitForXactionIC(baseParams, &xargs)
+	tassert.CheckFatal(t, err)
+
+	p, err := api.HeadBucket(baseParams, m.bck, true /* don't add */)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, p.EC.DataSlices == newDataCnt && p.EC.ParitySlices == newParityCnt,
+		"expected (d=%d, p=%d), got (d=%d, p=%d)", newDataCnt, newParityCnt, p.EC.DataSlices, p.EC.ParitySlices)
+
+	m.gets(nil, false)
+	m.ensureNoGetErrors()
+}
+
 // Creates two buckets (with EC enabled and disabled), fill them with data,
 // and then runs two parallel rebalances
 func TestECAndRegularRebalance(t *testing.T) {
@@ -0,0 +1,173 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// get-batch (see apc.GetBatchMsg and xs.XactGetBatch):
+// - GET:  designated target (DT) starts the xaction, tells the other targets which entries
+//         to send, and writes the response;
+// - POST: DT => other targets (intra-cluster)
+
+// DT => other targets
+type gbPeerMsg struct {
+	Msg apc.GetBatchMsg  `json:"msg"`
+	DT  string           `json:"dt"`
+	Idx map[string][]int `json:"idx"` // by target ID
+}
+
+// verb /v1/get-batch
+func (t *target) getBatchHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		t.httpgbget(w, r)
+	case http.MethodPost:
+		t.httpgbpost(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
+// GET /v1/get-batch[/bucket-name]?uuid=...
+func (t *target) httpgbget(w http.ResponseWriter, r *http.Request) {
+	items, err := t.parseURL(w, r, apc.URLPathGetBatch.L, 0, true)
+	if err != nil {
+		return
+	}
+	msg := &apc.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	query := r.URL.Query()
+	if err := gbNormalize(msg, items, query); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	uuid := query.Get(apc.QparamUUID)
+	if uuid == "" {
+		t.writeErrf(w, r, "%s: missing %q query parameter", apc.ActGetBatch, apc.QparamUUID)
+		return
+	}
+
+	// 1. start
+	rns := xreg.RenewGetBatch(uuid, &xreg.GetBatchArgs{Msg: msg, DT: t.SID()})
+	if rns.Err != nil {
+		t.writeErr(w, r, rns.Err)
+		return
+	}
+	xctn := rns.Entry.Get()
+	if rns.IsRunning() {
+		t.writeErrf(w, r, "%s: %s is already running", t, xctn)
+		return
+	}
+	xgb := xctn.(*xs.XactGetBatch)
+
+	// 2. tell the other targets to start sending their entries
+	if idx := xgb.PeerIdx(); len(idx) > 0 {
+		t.gbStartPeers(xgb, msg, idx, query)
+	}
+
+	// 3. write the response
+	if msg.Mime == archive.ExtTar {
+		w.Header().Set(cos.HdrContentType, cos.ContentTar)
+	} else {
+		w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	}
+	written, err := xgb.Assemble(w, msg.Mime)
+	if err == nil {
+		t.statsT.Inc(stats.GetCount)
+		return
+	}
+	t.statsT.IncErr(stats.ErrGetCount)
+	if written == 0 {
+		t.writeErr(w, r, err)
+	} else {
+		// (too late to respond with an error status)
+		nlog.Errorln(t.String(), xgb.Name(), "failed after writing", written, "bytes:", err)
+	}
+}
+
+func (t *target) gbStartPeers(xgb *xs.XactGetBatch, msg *apc.GetBatchMsg, idx map[string][]int, query url.Values) {
+	var (
+		smap  = t.owner.smap.get()
+		nodes = make(meta.Nodes, 0, len(idx))
+		body  = cos.MustMarshal(&gbPeerMsg{Msg: *msg, DT: t.SID(), Idx: idx})
+	)
+	for tid := range idx {
+		tsi := smap.GetActiveNode(tid)
+		if tsi == nil {
+			xgb.PeerFailed(tid, &errNodeNotFound{apc.ActGetBatch + " failure:", tid, t.si, smap})
+			continue
+		}
+		nodes = append(nodes, tsi)
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPost,
+		Path:   apc.URLPathGetBatch.S,
+		Query:  url.Values{apc.QparamUUID: query[apc.QparamUUID]},
+		Body:   body,
+	}
+	args.network = cmn.NetIntraControl
+	args.selected = nodes
+	args.nodeCount = len(nodes)
+	args.smap = smap
+	results := t.bcastSelected(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			xgb.PeerFailed(res.si.ID(), res.toErr())
+		}
+	}
+	freeBcastRes(results)
+}
+
+// POST /v1/get-batch?uuid=...
+func (t *target) httpgbpost(w http.ResponseWriter, r *http.Request) {
+	if !t.ensureIntraControl(w, r, false /* from primary */) {
+		return
+	}
+	if _, err := t.parseURL(w, r, apc.URLPathGetBatch.L, 0, false); err != nil {
+		return
+	}
+	body, err := cos.ReadAllN(r.Body, r.ContentLength)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	pmsg := &gbPeerMsg{}
+	if err := jsoniter.Unmarshal(body, pmsg); err != nil {
+		t.writeErr(w, r, fmt.Errorf(cmn.FmtErrUnmarshal, t, apc.ActGetBatch, cos.BHead(body), err))
+		return
+	}
+	idx, ok := pmsg.Idx[t.SID()]
+	if !ok || len(idx) == 0 {
+		t.writeErrf(w, r, "%s: no %s entries to send", t, apc.ActGetBatch)
+		return
+	}
+	uuid := r.URL.Query().Get(apc.QparamUUID)
+	rns := xreg.RenewGetBatch(uuid, &xreg.GetBatchArgs{Msg: &pmsg.Msg, DT: pmsg.DT, Idx: idx})
+	if rns.Err != nil {
+		t.writeErr(w, r, rns.Err)
+	}
+}
@@ -384,6 +384,8 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	case apc.WhatSysInfo:
 		tsysinfo := apc.TSysInfo{MemCPUInfo: apc.GetMemCPU(), CapacityInfo: fs.CapStatusGetWhat()}
 		t.writeJSON(w, r, tsysinfo, httpdaeWhat)
+	case apc.WhatBckUsage:
+		t.writeJSON(w, r, core.AllUsages(), httpdaeWhat)
 	case apc.WhatNodeStats:
 		ds := t.statsAndStatus()
 		daeStats := t.statsT.GetStats()
@@ -1109,6 +1111,7 @@ func (t *target) metasyncPut(w http.ResponseWriter, r *http.Request) {
 		newBMD, msgBMD, errBMD       = t.extractBMD(payload, caller)
 		newRMD, msgRMD, errRMD       = t.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = t.extractEtlMD(payload, caller)
+		s3keys, errS3keys            = t.extractS3Keys(payload, caller)
 	)
 	// 2. apply
 	if errConf == nil && newConf != nil {
@@ -1131,12 +1134,15 @@ func (t *target) metasyncPut(w http.ResponseWriter, r *http.Request) {
 	if errEtlMD == nil && newEtlMD != nil {
 		errEtlMD = t.receiveEtlMD(newEtlMD, msgEtlMD, payload, caller, _stopETLs)
 	}
+	if errS3keys == nil && s3keys != nil {
+		t.receiveS3Keys(s3keys)
+	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errEtlMD == nil && errS3keys == nil {
 		return
 	}
 	t.fillNsti(nsti)
-	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, nil)
+	retErr := err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errS3keys)
 	t.writeErr(w, r, retErr, http.StatusConflict)
 }
 
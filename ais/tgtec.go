@@ -19,10 +19,20 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
 var errCloseStreams = errors.New("EC is currently active, cannot close streams")
 
+// periodic EC scrubbing (see ec/scrub.go): each target independently runs ec-scrub
+// for every erasure-coded bucket configured with non-zero `ec.scrub_interval`
+const ecScrubIval = time.Minute // how often to check which buckets are due
+
+type ecScrubSched struct {
+	t    *target
+	last map[uint64]int64 // bucket ID => when (mono) scrubbing was started last time
+}
+
 func (t *target) ecHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -113,3 +123,41 @@ func closeEc(int64) time.Duration {
 func errActEc(act string) error {
 	return fmt.Errorf(fmtErrInvaldAction, act, []string{apc.ActEcOpen, apc.ActEcClose})
 }
+
+func (sched *ecScrubSched) housekeep(now int64) time.Duration {
+	t := sched.t
+	if !t.ClusterStarted() {
+		return ecScrubIval
+	}
+	if sched.last == nil {
+		sched.last = make(map[uint64]int64, 4)
+	}
+	seen := make(map[uint64]struct{}, len(sched.last))
+	t.owner.bmd.get().Range(nil, nil, func(bck *meta.Bck) bool {
+		ecConf := &bck.Props.EC
+		if !ecConf.Enabled || ecConf.ScrubIval == 0 {
+			return false
+		}
+		seen[bck.Props.BID] = struct{}{}
+		last, ok := sched.last[bck.Props.BID]
+		if !ok {
+			// (not scrubbing right away upon startup)
+			sched.last[bck.Props.BID] = now
+			return false
+		}
+		if time.Duration(now-last) < ecConf.ScrubIval.D() {
+			return false
+		}
+		sched.last[bck.Props.BID] = now
+		if rns := xreg.RenewECScrub(cos.GenUUID(), bck); rns.Err != nil {
+			nlog.Errorln("failed to run ec-scrub on", bck.Cname(""), "err:", rns.Err)
+		}
+		return false
+	})
+	for bid := range sched.last {
+		if _, ok := seen[bid]; !ok {
+			delete(sched.last, bid)
+		}
+	}
+	return ecScrubIval
+}
@@ -22,11 +22,8 @@ import (
 )
 
 // [METHOD] /v1/etl
+// (K8s is required only for etl.PlatformK8s - see handleETLPut)
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if !k8s.IsK8s() {
-		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -66,6 +63,10 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if initMsg.Platform() == etl.PlatformK8s && !k8s.IsK8s() {
+		t.writeErr(w, r, k8s.ErrK8sRequired)
+		return
+	}
 	xid := r.URL.Query().Get(apc.QparamUUID)
 
 	switch msg := initMsg.(type) {
@@ -73,6 +74,8 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		err = etl.InitSpec(msg, xid, etl.StartOpts{})
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(msg, xid)
+	case *etl.InitPipelineMsg:
+		err = etl.InitPipeline(msg, xid)
 	default:
 		debug.Assert(false, initMsg.String())
 	}
@@ -116,7 +119,9 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 	case apc.ETLHealth:
 		t.healthETL(w, r, apiItems[0])
 	case apc.ETLMetrics:
-		k8s.InitMetricsClient()
+		if k8s.IsK8s() {
+			k8s.InitMetricsClient()
+		}
 		t.metricsETL(w, r, apiItems[0])
 	default:
 		t.writeErrURL(w, r)
@@ -170,8 +175,9 @@ func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName string,
 	if err := comm.InlineTransform(w, r, lom); err != nil {
 		errV := cmn.NewErrETL(&cmn.ETLErrCtx{ETLName: etlName, PodName: comm.PodName(), SvcName: comm.SvcName()},
 			err.Error())
-		xetl := comm.Xact()
-		xetl.AddErr(errV)
+		if xetl := comm.Xact(); xetl != nil { // (nil when ad-hoc pipeline)
+			xetl.AddErr(errV)
+		}
 		t.writeErr(w, r, errV)
 	}
 }
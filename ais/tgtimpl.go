@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
@@ -38,6 +39,14 @@ func (t *target) Health(si *meta.Snode, timeout time.Duration, query url.Values)
 }
 
 func (t *target) Backend(bck *meta.Bck) core.Backend {
+	bp := t._backend(bck)
+	if tracing.IsEnabled() {
+		return backend.Traced(bp)
+	}
+	return bp
+}
+
+func (t *target) _backend(bck *meta.Bck) core.Backend {
 	if bck.IsRemoteAIS() {
 		return t.backend[apc.AIS]
 	}
@@ -0,0 +1,47 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// bucket lifecycle rules (see cmn/lifecycle.go) are executed periodically
+// by each target, independently, for its own content
+const lifecycleIval = time.Hour
+
+func (t *target) lifecycleHK(int64) time.Duration {
+	if !t.ClusterStarted() {
+		return lifecycleIval
+	}
+	provider := apc.AIS
+	t.owner.bmd.get().Range(&provider, nil, func(bck *meta.Bck) bool {
+		if bck.Props.Lifecycle.IsEnabled() {
+			t.runLifecycle(cos.GenUUID(), bck)
+		}
+		return false
+	})
+	return lifecycleIval
+}
+
+func (*target) runLifecycle(id string, bck *meta.Bck) xreg.RenewRes {
+	args := &xreg.LifecycleArgs{
+		AbortMpt: func(bck *meta.Bck) int {
+			return s3.AbortStale(bck.Name, bck.Props.Lifecycle.AbortMpt)
+		},
+	}
+	rns := xreg.RenewLifecycle(id, bck, args)
+	if rns.Err != nil {
+		nlog.Errorln("failed to run lifecycle on", bck.Cname(""), "err:", rns.Err)
+	}
+	return rns
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -37,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -77,6 +79,7 @@ type (
 		t          *target         // this
 		lom        *core.LOM       // obj
 		dpq        *dpq
+		tk         *tok.Token // user to charge GET bytes (see rlimiters.take)
 		ranges     byteRanges // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
 		atime      int64      // access time.Now()
 		ltime      int64      // mono.NanoTime, to measure latency
@@ -136,6 +139,8 @@ type (
 		mime     string        // format
 		started  int64         // time of receiving
 		size     int64         // aka Content-Length
+		osize    int64         // size of the existing shard (-1 if none)
+		rsv      *quotaRsv     // bucket quota (see tgtquota.go)
 		put      bool          // overwrite
 	}
 )
@@ -173,6 +178,12 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 			poi.size = size
 		}
 	}
+	// bucket quota: fail early, without receiving the payload
+	if poi.size > 0 && poi.owt < cmn.OwtRebalance && poi.lom.Bprops().Quota.IsSet() {
+		if err := poi.t.checkQuota(poi.lom, poi.osizeQuota(), poi.size); err != nil {
+			return http.StatusInsufficientStorage, err
+		}
+	}
 	return poi.putObject()
 }
 
@@ -189,8 +200,14 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		}
 	}
 
+	var ctx context.Context
+	if poi.oreq != nil {
+		ctx = poi.oreq.Context()
+	}
+	_, span := tracing.StartObj(ctx, "disk.write", poi.lom)
 	buf, slab, lmfh, erw := poi.write()
 	poi._cleanup(buf, slab, lmfh, erw)
+	tracing.End(span, erw)
 	if erw != nil {
 		err, ecode = erw, http.StatusInternalServerError
 		goto rerr
@@ -244,6 +261,7 @@ func (poi *putOI) stats() {
 		cos.NamedVal64{Name: stats.PutLatency, Value: delta},
 		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
 	)
+	poi.t.statsBck(stats.BckPut, poi.lom, poi.oreq, size, delta)
 	if poi.rltime > 0 {
 		debug.Assert(bck.IsRemote())
 		backend := poi.t.Backend(bck)
@@ -256,6 +274,19 @@ func (poi *putOI) stats() {
 	}
 }
 
+// per-bucket (and per-user) metrics
+func (t *target) statsBck(op stats.BckOp, lom *core.LOM, r *http.Request, size, latency int64) {
+	conf := cmn.Rom.BckMetrics()
+	if !conf.Enabled {
+		return
+	}
+	var user string
+	if conf.PerUser && r != nil {
+		user = t.auditUser(r)
+	}
+	t.statsT.AddBck(op, lom.Bucket(), user, size, latency)
+}
+
 // verbose only
 func (poi *putOI) loghdr() string {
 	var sb strings.Builder
@@ -314,6 +345,19 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
+	// bucket quota (see tgtquota.go)
+	var (
+		rsv   *quotaRsv
+		quota = lom.Bprops().Quota.IsSet()
+	)
+	if quota {
+		rsv, err = poi.t.reserveQuota(lom, poi.osizeQuota(), lom.Lsize(), poi.owt < cmn.OwtRebalance /*enforce*/)
+		if err != nil {
+			return http.StatusInsufficientStorage, err
+		}
+		defer rsv.rollback() // (unless committed)
+	}
+
 	// put remote
 	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
 		ecode, err = poi.putRemote()
@@ -353,9 +397,30 @@ func (poi *putOI) fini() (ecode int, err error) {
 		defer lom.Unlock(true)
 		lom.SetAtimeUnix(poi.atime)
 	}
+	osize := int64(-1)
+	if quota {
+		osize = osizeQuota(lom)
+	}
+
+	// object lock (WORM)
+	if poi.owt < cmn.OwtRebalance && lom.Bprops().ObjLock.Enabled {
+		if err = lom.CheckObjLock(); err != nil {
+			return http.StatusForbidden, err
+		}
+		lom.SetRetention(time.Now())
+	}
 
 	// ais versioning
+	var pfqn string
 	if bck.IsAIS() && lom.VersionConf().Enabled {
+		if poi.retainPrior() {
+			var errP error
+			if pfqn, errP = lom.RetainPrior(lom.VersionConf().KeepPrior); errP != nil {
+				nlog.Errorln(poi.loghdr(), "failed to retain prior version:", errP) // proceeding anyway
+			} else if pfqn != "" && osize > 0 {
+				osize = 0 // (retained - see osizeQuota)
+			}
+		}
 		if poi.owt < cmn.OwtRebalance {
 			if poi.skipVC {
 				err = lom.IncVersion()
@@ -370,6 +435,9 @@ func (poi *putOI) fini() (ecode int, err error) {
 
 	// done
 	if err = lom.RenameFinalize(poi.workFQN); err != nil {
+		if pfqn != "" {
+			lom.RevertPrior(pfqn)
+		}
 		return 0, err
 	}
 	if lom.HasCopies() {
@@ -380,9 +448,18 @@ func (poi *putOI) fini() (ecode int, err error) {
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime)
 	}
+	if quota {
+		rsv.commit(deltaQuota(osize, lom.Lsize()))
+	}
 	return 0, lom.PersistMain()
 }
 
+// whether overwriting retains the current version (see core/lom_prior.go)
+func (poi *putOI) retainPrior() bool {
+	vconf := poi.lom.VersionConf()
+	return poi.owt == cmn.OwtPut && poi.lom.Bck().IsAIS() && vconf.Enabled && vconf.KeepPrior > 0
+}
+
 // via backend.PutObj()
 func (poi *putOI) putRemote() (int, error) {
 	var (
@@ -560,7 +637,9 @@ func (goi *getOI) get() (ecode int, err error) {
 		cold        bool
 	)
 do:
+	_, span := tracing.StartObj(goi.ctx, "lom.load", goi.lom)
 	err = goi.lom.Load(true /*cache it*/, true /*locked*/)
+	tracing.End(span, err)
 	if err != nil {
 		cold = cos.IsNotExist(err, 0)
 		if !cold {
@@ -700,7 +779,9 @@ do:
 
 	// read locally and stream back
 fin:
+	_, span = tracing.StartObj(goi.ctx, "disk.read", goi.lom)
 	ecode, err = goi.txfini()
+	tracing.End(span, err)
 	if err == nil {
 		return 0, nil
 	}
@@ -896,7 +977,12 @@ gfn:
 	}
 
 	// restore from existing EC slices, if possible
-	ecErr := ec.ECM.RestoreObject(goi.lom)
+	ecErr := ec.ErrorECDisabled
+	if ecEnabled {
+		ctx, span := tracing.StartObj(goi.ctx, "ec.restore", goi.lom)
+		ecErr = ec.ECM.RestoreObject(ctx, goi.lom)
+		tracing.End(span, ecErr)
+	}
 	if ecErr == nil {
 		ecErr = goi.lom.Load(true /*cache it*/, false /*locked*/) // TODO: optimize locking
 		if ecErr == nil {
@@ -938,6 +1024,7 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
 		reqArgs.Query = query
 	}
+	tracing.Inject(goi.ctx, reqArgs.Header)
 	config := cmn.GCO.Get()
 	req, _, cancel, err := reqArgs.ReqWithTimeout(config.Timeout.SendFile.D())
 	if err != nil {
@@ -946,12 +1033,15 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 	}
 	defer cancel()
 
+	span := tracing.StartClient(req)
 	resp, err := g.client.data.Do(req) //nolint:bodyclose // closed by `poi.putObject`
 	cmn.FreeHra(reqArgs)
 	if err != nil {
+		tracing.EndHTTP(span, 0, err)
 		nlog.Errorf("%s: gfn failure, %s %q, err: %v", goi.t, tsi, lom, err)
 		return false
 	}
+	tracing.EndHTTP(span, resp.StatusCode, nil)
 
 	cksumToUse := lom.ObjAttrs().FromHeader(resp.Header)
 	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileRemote)
@@ -1081,6 +1171,9 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 	if dpq.isS3 {
 		// (expecting user to set bucket checksum = md5)
 		s3.SetEtag(whdr, lom)
+		s3.SetTaggingCount(whdr, lom.GetCustomMD())
+		s3.SetVersionID(whdr, lom)
+		s3.SetObjLockHdrs(whdr, &lom.Bprops().ObjLock, lom.GetCustomMD())
 	}
 
 	buf, slab := goi.t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
@@ -1100,6 +1193,25 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	if err != nil {
 		return err
 	}
+
+	// single file from .tar: seek directly via (persistent) tar index, if available
+	if mime == archive.ExtTar && dpq.arch.path != "" && fqn == lom.FQN {
+		idx, err := lom.TarIndex(lmfh)
+		if err == nil {
+			csl := idx.ReadOne(lmfh, dpq.arch.path)
+			if csl == nil {
+				return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+			}
+			return goi._txone(csl, fqn, whdr)
+		}
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Infoln("tar index of", lom.Cname(), "is not available:", err)
+		}
+		if _, err := lmfh.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
 	ar, err = archive.NewReader(mime, lmfh, lom.Lsize())
 	if err != nil {
 		return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
@@ -1117,13 +1229,7 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 		if csl == nil {
 			return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
 		}
-		// found
-		whdr.Set(cos.HdrContentType, cos.ContentBinary)
-		buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
-		err = goi.transmit(csl, buf, fqn)
-		slab.Free(buf)
-		csl.Close()
-		return err
+		return goi._txone(csl, fqn, whdr)
 	}
 
 	// multi match; writing & streaming tar =>(directly)=> response writer
@@ -1143,6 +1249,16 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	return err
 }
 
+// transmit (found) single archived file
+func (goi *getOI) _txone(csl cos.ReadCloseSizer, fqn string, whdr http.Header) error {
+	whdr.Set(cos.HdrContentType, cos.ContentBinary)
+	buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
+	err := goi.transmit(csl, buf, fqn)
+	slab.Free(buf)
+	csl.Close()
+	return err
+}
+
 func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 	written, err := cos.CopyBuffer(goi.w, r, buf)
 	if err != nil {
@@ -1162,14 +1278,22 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 		uname := goi.lom.UnamePtr()
 		bname := cos.UnsafeBptr(uname)
 		goi.t.reb.FilterAdd(*bname)
-	} else if !goi.cold { // GFN & cold-GET: must be already loaded w/ atime set
-		if err := goi.lom.Load(false /*cache it*/, true /*locked*/); err != nil {
-			fs.CleanPathErr(err)
-			nlog.Errorln(goi.t.String(), "GET post-transmission failure:", err)
-			return errSendingResp
+	} else {
+		lfu := cos.Left(goi.lom.Bprops().LRU.Policy, cmn.GCO.Get().LRU.Policy) == cmn.LRUPolicyLFU
+		if !goi.cold { // GFN & cold-GET: must be already loaded w/ atime set
+			if err := goi.lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+				fs.CleanPathErr(err)
+				nlog.Errorln(goi.t.String(), "GET post-transmission failure:", err)
+				return errSendingResp
+			}
+			goi.lom.SetAtimeUnix(goi.atime)
+		}
+		if lfu {
+			goi.lom.IncAccessCount() // (approximate under concurrent GETs; cold GET counts as the first access)
+		}
+		if !goi.cold || lfu {
+			goi.lom.Recache()
 		}
-		goi.lom.SetAtimeUnix(goi.atime)
-		goi.lom.Recache()
 	}
 	//
 	// stats
@@ -1187,6 +1311,8 @@ func (goi *getOI) stats(written int64) {
 		cos.NamedVal64{Name: stats.GetLatency, Value: delta},      // see also: per-backend *LatencyTotal below
 		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta}, // ditto
 	)
+	goi.t.statsBck(stats.BckGet, goi.lom, goi.req, written, delta)
+	goi.t.rlim.take(goi.lom.Bck(), goi.tk, written) // the size is known only now (see rlimiters.allowGet)
 	if goi.verchanged {
 		goi.t.statsT.AddMany(
 			cos.NamedVal64{Name: stats.VerChangeCount, Value: 1},
@@ -1195,6 +1321,7 @@ func (goi *getOI) stats(written int64) {
 	}
 
 	if goi.rltime > 0 {
+		goi.t.statsBck(stats.BckGetCold, goi.lom, goi.req, written, delta)
 		bck := goi.lom.Bck()
 		backend := goi.t.Backend(bck)
 		goi.t.statsT.AddMany(
@@ -1518,6 +1645,9 @@ func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error)
 	if !lcopy {
 		dst.Lock(true)
 		defer dst.Unlock(true)
+		if err := dst.CheckObjLock(); err != nil {
+			return 0, err
+		}
 		if err := dst.Load(false /*cache it*/, true /*locked*/); err == nil {
 			if lom.EqCksum(dst.Checksum()) {
 				return 0, nil
@@ -1526,9 +1656,23 @@ func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error)
 			return 0, err
 		}
 	}
+	var (
+		rsv   *quotaRsv
+		osize = int64(-1)
+	)
+	if !lcopy && dst.Bprops().Quota.IsSet() {
+		var err error
+		osize = osizeQuota(dst)
+		if rsv, err = t.reserveQuota(dst, osize, lom.Lsize(), true /*enforce*/); err != nil {
+			return 0, err
+		}
+	}
 	dst2, err := lom.Copy2FQN(dst.FQN, coi.Buf)
-	if err == nil {
+	if err != nil {
+		rsv.rollback()
+	} else {
 		size = lom.Lsize()
+		rsv.commit(deltaQuota(osize, size))
 		if coi.Finalize {
 			t.putMirror(dst2)
 		}
@@ -1716,6 +1860,7 @@ func (a *putA2I) do() (int, error) {
 			}
 			return http.StatusInternalServerError, err
 		}
+		a.lom.RemoveTarIdx() // (about to be invalidated)
 		// do - fast
 		if size, err = a.fast(fh, tarFormat, offset); err == nil {
 			// TODO: checksum NIY
@@ -1828,6 +1973,7 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 	if err := a.lom.Persist(); err != nil {
 		return err
 	}
+	a.rsv.commit(deltaQuota(a.osize, size))
 	if a.lom.ECEnabled() {
 		if err := ec.ECM.EncodeObject(a.lom, nil); err != nil && err != ec.ErrorECDisabled {
 			return err
@@ -60,6 +60,7 @@ func TestMain(m *testing.M) {
 	fs.TestNew(nil)
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.PriorVerType, &fs.PriorVerContentResolver{}, true)
 
 	// target
 	config := cmn.GCO.Get()
@@ -0,0 +1,170 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Object lock (WORM): per-object retention and legal hold (see cmn/objlock.go)
+
+// object retention and legal hold can be only modified via (native) apc.ActObjLock
+// or (S3) ?retention and ?legal-hold - not via generic custom metadata
+func objLockCustom(lom *core.LOM, custom cos.StrKVs, delOldSetNew bool) error {
+	for _, key := range []string{cmn.RetainUntilObjMD, cmn.LegalHoldObjMD} {
+		if _, ok := custom[key]; ok {
+			return fmt.Errorf("%s: custom metadata key %q is reserved (use %q)", lom.Cname(), key, apc.ActObjLock)
+		}
+		if !delOldSetNew {
+			continue
+		}
+		if v, ok := lom.GetCustomKey(key); ok {
+			custom[key] = v
+		}
+	}
+	return nil
+}
+
+// update retention and/or legal hold of an existing object
+func (t *target) setObjLock(lom *core.LOM, msg *apc.ObjLockMsg) (int, error) {
+	conf := &lom.Bprops().ObjLock
+	if !conf.Enabled {
+		return http.StatusBadRequest, fmt.Errorf("%s: object lock is not enabled", lom.Bck().Cname(""))
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			return http.StatusNotFound, cos.NewErrNotFound(t, lom.Cname())
+		}
+		return 0, err
+	}
+	if msg.RetainUntil != nil {
+		until := *msg.RetainUntil
+		if err := conf.CheckRetention(lom.Cname(), lom.GetCustomMD(), until, time.Now()); err != nil {
+			if cmn.IsErrObjLocked(err) {
+				return http.StatusForbidden, err
+			}
+			return http.StatusBadRequest, err
+		}
+		if until.IsZero() {
+			lom.ObjAttrs().DelCustomKeys(cmn.RetainUntilObjMD)
+		} else {
+			lom.SetCustomKey(cmn.RetainUntilObjMD, cmn.FmtRetainUntil(until))
+		}
+	}
+	if msg.LegalHold != nil {
+		if *msg.LegalHold {
+			lom.SetCustomKey(cmn.LegalHoldObjMD, cmn.LegalHoldOn)
+		} else {
+			lom.ObjAttrs().DelCustomKeys(cmn.LegalHoldObjMD)
+		}
+	}
+	return 0, lom.Persist()
+}
+
+// [METHOD] /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) objLockS3(w http.ResponseWriter, r *http.Request, items []string, retention bool) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	objName := s3.ObjName(items)
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		t.getObjLockS3(w, r, lom, bck, retention)
+	case http.MethodPut:
+		msg := &apc.ObjLockMsg{}
+		if retention {
+			in := &s3.Retention{}
+			if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			if in.Mode != "" && in.Mode != bck.Props.ObjLock.Mode {
+				err := fmt.Errorf("retention mode %q does not match the bucket's mode %q", in.Mode, bck.Props.ObjLock.Mode)
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			until, err := in.RetainUntil()
+			if err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			msg.RetainUntil = &until
+		} else {
+			in := &s3.LegalHold{}
+			if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			on, err := in.On()
+			if err != nil {
+				s3.WriteErr(w, r, err, 0)
+				return
+			}
+			msg.LegalHold = &on
+		}
+		if ecode, err := t.setObjLock(lom, msg); err != nil {
+			s3.WriteErr(w, r, err, ecode)
+		}
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPut)
+	}
+}
+
+// GET /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) getObjLockS3(w http.ResponseWriter, r *http.Request, lom *core.LOM, bck *meta.Bck, retention bool) {
+	conf := &bck.Props.ObjLock
+	if !conf.Enabled {
+		s3.WriteErr(w, r, s3.ErrNoSuchObjLockConf, http.StatusNotFound)
+		return
+	}
+	lom.Lock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	custom := lom.GetCustomMD()
+	lom.Unlock(false)
+
+	sgl := t.gmm.NewSGL(0)
+	if retention {
+		resp, ok := s3.NewRetention(conf, custom)
+		if !ok {
+			sgl.Free()
+			s3.WriteErr(w, r, s3.ErrNoSuchObjLockConf, http.StatusNotFound)
+			return
+		}
+		resp.MustMarshal(sgl)
+	} else {
+		s3.NewLegalHold(custom).MustMarshal(sgl)
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
@@ -0,0 +1,218 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Bucket quotas (see cmn/quota.go): each target enforces cluster-wide limits using its own
+// usage (see core/quota.go) plus the usage of all other targets as of the last time it was
+// collected - periodically, in the background, and only when there are buckets with quotas.
+
+const (
+	quotaIval     = 10 * time.Second
+	quotaWarnIval = time.Minute // (soft limits)
+)
+
+type (
+	// usage of all other targets
+	peerUsage struct {
+		m      map[uint64][2]int64 // bucket ID => [size, number of objects]
+		warned map[uint64]int64    // bucket ID => mono time (soft limit warning)
+		mu     sync.RWMutex
+	}
+	// (expected) usage delta that's been checked against the quota and added to the bucket's
+	// usage - before writing; subsequently, committed with the actual delta or rolled back
+	quotaRsv struct {
+		u            *core.BckUsage
+		dsize, dobjs int64
+		done         bool
+	}
+)
+
+var quotaPeers = &peerUsage{}
+
+func (pu *peerUsage) get(bid uint64) (u [2]int64) {
+	pu.mu.RLock()
+	u = pu.m[bid]
+	pu.mu.RUnlock()
+	return
+}
+
+func (pu *peerUsage) set(m map[uint64][2]int64) {
+	pu.mu.Lock()
+	pu.m = m
+	pu.mu.Unlock()
+}
+
+// throttled warning
+func (pu *peerUsage) warn(bid uint64) bool {
+	now := mono.NanoTime()
+	pu.mu.Lock()
+	defer pu.mu.Unlock()
+	if pu.warned == nil {
+		pu.warned = make(map[uint64]int64, 4)
+	}
+	if last, ok := pu.warned[bid]; ok && time.Duration(now-last) < quotaWarnIval {
+		return false
+	}
+	pu.warned[bid] = now
+	return true
+}
+
+func (t *target) quotaHK(int64) time.Duration {
+	if !t.ClusterStarted() {
+		return quotaIval
+	}
+	bmd := t.owner.bmd.get()
+	if !core.PruneUsages(&bmd.BMD) {
+		quotaPeers.set(nil)
+		return quotaIval
+	}
+	smap := t.owner.smap.get()
+	if smap.CountActiveTs() < 2 {
+		quotaPeers.set(nil)
+		return quotaIval
+	}
+	var (
+		sum  = make(map[uint64][2]int64, 4)
+		args = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDae.S,
+		Query:  url.Values{apc.QparamWhat: []string{apc.WhatBckUsage}},
+	}
+	args.to = core.Targets
+	args.smap = smap
+	args.cresv = cresBU{}
+	results := t.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nlog.Warningln(t.String(), "failed to get bucket usage from", res.si.StringEx(), "err:", res.err)
+			continue // (keep going with partial usage)
+		}
+		for bid, u := range *res.v.(*map[uint64][2]int64) {
+			s := sum[bid]
+			s[0] += u[0]
+			s[1] += u[1]
+			sum[bid] = s
+		}
+	}
+	freeBcastRes(results)
+	quotaPeers.set(sum)
+	return quotaIval
+}
+
+// reserveQuota returns cmn.ErrQuotaExceeded if writing `size` bytes (that'd overwrite the
+// existing object of `osize` bytes, or create a new one when osize < 0) would exceed any
+// of the bucket's (cluster-wide) hard limits; otherwise, reserves the corresponding delta
+// (and the caller must either commit or roll back)
+//   - returns nil reservation when the bucket has no quota;
+//   - when not enforcing (e.g., rebalance) or the usage is not computed yet, reserves nothing -
+//     commit simply adds the actual delta
+func (t *target) reserveQuota(lom *core.LOM, osize, size int64, enforce bool) (*quotaRsv, error) {
+	u := lom.Usage()
+	if u == nil {
+		return nil, nil
+	}
+	rsv := &quotaRsv{u: u}
+	if !enforce || !u.Ready() {
+		return rsv, nil
+	}
+	rsv.dsize, rsv.dobjs = deltaQuota(osize, size)
+	var (
+		conf         = &lom.Bprops().Quota
+		bid          = lom.Bprops().BID
+		usize, uobjs = u.Add(rsv.dsize, rsv.dobjs) // (includes concurrent reservations)
+		pu           = quotaPeers.get(bid)
+		total        = usize + pu[0]
+		objs         = uobjs + pu[1]
+	)
+	if err := conf.Check(lom.Bck().Cname(""), total, objs); err != nil {
+		rsv.rollback()
+		return nil, err
+	}
+	if conf.SoftExceeded(total, objs) && quotaPeers.warn(bid) {
+		nlog.Warningln(t.String()+":", lom.Bck().Cname(""), "soft quota exceeded: total size", total, "objects", objs)
+	}
+	return rsv, nil
+}
+
+//////////////
+// quotaRsv //
+//////////////
+
+// replace the reservation with the actual (dsize, dobjs)
+func (rsv *quotaRsv) commit(dsize, dobjs int64) {
+	if rsv == nil || rsv.done {
+		return
+	}
+	rsv.u.Add(dsize-rsv.dsize, dobjs-rsv.dobjs)
+	rsv.done = true
+}
+
+// no-op if already committed
+func (rsv *quotaRsv) rollback() {
+	if rsv == nil || rsv.done {
+		return
+	}
+	rsv.u.Add(-rsv.dsize, -rsv.dobjs)
+	rsv.done = true
+}
+
+// checkQuota is the early (non-reserving) check of the PUT size given by Content-Length -
+// to fail before receiving the payload; reserveQuota remains authoritative
+func (t *target) checkQuota(lom *core.LOM, osize, size int64) error {
+	u := lom.Usage()
+	if u == nil || !u.Ready() {
+		return nil
+	}
+	var (
+		conf         = &lom.Bprops().Quota
+		dsize, dobjs = deltaQuota(osize, size)
+		usize, uobjs = u.Get()
+		pu           = quotaPeers.get(lom.Bprops().BID)
+	)
+	return conf.Check(lom.Bck().Cname(""), usize+dsize+pu[0], uobjs+dobjs+pu[1])
+}
+
+// same as osizeQuota (below) except that overwriting with prior version retention
+// frees nothing (see core/lom_prior.go)
+func (poi *putOI) osizeQuota() int64 {
+	osize := osizeQuota(poi.lom)
+	if osize > 0 && poi.retainPrior() {
+		osize = 0
+	}
+	return osize
+}
+
+// size of the existing object that is about to be overwritten; -1 if doesn't exist
+func osizeQuota(lom *core.LOM) int64 {
+	if finfo, err := os.Stat(lom.FQN); err == nil {
+		return finfo.Size()
+	}
+	return -1
+}
+
+// (dsize, dobjs) to overwrite or create a new object
+func deltaQuota(osize, size int64) (int64, int64) {
+	if osize < 0 {
+		return size, 1
+	}
+	return size - osize, 0
+}
@@ -0,0 +1,206 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+)
+
+func TestQuotaEnforce(tt *testing.T) {
+	bck := meta.NewBck("quota", apc.AIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	bmd.Version++ // (unique BID)
+	bmd.add(bck, &cmn.Bprops{
+		Cksum: cmn.CksumConf{Type: cos.ChecksumNone},
+		Quota: cmn.QuotaConf{Size: 4 * cos.KiB},
+	})
+	t.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+
+	u := core.GetUsage(bck)
+	for !u.Ready() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	newLOM := func(objName string) *core.LOM {
+		lom := core.AllocLOM(objName)
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			tt.Fatal(err)
+		}
+		return lom
+	}
+	put := func(objName string) error {
+		lom := newLOM(objName)
+		defer core.FreeLOM(lom)
+		r, _ := readers.NewRand(cos.KiB, cos.ChecksumNone)
+		poi := &putOI{
+			atime:   time.Now().UnixNano(),
+			t:       t,
+			lom:     lom,
+			r:       r,
+			workFQN: fs.CSM.Gen(lom, fs.WorkfileType, "quota"),
+			config:  cmn.GCO.Get(),
+			owt:     cmn.OwtPut,
+		}
+		_, err := poi.putObject()
+		return err
+	}
+	cp := func(src, dst string) error {
+		lom, dlom := newLOM(src), newLOM(dst)
+		defer core.FreeLOM(lom)
+		defer core.FreeLOM(dlom)
+		_, err := (&copyOI{})._regular(t, lom, dlom)
+		return err
+	}
+	arch := func(objName string, flags int64) error {
+		lom := newLOM(objName)
+		defer core.FreeLOM(lom)
+		content := bytes.Repeat([]byte{'a'}, cos.KiB)
+		r := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(content))
+		r.Header.Set(cos.HdrContentLength, strconv.Itoa(len(content)))
+		if flags != 0 {
+			r.Header.Set(apc.HdrPutApndArchFlags, strconv.FormatInt(flags, 10))
+		}
+		dpq := &dpq{}
+		dpq.arch.mime, dpq.arch.path = archive.ExtTar, "file-"+strconv.FormatInt(time.Now().UnixNano(), 10)
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		_, err := t.putApndArch(r, lom, time.Now().UnixNano(), dpq)
+		return err
+	}
+	check := func(err error, exceeded bool, tag string) {
+		tt.Helper()
+		if exceeded && !cmn.IsErrQuotaExceeded(err) {
+			tt.Fatalf("%s: expected quota exceeded, got %v", tag, err)
+		}
+		if !exceeded && err != nil {
+			tt.Fatalf("%s: %v", tag, err)
+		}
+	}
+
+	check(put("o1"), false, "put")
+	check(put("o2"), false, "put")
+	check(put("o1"), false, "overwrite")
+	check(cp("o1", "o3"), false, "copy")
+	if size, objs := u.Get(); size != 3*cos.KiB || objs != 3 {
+		tt.Fatalf("expected (%d, 3), got (%d, %d)", 3*cos.KiB, size, objs)
+	}
+	check(arch("shard.tar", 0), false, "archive") // (headers not counted: reserving 1KiB)
+
+	// over quota
+	size, objs := u.Get()
+	if size <= 4*cos.KiB || objs != 4 {
+		tt.Fatalf("expected over quota with 4 objects, got (%d, %d)", size, objs)
+	}
+	check(put("o4"), true, "put")
+	check(cp("o1", "o5"), true, "copy")
+	check(arch("shard.tar", apc.ArchAppend), true, "archive-append")
+
+	// rolled back
+	if s, o := u.Get(); s != size || o != objs {
+		tt.Fatalf("expected (%d, %d) upon rejected writes, got (%d, %d)", size, objs, s, o)
+	}
+}
+
+// fails PUT without reading the payload; counts retained prior versions
+func TestQuotaEarlyAndPriors(tt *testing.T) {
+	bck := meta.NewBck("quota-priors", apc.AIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	bmd.Version++ // (unique BID)
+	bmd.add(bck, &cmn.Bprops{
+		Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+		Versioning: cmn.VersionConf{Enabled: true, KeepPrior: 1},
+		Quota:      cmn.QuotaConf{Size: 4 * cos.KiB},
+	})
+	t.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+
+	u := core.GetUsage(bck)
+	for !u.Ready() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	put := func(objName string, size int64, body io.Reader) error {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			tt.Fatal(err)
+		}
+		r := httptest.NewRequest(http.MethodPut, "/", body)
+		r.Header.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+		poi := &putOI{
+			atime:  time.Now().UnixNano(),
+			t:      t,
+			lom:    lom,
+			config: cmn.GCO.Get(),
+		}
+		_, err := poi.do(nil, r, &dpq{})
+		return err
+	}
+	putRand := func(objName string) error {
+		r, _ := readers.NewRand(cos.KiB, cos.ChecksumNone)
+		return put(objName, cos.KiB, r)
+	}
+	expect := func(size, objs int64) {
+		tt.Helper()
+		if s, o := u.Get(); s != size || o != objs {
+			tt.Fatalf("expected (%d, %d), got (%d, %d)", size, objs, s, o)
+		}
+	}
+
+	// overwrite retains the prior version (at most one)
+	if err := putRand("o1"); err != nil {
+		tt.Fatal(err)
+	}
+	if err := putRand("o1"); err != nil {
+		tt.Fatal(err)
+	}
+	expect(2*cos.KiB, 1)
+	if err := putRand("o1"); err != nil {
+		tt.Fatal(err)
+	}
+	expect(2*cos.KiB, 1) // (oldest prior pruned)
+
+	// rejected based on Content-Length alone
+	err := put("o2", 3*cos.KiB, iotest.ErrReader(errors.New("must not be read")))
+	if !cmn.IsErrQuotaExceeded(err) {
+		tt.Fatalf("expected quota exceeded, got %v", err)
+	}
+	expect(2*cos.KiB, 1)
+
+	// delete the prior version
+	lom := core.AllocLOM("o1")
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		tt.Fatal(err)
+	}
+	lom.Lock(true)
+	priors, err := lom.Priors()
+	if err == nil && len(priors) == 1 {
+		err = lom.RemovePrior(priors[0].FQN)
+	}
+	lom.Unlock(true)
+	if err != nil || len(priors) != 1 {
+		tt.Fatalf("expected to remove one prior version, got %d (err: %v)", len(priors), err)
+	}
+	expect(cos.KiB, 1)
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 const fmtErrBckObj = "invalid %s request: expecting bucket and object (names) in the URL, have %v"
@@ -35,11 +37,19 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if l := len(apiItems); (l == 0 && r.Method == http.MethodGet) || l < 2 {
+	if t.s3cors(w, r, apiItems) {
+		return // preflight
+	}
+	// (GET /s3/<bucket-name> is proxy-broadcast: list multipart uploads, list object versions)
+	if l := len(apiItems); l == 0 || (l < 2 && r.Method != http.MethodGet) {
 		err := fmt.Errorf(fmtErrBckObj, r.Method, apiItems)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := t.s3ratelim(r, apiItems[0]); err != nil {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -51,9 +61,14 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		t.putCopyMpt(w, r, config, apiItems)
 	case http.MethodDelete:
 		q := r.URL.Query()
-		if q.Has(s3.QparamMptUploadID) {
+		switch {
+		case q.Has(s3.QparamMptUploadID):
 			t.abortMpt(w, r, apiItems, q)
-		} else {
+		case q.Has(s3.QparamTagging):
+			t.taggingS3(w, r, apiItems)
+		case q.Has(s3.QparamVersionID):
+			t.delObjVerS3(w, r, apiItems, q.Get(s3.QparamVersionID))
+		default:
 			t.delObjS3(w, r, apiItems)
 		}
 	case http.MethodPost:
@@ -78,6 +93,10 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 	}
 	q := r.URL.Query()
 	switch {
+	case q.Has(s3.QparamTagging):
+		t.taggingS3(w, r, items)
+	case q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold):
+		t.objLockS3(w, r, items, q.Has(s3.QparamRetention))
 	case q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID):
 		if r.Header.Get(cos.S3HdrObjSrc) != "" {
 			// TODO: copy another object (or its range) => part of the specified multipart upload.
@@ -88,8 +107,16 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
 			nlog.Infoln("putMptPart", bck.String(), items, q)
 		}
+		if err := t.s3payload(r, bck, false /*part checksum validated by putMptPart*/); err != nil {
+			s3.WriteErr(w, r, err, http.StatusBadRequest)
+			return
+		}
 		t.putMptPart(w, r, items, q, bck)
 	case r.Header.Get(cos.S3HdrObjSrc) == "":
+		if err := t.s3payload(r, bck, true /*verify sha256*/); err != nil {
+			s3.WriteErr(w, r, err, http.StatusBadRequest)
+			return
+		}
 		objName := s3.ObjName(items)
 		lom := core.AllocLOM(objName)
 		t.putObjS3(w, r, bck, config, lom)
@@ -99,6 +126,29 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 	}
 }
 
+// AWS Signature V4 payload:
+//   - decode "aws-chunked" streaming payload;
+//   - validate chunk signatures and signed payload sha256 when the request is signed
+//     with one of the AuthN-managed S3 access keys (see also: p.s3access)
+func (t *target) s3payload(r *http.Request, bck *meta.Bck, verifySHA bool) error {
+	var key *s3key
+	sig, err := s3.ParseSigV4(r)
+	if err == nil && sig != nil {
+		key = t.owner.s3keys.lookup(sig.AccessKey)
+	}
+	if key == nil {
+		// not ours - leave as is for the remote S3 to authenticate (feat.S3PresignedRequest)
+		if sig != nil && bck.IsRemoteS3() && bck.Props.Features.IsSet(feat.S3PresignedRequest) {
+			return nil
+		}
+		return s3.DecodeChunked(r, nil, "")
+	}
+	if verifySHA {
+		s3.VerifyPayload(r, sig)
+	}
+	return s3.DecodeChunked(r, sig, key.secret)
+}
+
 // Copy object (maybe from another bucket)
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html
 func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.Config, items []string) {
@@ -193,6 +243,23 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 	started := time.Now()
 	lom.SetAtimeUnix(started.UnixNano())
 
+	tags, err := s3.ParseTaggingHdr(r.Header)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if len(tags) > 0 {
+		lom.SetCustomKey(cmn.TaggingObjMD, cmn.EncodeObjTags(tags))
+	}
+	lock := make(cos.StrKVs, 2)
+	if err := s3.ParseObjLockHdrs(r.Header, &bck.Props.ObjLock, lock); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	for k, v := range lock {
+		lom.SetCustomKey(k, v)
+	}
+
 	// TODO: dual checksumming, e.g. lom.SetCustom(apc.AWS, ...)
 
 	dpq := dpqAlloc()
@@ -217,6 +284,7 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 		s3.WriteErr(w, r, err, ecode)
 	} else {
 		s3.SetEtag(w.Header(), lom)
+		s3.SetVersionID(w.Header(), lom)
 	}
 	dpqFree(dpq)
 }
@@ -237,12 +305,24 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		t.listMptUploads(w, bck, q)
 		return
 	}
+	if len(items) == 1 && q.Has(s3.QparamVersions) {
+		t.listPriorVersS3(w, bck, q)
+		return
+	}
 	if len(items) < 2 {
 		err := fmt.Errorf(fmtErrBckObj, r.Method, items)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
 	objName := s3.ObjName(items)
+	if q.Has(s3.QparamTagging) {
+		t.taggingS3(w, r, items)
+		return
+	}
+	if q.Has(s3.QparamRetention) || q.Has(s3.QparamLegalHold) {
+		t.objLockS3(w, r, items, q.Has(s3.QparamRetention))
+		return
+	}
 	if q.Has(s3.QparamMptPartNo) {
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
 			nlog.Infoln("getMptPart", bck.String(), objName, q)
@@ -260,6 +340,11 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		t.listMptParts(w, r, bck, objName, q)
 		return
 	}
+	if ver := q.Get(s3.QparamVersionID); ver != "" {
+		if t.getPriorVerS3(w, r, bck, objName, ver) {
+			return
+		}
+	}
 
 	dpq := dpqAlloc()
 	if err := dpq.parse(r.URL.RawQuery); err != nil {
@@ -287,6 +372,11 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	if ver := r.URL.Query().Get(s3.QparamVersionID); ver != "" {
+		if t.getPriorVerS3(w, r, bck, objName, ver) {
+			return
+		}
+	}
 	lom := core.AllocLOM(objName)
 	defer core.FreeLOM(lom)
 	if err := lom.InitBck(bck.Bucket()); err != nil {
@@ -329,6 +419,9 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		hdr.Set(cos.HdrETag, v)
 	}
 	s3.SetEtag(hdr, lom)
+	s3.SetTaggingCount(hdr, custom)
+	s3.SetVersionID(hdr, lom)
+	s3.SetObjLockHdrs(hdr, &bck.Props.ObjLock, custom)
 	hdr.Set(cos.HdrContentLength, strconv.FormatInt(op.Size, 10))
 	if v, ok := custom[cos.HdrContentType]; ok {
 		hdr.Set(cos.HdrContentType, v)
@@ -356,6 +449,7 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	started := mono.NanoTime()
 	ecode, err = t.DeleteObject(lom, false)
 	if err != nil {
 		name := lom.Cname()
@@ -366,6 +460,7 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		}
 		return
 	}
+	t.statsBck(stats.BckDelete, lom, r, 0, mono.SinceNano(started))
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
@@ -73,7 +73,10 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		uploadID = cos.GenUUID()
 	}
 
-	s3.InitUpload(uploadID, bck.Name, objName)
+	if err := s3.InitUpload(uploadID, lom); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
@@ -139,7 +142,7 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		size         int64
 		ecode        int
 		partSHA      = r.Header.Get(cos.S3HdrContentSHA256)
-		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload
+		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload && partSHA != cos.S3StreamingPayload
 		cksumSHA     = &cos.CksumHash{}
 		cksumMD5     = &cos.CksumHash{}
 		remote       = bck.IsRemoteS3()
@@ -191,13 +194,26 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		if !cksumSHA.Equal(recvSHA) {
 			detail := fmt.Sprintf("upload %q, %s, part %d", uploadID, lom, partNum)
 			err = cos.NewErrDataCksum(&cksumSHA.Cksum, recvSHA, detail)
+			if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+				nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
+			}
 			s3.WriteMptErr(w, r, err, http.StatusInternalServerError, lom, uploadID)
 			return
 		}
 	}
+
+	// 5. workfile => persistent part (see s3.PartFQN)
+	pfqn := s3.PartFQN(lom, uploadID, partNum)
+	if err := cos.Rename(wfqn, pfqn); err != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
+		}
+		s3.WriteMptErr(w, r, err, 0, lom, uploadID)
+		return
+	}
 	npart := &s3.MptPart{
 		MD5:  md5,
-		FQN:  wfqn,
+		FQN:  pfqn,
 		Size: size,
 		Num:  partNum,
 	}
@@ -383,6 +399,7 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 
 	// stats
 	t.statsT.Inc(stats.PutCount)
+	t.statsBck(stats.BckPut, lom, r, size, time.Since(started).Nanoseconds())
 	if remote {
 		t.statsT.Inc(t.Backend(bck).MetricName(stats.PutCount))
 	}
@@ -531,9 +548,11 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	}
 	cos.Close(fh)
 	slab.Free(buf)
+	delta := mono.SinceNano(startTime)
 	t.statsT.AddMany(
 		cos.NamedVal64{Name: stats.GetCount, Value: 1},
 		cos.NamedVal64{Name: stats.GetSize, Value: size},
-		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: mono.SinceNano(startTime)},
+		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta},
 	)
+	t.statsBck(stats.BckGet, lom, r, size, delta)
 }
@@ -0,0 +1,133 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// S3 object tagging: tags are stored in the object's custom metadata (cmn.TaggingObjMD)
+// and apply only to objects present in the cluster (i.e., are not propagated to remote backends)
+
+// [METHOD] /s3/<bucket-name>/<object-name>?tagging
+func (t *target) taggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	objName := s3.ObjName(items)
+	switch r.Method {
+	case http.MethodGet:
+		t.getObjTaggingS3(w, r, bck, objName)
+	case http.MethodPut:
+		t.putObjTaggingS3(w, r, bck, objName)
+	case http.MethodDelete:
+		t.delObjTaggingS3(w, r, bck, objName)
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPut)
+	}
+}
+
+// GET /s3/<bucket-name>/<object-name>?tagging
+func (t *target) getObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if ecode, err := t._loadTagged(lom, bck, false /*exclusive*/); err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	var tags cos.StrKVs
+	if s, ok := lom.GetCustomKey(cmn.TaggingObjMD); ok {
+		var err error
+		if tags, err = cmn.ParseObjTags(s); err != nil {
+			s3.WriteErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	lom.Unlock(false)
+
+	resp := s3.NewTagging(tags)
+	sgl := t.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?tagging
+func (t *target) putObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string) {
+	in := &s3.Tagging{}
+	if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	tags, err := in.Tags()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	ecode, err := t._loadTagged(lom, bck, true /*exclusive*/)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if len(tags) == 0 {
+		lom.ObjAttrs().DelCustomKeys(cmn.TaggingObjMD)
+	} else {
+		lom.SetCustomKey(cmn.TaggingObjMD, cmn.EncodeObjTags(tags))
+	}
+	err = lom.Persist()
+	lom.Unlock(true)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+// DELETE /s3/<bucket-name>/<object-name>?tagging
+func (t *target) delObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	ecode, err := t._loadTagged(lom, bck, true /*exclusive*/)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if _, ok := lom.GetCustomKey(cmn.TaggingObjMD); ok {
+		lom.ObjAttrs().DelCustomKeys(cmn.TaggingObjMD)
+		err = lom.Persist()
+	}
+	lom.Unlock(true)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// returns locked (and loaded) LOM upon success
+func (t *target) _loadTagged(lom *core.LOM, bck *meta.Bck, exclusive bool) (int, error) {
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return 0, err
+	}
+	lom.Lock(exclusive)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(exclusive)
+		if cos.IsNotExist(err, 0) {
+			return http.StatusNotFound, cos.NewErrNotFound(t, lom.Cname())
+		}
+		return 0, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,265 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// S3 object versions: prior versions retained by ais buckets
+// with versioning.keep_prior > 0 (see core/lom_prior.go)
+
+var errInvalidVersionID = errors.New("invalid version ID")
+
+type (
+	// walks prior versions of a given bucket on a given mountpath in the listing order
+	// (see s3.Before), skipping directories that precede the key marker or do not match
+	// the prefix, and stopping upon collecting `limit` versions
+	priorWalk struct {
+		res   *s3.ListVersionsResult
+		vers  []*s3.ObjVersion
+		limit int
+	}
+	priorEnt struct {
+		de   os.DirEntry
+		key  string // object name or (for directories) relative path + "/"
+		ver  string
+		name string
+	}
+)
+
+var errPriorWalkDone = errors.New("done")
+
+// GET /s3/<bucket-name>?versions
+// (the proxy lists current versions and aggregates prior ones from all targets,
+// max-keys + 1 at most from each)
+func (t *target) listPriorVersS3(w http.ResponseWriter, bck *meta.Bck, q url.Values) {
+	var (
+		result = s3.NewListVersionsResult(bck.Name, q)
+		limit  = result.MaxKeys + 1
+		avail  = fs.GetAvail()
+	)
+	for _, mi := range avail {
+		var (
+			ctdir = mi.MakePathCT(bck.Bucket(), fs.PriorVerType)
+			pw    = &priorWalk{res: result, limit: limit}
+		)
+		if err := pw.do(ctdir, ""); err != nil && err != errPriorWalkDone && !os.IsNotExist(err) {
+			nlog.Errorln(t.String(), "failed to list prior versions in", ctdir, "err:", err)
+		}
+		result.Versions = append(result.Versions, pw.vers...)
+	}
+	result.Sort()
+	if len(result.Versions) > limit {
+		result.Versions = result.Versions[:limit]
+	}
+	sgl := t.gmm.NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+func (pw *priorWalk) do(dir, rel string) error {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var (
+		prefix = pw.res.Prefix
+		marker = pw.res.KeyMarker
+		ents   = make([]priorEnt, 0, len(des))
+	)
+	for _, de := range des {
+		name := de.Name()
+		if de.IsDir() {
+			key := rel + name + "/"
+			if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(prefix, key) {
+				continue
+			}
+			if key < marker && !strings.HasPrefix(marker, key) { // (all names in the subtree precede the marker)
+				continue
+			}
+			ents = append(ents, priorEnt{de: de, key: key, name: name})
+			continue
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i <= 0 {
+			continue
+		}
+		objName, ver := rel+name[:i], name[i+1:]
+		if !strings.HasPrefix(objName, prefix) || !s3.ValidVersionID(ver) || !pw.res.AfterMarker(objName, ver) {
+			continue
+		}
+		ents = append(ents, priorEnt{de: de, key: objName, ver: ver, name: name})
+	}
+	// (a directory "a/" follows all sibling files "a", "a-b", etc. and precedes "a0")
+	sort.Slice(ents, func(i, j int) bool { return s3.Before(ents[i].key, ents[i].ver, ents[j].key, ents[j].ver) })
+
+	for i := range ents {
+		e := &ents[i]
+		fqn := filepath.Join(dir, e.name)
+		if e.de.IsDir() {
+			if err := pw.do(fqn, e.key); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		finfo, err := e.de.Info()
+		if err != nil {
+			continue // (removed in the meantime)
+		}
+		ov := &s3.ObjVersion{
+			Key:          e.key,
+			VersionID:    e.ver,
+			LastModified: cos.FormatTime(finfo.ModTime(), cos.ISO8601),
+			Size:         finfo.Size(),
+		}
+		if oa, err := core.LoadPriorAttrs(fqn); err == nil {
+			ov.ETag = s3.PriorETag(oa)
+		}
+		pw.vers = append(pw.vers, ov)
+		if len(pw.vers) >= pw.limit {
+			return errPriorWalkDone
+		}
+	}
+	return nil
+}
+
+// GET|HEAD /s3/<bucket-name>/<object-name>?versionId=<id>
+// returns false when the specified version is the current one (to be served via regular path)
+func (t *target) getPriorVerS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName, ver string) bool {
+	if !s3.ValidVersionID(ver) {
+		s3.WriteErr(w, r, errInvalidVersionID, 0)
+		return true
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err == nil {
+		if s3.IsCurrent(ver, lom.Version()) {
+			return false
+		}
+	} else if !cos.IsNotExist(err, 0) {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+
+	pfqn := lom.PriorFQN(ver)
+	fh, err := os.Open(pfqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s3.WriteErr(w, r, s3.ErrNoSuchVersion, http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return true
+	}
+	defer cos.Close(fh)
+	finfo, err := fh.Stat()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return true
+	}
+
+	hdr := w.Header()
+	if oa, err := core.LoadPriorAttrs(pfqn); err == nil {
+		if etag := s3.PriorETag(oa); etag != "" {
+			hdr.Set(cos.S3CksumHeader, etag)
+		}
+		if v, ok := oa.GetCustomKey(cos.HdrContentType); ok {
+			hdr.Set(cos.HdrContentType, v)
+		}
+		s3.SetTaggingCount(hdr, oa.GetCustomMD())
+	}
+	if hdr.Get(cos.HdrContentType) == "" {
+		hdr.Set(cos.HdrContentType, cos.ContentBinary)
+	}
+	hdr.Set(cos.HdrContentLength, strconv.FormatInt(finfo.Size(), 10))
+	hdr.Set(cos.S3LastModified, cos.FormatTime(finfo.ModTime(), cos.RFC1123GMT))
+	hdr.Set(cos.S3VersionHeader, ver)
+	if r.Method == http.MethodHead {
+		return true
+	}
+	if _, err := io.Copy(w, fh); err != nil {
+		nlog.Errorln(t.String(), "failed to transmit", pfqn, "err:", err)
+	}
+	return true
+}
+
+// DELETE /s3/<bucket-name>/<object-name>?versionId=<id>
+func (t *target) delObjVerS3(w http.ResponseWriter, r *http.Request, items []string, ver string) {
+	if !s3.ValidVersionID(ver) {
+		s3.WriteErr(w, r, errInvalidVersionID, 0)
+		return
+	}
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	objName := s3.ObjName(items)
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil && s3.IsCurrent(ver, lom.Version()) {
+		// the current version (still under lock - a concurrent PUT must not get deleted)
+		ecode, err := t.delObjLocked(lom, false)
+		lom.Unlock(true)
+		if err != nil {
+			s3.WriteErr(w, r, err, ecode)
+			return
+		}
+		ec.ECM.CleanupObject(lom)
+	} else {
+		pfqn := lom.PriorFQN(ver)
+		if oa, err := core.LoadPriorAttrs(pfqn); err == nil {
+			if err := lom.Bprops().ObjLock.Check(lom.Cname(), oa.GetCustomMD(), time.Now()); err != nil {
+				lom.Unlock(true)
+				s3.WriteErr(w, r, err, http.StatusForbidden)
+				return
+			}
+		}
+		err := lom.RemovePrior(pfqn)
+		lom.Unlock(true)
+		if err != nil {
+			if os.IsNotExist(err) {
+				s3.WriteErr(w, r, s3.ErrNoSuchVersion, http.StatusNotFound)
+			} else {
+				s3.WriteErr(w, r, err, 0)
+			}
+			return
+		}
+	}
+	w.Header().Set(cos.S3VersionHeader, ver)
+	w.WriteHeader(http.StatusNoContent)
+}
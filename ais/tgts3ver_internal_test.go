@@ -0,0 +1,52 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+)
+
+func TestPriorWalk(tt *testing.T) {
+	dir := tt.TempDir()
+	for _, name := range []string{"a.1", "a.3", "a-b.2", "a/x.5", "a/x.4", "a/y/z.1", "a0.2", "b.7", "c/d/e.1", "noversion", "bad.0"} {
+		fqn := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fqn), 0o755); err != nil {
+			tt.Fatal(err)
+		}
+		if err := os.WriteFile(fqn, []byte(name), 0o644); err != nil {
+			tt.Fatal(err)
+		}
+	}
+	tests := []struct {
+		q    url.Values
+		want string
+	}{
+		{url.Values{}, "a:3 a:1 a-b:2 a/x:5 a/x:4 a/y/z:1 a0:2 b:7 c/d/e:1 "},
+		{url.Values{s3.QparamMaxKeys: {"2"}}, "a:3 a:1 a-b:2 "},
+		{url.Values{s3.QparamPrefix: {"a/"}}, "a/x:5 a/x:4 a/y/z:1 "},
+		{url.Values{s3.QparamKeyMarker: {"a/x"}, s3.QparamVersionIDMarker: {"5"}, s3.QparamMaxKeys: {"2"}}, "a/x:4 a/y/z:1 a0:2 "},
+		{url.Values{s3.QparamKeyMarker: {"a0"}}, "b:7 c/d/e:1 "},
+		{url.Values{s3.QparamKeyMarker: {"b"}, s3.QparamPrefix: {"c/"}}, "c/d/e:1 "},
+	}
+	for _, test := range tests {
+		res := s3.NewListVersionsResult("bck", test.q)
+		pw := &priorWalk{res: res, limit: res.MaxKeys + 1}
+		if err := pw.do(dir, ""); err != nil && err != errPriorWalkDone {
+			tt.Fatal(err)
+		}
+		var got string
+		for _, v := range pw.vers {
+			got += v.Key + ":" + v.VersionID + " "
+		}
+		if got != test.want {
+			tt.Errorf("%v: expected %q, got %q", test.q, test.want, got)
+		}
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -506,9 +505,6 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *meta.Bck, msg *aisMsg) error
 }
 
 func etlDP(msg *apc.TCBMsg) (core.DP, error) {
-	if !k8s.IsK8s() {
-		return nil, k8s.ErrK8sRequired
-	}
 	if err := msg.Validate(true); err != nil {
 		return nil, err
 	}
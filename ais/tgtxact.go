@@ -229,6 +229,12 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(args.ID, bck)
 		return xid, rns.Err
+	case apc.ActLifecycle:
+		rns := t.runLifecycle(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActECScrub:
+		rns := xreg.RenewECScrub(args.ID, bck)
+		return xid, rns.Err
 	case apc.ActBlobDl:
 		debug.Assert(msg.Name != "")
 		lom := core.AllocLOM(msg.Name)
@@ -25,6 +25,7 @@ const (
 	ActECGet     = "ec-get"    // read erasure coded objects
 	ActECPut     = "ec-put"    // erasure code objects
 	ActECRespond = "ec-resp"   // respond to other targets' EC requests
+	ActECScrub   = "ec-scrub"  // verify and repair erasure coded slices and metafiles
 
 	ActCopyBck = "copy-bck"
 	ActETLBck  = "etl-bck"
@@ -48,6 +49,7 @@ const (
 
 	ActLRU          = "lru"
 	ActStoreCleanup = "cleanup-store"
+	ActLifecycle    = "lifecycle" // expire objects and abort stale multipart uploads as per bucket lifecycle rules
 
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
@@ -56,6 +58,7 @@ const (
 	ActNewPrimary     = "new-primary"
 	ActPromote        = "promote"
 	ActRenameObject   = "rename-obj"
+	ActObjLock        = "obj-lock" // set object retention and/or legal hold (see ObjLockMsg)
 
 	// cp (reverse)
 	ActResetStats  = "reset-stats"
@@ -72,7 +75,8 @@ const (
 	ActETLObjects      = "etl-listrange"
 	ActEvictObjects    = "evict-listrange"
 	ActPrefetchObjects = "prefetch-listrange"
-	ActArchive         = "archive" // see ArchiveMsg
+	ActArchive         = "archive"   // see ArchiveMsg
+	ActGetBatch        = "get-batch" // see GetBatchMsg
 
 	ActAttachRemAis = "attach"
 	ActDetachRemAis = "detach"
@@ -31,7 +31,16 @@ type (
 			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`  // sum(all object sizes in a remote bucket)
 			Disks       uint64 `json:"total_disks_size,string"`
 		}
-		UsedPct      uint64 `json:"used_pct"`
-		IsBckPresent bool   `json:"is_present"` // in BMD
+		Quota        *QuotaUsage `json:"quota,omitempty"` // nil when the bucket has no quota
+		UsedPct      uint64      `json:"used_pct"`
+		IsBckPresent bool        `json:"is_present"` // in BMD
+	}
+
+	// bucket quota (hard limits) and its usage
+	QuotaUsage struct {
+		Size         int64 `json:"size,string,omitempty"`    // total size of all objects (zero - unlimited)
+		Objects      int64 `json:"objects,string,omitempty"` // number of objects (ditto)
+		Pct          int64 `json:"pct"`                      // usage of the most utilized limit (%)
+		SoftExceeded bool  `json:"soft_exceeded,omitempty"`
 	}
 )
@@ -0,0 +1,38 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// get-batch: retrieve many objects and/or archived files in a single (streaming) response
+// formatted as one of the supported archive types (default: .tar)
+// - the request's entries may belong to different buckets, with the request's own bucket
+//   (see api.GetBatch) being the default; all buckets must be in the global namespace;
+// - the response is assembled by a single (designated) target in the exact order
+//   of the request's entries;
+// - missing entries and entries that failed to read are, respectively, named
+//   GetBatchMissingDir/<name> (empty) and GetBatchErrDir/<name> (the content being the error message),
+//   iff ContinueOnError is set; otherwise, the first such entry terminates the response;
+// - the number of entries is limited by GetBatchMaxEntries.
+
+const (
+	GetBatchMissingDir = "__404__"
+	GetBatchErrDir     = "__err__"
+
+	GetBatchMaxEntries = 16 * 1024
+)
+
+type (
+	GetBatchIn struct {
+		ObjName  string `json:"objname"`
+		Bucket   string `json:"bucket,omitempty"`   // when omitted, the request's bucket
+		Provider string `json:"provider,omitempty"` // ditto
+		ArchPath string `json:"archpath,omitempty"` // extract the specified file from an object (shard)
+	}
+	GetBatchMsg struct {
+		In              []GetBatchIn `json:"in"`
+		Mime            string       `json:"mime"` // output format (default: .tar)
+		OnlyObjName     bool         `json:"onob"` // name the output entries <objname>[/<archpath>] (default: <bucket>/<objname>[/<archpath>])
+		ContinueOnError bool         `json:"coer"` // missing and failed entries do not terminate the response (see above)
+	}
+)
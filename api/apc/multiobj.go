@@ -4,17 +4,22 @@
  */
 package apc
 
+import "github.com/NVIDIA/aistore/cmn/cos"
+
 // (common for all multi-object operations)
 type (
 	// List of object names _or_ a template specifying { optional Prefix, zero or more Ranges }
+	// optionally, further filtered by object tags
 	ListRange struct {
-		Template string   `json:"template"`
-		ObjNames []string `json:"objnames"`
+		Tags     cos.StrKVs `json:"tags,omitempty"` // select only objects that have all of the specified tags
+		Template string     `json:"template"`
+		ObjNames []string   `json:"objnames"`
 	}
 )
 
 // [NOTE]
 // - empty `ListRange{}` implies operating on an entire bucket ("all objects in the source bucket")
+// - tag filtering applies only to objects present in the cluster (see cmn/objtags.go)
 // - in re `LatestVer`, see related: `QparamLatestVer`, 'versioning.validate_warm_get'
 
 func (lrm *ListRange) IsList() bool      { return len(lrm.ObjNames) > 0 }
@@ -34,7 +39,7 @@ type PrefetchMsg struct {
 // at the specified (bucket) destination.
 // See also: api.PutApndArchArgs
 // --------------------  terminology   ---------------------
-// here and elsewhere "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4) formatted object.
+// here and elsewhere "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4, .tar.zst) formatted object.
 // [NOTE] see cmn/api for cmn.ArchiveMsg (that also contains ToBck)
 type ArchiveMsg struct {
 	TxnUUID     string `json:"-"`        // internal use
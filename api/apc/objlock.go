@@ -0,0 +1,14 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import "time"
+
+// ObjLockMsg sets object retention and/or legal hold in a bucket with object lock
+// enabled (bucket property "object_lock"); nil fields remain unchanged
+type ObjLockMsg struct {
+	RetainUntil *time.Time `json:"retain-until,omitempty"` // zero time removes retention (not permitted in compliance mode)
+	LegalHold   *bool      `json:"legal-hold,omitempty"`
+}
@@ -28,6 +28,12 @@ const (
 	// e.g., usage: copy bucket
 	QparamBckTo = "bck_to"
 
+	// (AuthN) user ID, as asserted by the redirecting proxy (see audit log and per-bucket metrics)
+	QparamAuditUser = "audit_user"
+
+	// W3C trace context of the redirecting proxy's span (see tracing)
+	QparamTraceparent = "traceparent"
+
 	// Do not add remote bucket to cluster's BMD e.g. when checking existence
 	// via api.HeadBucket
 	// By default, when existence of a remote buckets is confirmed the bucket's
@@ -71,7 +77,7 @@ const (
 	QparamAllLogs = "all"
 
 	// The following 4 (four) QparamArch* parameters are all intended for usage with sharded datasets,
-	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, and/or .tar.lz4) formatted objects.
+	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, .tar.lz4, and/or .tar.zst) formatted objects.
 	//
 	// For the most recently updated list of supported serialization formats, please see cmn/archive package.
 	//
@@ -178,16 +184,17 @@ const (
 
 // Internal query params.
 const (
-	QparamProxyID          = "pid" // ID of the redirecting proxy.
-	QparamPrimaryCandidate = "can" // ID of the candidate for the primary proxy.
-	QparamPrepare          = "prp" // true: request belongs to the "prepare" phase of the primary proxy election
-	QparamNonElectable     = "nel" // true: proxy is non-electable for the primary role
-	QparamUnixTime         = "utm" // Unix time since 01/01/70 UTC (nanoseconds)
-	QparamIsGFNRequest     = "gfn" // true if the request is a Get-From-Neighbor
-	QparamRebStatus        = "rbs" // true: get detailed rebalancing status
-	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
-	QparamClusterInfo      = "cii" // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
-	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
+	QparamProxyID          = "pid"  // ID of the redirecting proxy.
+	QparamPrimaryCandidate = "can"  // ID of the candidate for the primary proxy.
+	QparamPrepare          = "prp"  // true: request belongs to the "prepare" phase of the primary proxy election
+	QparamNonElectable     = "nel"  // true: proxy is non-electable for the primary role
+	QparamUnixTime         = "utm"  // Unix time since 01/01/70 UTC (nanoseconds)
+	QparamRedirectSig      = "rsig" // redirecting proxy's signature (see isVerifiedRedirect)
+	QparamIsGFNRequest     = "gfn"  // true if the request is a Get-From-Neighbor
+	QparamRebStatus        = "rbs"  // true: get detailed rebalancing status
+	QparamRebData          = "rbd"  // true: get EC rebalance data (pulling data if push way fails)
+	QparamClusterInfo      = "cii"  // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
+	QparamOWT              = "owt"  // object write transaction enum { OwtPut, ..., OwtGet* }
 
 	QparamDontResilver = "dntres" // true: do not resilver data off of mountpaths that are being disabled/detached
 
@@ -252,6 +259,7 @@ const (
 	// internal
 	WhatSnode    = "snode"
 	WhatICBundle = "ic_bundle"
+	WhatBckUsage = "bucket_usage" // target's usage of the buckets that have quotas (see cmn/quota.go)
 
 	// tls
 	WhatCertificate = "tls_certificate"
@@ -259,7 +267,8 @@ const (
 
 // QparamLogSev enum.
 const (
-	LogInfo = "info"
-	LogWarn = "warning"
-	LogErr  = "error"
+	LogInfo  = "info"
+	LogWarn  = "warning"
+	LogErr   = "error"
+	LogAudit = "audit" // audit records (see nlog.Audit)
 )
@@ -31,7 +31,9 @@ const (
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	OIDC      = "oidc"     // AuthN
 	IC        = "ic"       // information center
+	GetBatch  = "get-batch"
 
 	// l3 ---
 
@@ -47,6 +49,17 @@ const (
 	// target
 	Mountpaths = "mountpaths"
 
+	// AuthN-managed S3 access keys (users/<user-id>/s3keys and tokens/s3keys)
+	S3Keys = "s3keys"
+
+	// AuthN public signing keys (tokens/jwks)
+	JWKS = "jwks"
+
+	// AuthN login via external OIDC identity provider (oidc/login, oidc/callback, oidc/token)
+	OIDCLogin    = "login"
+	OIDCCallback = "callback"
+	OIDCToken    = "token"
+
 	// common
 	Init     = "init"
 	Start    = "start"
@@ -106,6 +119,7 @@ var (
 	URLPathTxn      = urlpath(Version, Txn)
 	URLPathXactions = urlpath(Version, Xactions)
 	URLPathIC       = urlpath(Version, IC)
+	URLPathGetBatch = urlpath(Version, GetBatch)
 	URLPathHealth   = urlpath(Version, Health)
 	URLPathMetasync = urlpath(Version, Metasync)
 
@@ -165,6 +179,7 @@ var (
 	URLPathUsers    = urlpath(Version, Users)
 	URLPathClusters = urlpath(Version, Clusters)
 	URLPathRoles    = urlpath(Version, Roles)
+	URLPathOIDC     = urlpath(Version, OIDC)
 )
 
 func (u URLPath) Join(words ...string) string {
@@ -272,6 +272,97 @@ func RevokeToken(bp api.BaseParams, token string) error {
 	return reqParams.DoRequest()
 }
 
+// Start logging in via external OIDC identity provider. The user then completes
+// authentication at the returned URL (entering the user code, if any), while
+// the caller polls PollOIDCToken
+func LoginOIDC(bp api.BaseParams, msg *OIDCLoginMsg) (*OIDCLogin, error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathOIDC.Join(apc.OIDCLogin)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	login := &OIDCLogin{}
+	if _, err := reqParams.DoReqAny(login); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+// Poll for the token of the OIDC login session; returns nil token (and no error)
+// while the user has not completed authentication yet
+func PollOIDCToken(bp api.BaseParams, session string) (*TokenMsg, error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathOIDC.Join(apc.OIDCToken)
+		reqParams.Body = cos.MustMarshal(&OIDCTokenMsg{Session: session})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	token := &TokenMsg{}
+	status, err := reqParams.DoReqAny(token)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		return nil, nil // pending
+	}
+	if token.Token == "" {
+		return nil, errors.New("login failed: empty response from AuthN server")
+	}
+	return token, nil
+}
+
+// Generate a new S3 access key for the user. Returns both the access key ID and secret -
+// the latter cannot be retrieved later.
+func AddS3Key(bp api.BaseParams, userID string) (*S3Key, error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, apc.S3Keys)
+	}
+	key := &S3Key{}
+	if _, err := reqParams.DoReqAny(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// List user's S3 access keys (without secrets)
+func ListS3Keys(bp api.BaseParams, userID string) ([]*S3Key, error) {
+	bp.Method = http.MethodGet
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, apc.S3Keys)
+	}
+	keys := make([]*S3Key, 0)
+	_, err := reqParams.DoReqAny(&keys)
+
+	less := func(i, j int) bool { return keys[i].ID < keys[j].ID }
+	sort.Slice(keys, less)
+	return keys, err
+}
+
+func DeleteS3Key(bp api.BaseParams, userID, keyID string) error {
+	bp.Method = http.MethodDelete
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, apc.S3Keys, keyID)
+	}
+	return reqParams.DoRequest()
+}
+
 func GetConfig(bp api.BaseParams) (*Config, error) {
 	bp.Method = http.MethodGet
 	reqParams := api.AllocRp()
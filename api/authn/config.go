@@ -22,6 +22,7 @@ type (
 		Net     NetConf     `json:"net"`
 		Server  ServerConf  `json:"auth"`
 		Timeout TimeoutConf `json:"timeout"`
+		OIDC    *OIDCConf   `json:"oidc,omitempty"`
 		// private
 		mu sync.RWMutex `json:"-"`
 	}
@@ -41,6 +42,12 @@ type (
 	ServerConf struct {
 		Secret string       `json:"secret"`
 		Expire cos.Duration `json:"expiration_time"`
+		// PEM-encoded RSA or EC P-256 private key files: the first one signs new tokens (RS256 or ES256),
+		// all are published via JWKS (to keep validating tokens issued prior to key rotation);
+		// empty - sign with the secret (HS256)
+		SigningKeys []string `json:"signing_keys,omitempty"`
+		// (with signing keys) keep accepting HS256 tokens signed with the secret, e.g. during migration
+		AllowHS256 bool `json:"allow_hs256,omitempty"`
 		// private
 		psecret *string       `json:"-"`
 		pexpire *cos.Duration `json:"-"`
@@ -48,12 +55,36 @@ type (
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
 	}
+	// external OIDC identity provider (IdP)
+	OIDCConf struct {
+		Issuer       string `json:"issuer"` // e.g. "https://keycloak.example.com/realms/ais"
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret,omitempty"` // empty for public clients
+		// AuthN endpoint the IdP redirects to (authorization code flow), e.g.
+		// "https://authn.example.com:52001/v1/oidc/callback"; empty - device flow only
+		RedirectURL string   `json:"redirect_url,omitempty"`
+		Scopes      []string `json:"scopes,omitempty"`       // in addition to "openid"
+		UserClaim   string   `json:"user_claim,omitempty"`   // default: "preferred_username", then "sub"
+		GroupsClaim string   `json:"groups_claim,omitempty"` // default: "groups"
+		// IdP groups (or other claims) => AuthN roles and bucket ACLs;
+		// logging in is not permitted unless the user is granted at least one role or ACL
+		Mappings     []*OIDCMapping `json:"mappings"`
+		DefaultRoles []string       `json:"default_roles,omitempty"` // roles granted to any authenticated user
+	}
+	OIDCMapping struct {
+		Claim      string    `json:"claim,omitempty"` // default: groups claim
+		Value      string    `json:"value"`           // claim value or one of its values, e.g. IdP group name
+		Roles      []string  `json:"roles,omitempty"`
+		BucketACLs []*BckACL `json:"buckets,omitempty"`
+	}
 	ConfigToUpdate struct {
 		Server *ServerConfToSet `json:"auth"`
 	}
 	ServerConfToSet struct {
-		Secret *string `json:"secret,omitempty"`
-		Expire *string `json:"expiration_time,omitempty"`
+		Secret      *string   `json:"secret,omitempty"`
+		Expire      *string   `json:"expiration_time,omitempty"`
+		SigningKeys *[]string `json:"signing_keys,omitempty"`
+		AllowHS256  *bool     `json:"allow_hs256,omitempty"`
 	}
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
@@ -112,5 +143,11 @@ func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
 		c.Server.Expire = v
 		c.Server.pexpire = &v
 	}
+	if cu.Server.SigningKeys != nil {
+		c.Server.SigningKeys = *cu.Server.SigningKeys
+	}
+	if cu.Server.AllowHS256 != nil {
+		c.Server.AllowHS256 = *cu.Server.AllowHS256
+	}
 	return nil
 }
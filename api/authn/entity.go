@@ -16,11 +16,19 @@ const (
 	AdminRole = "Admin"
 )
 
+// OIDC login flows
+const (
+	OIDCFlowDevice = "device" // OAuth 2.0 device authorization grant (RFC 8628)
+	OIDCFlowCode   = "code"   // authorization code grant (via browser and AuthN callback)
+)
+
 type (
 	User struct {
 		ID       string  `json:"id"`
 		Password string  `json:"pass,omitempty"`
 		Roles    []*Role `json:"roles"`
+		// per-user limits enforced by AIS gateways (see cmn.RateLimitConf)
+		RateLimit *cmn.RateLimitConf `json:"rate_limit,omitempty"`
 	}
 
 	CluACL struct {
@@ -44,10 +52,45 @@ type (
 		ExpiresIn *time.Duration `json:"expires_in"`
 	}
 
+	// login via external OIDC identity provider
+	OIDCLoginMsg struct {
+		Flow      string         `json:"flow"` // OIDCFlowDevice (default) | OIDCFlowCode
+		ExpiresIn *time.Duration `json:"expires_in"`
+	}
+	// OIDCLogin is AuthN response to OIDCLoginMsg: the user completes authentication at URL
+	// while the client polls AuthN for the token (see PollOIDCToken)
+	OIDCLogin struct {
+		Session   string `json:"session"`
+		URL       string `json:"url"`
+		UserCode  string `json:"user_code,omitempty"` // (device flow)
+		Interval  int    `json:"interval"`            // polling interval, seconds
+		ExpiresIn int    `json:"expires_in"`          // seconds
+	}
+	OIDCTokenMsg struct {
+		Session string `json:"session"`
+	}
+
 	RegisteredClusters struct {
 		Clusters map[string]*CluACL `json:"clusters,omitempty"`
 	}
 
+	// S3 access key (AWS Signature V4)
+	// - ID and Secret are generated by AuthN
+	// - Token carries the permissions of the user the key belongs to
+	//   and is (re)generated every time the keys get synchronized with clusters
+	S3Key struct {
+		ID      string    `json:"id"`
+		Secret  string    `json:"secret,omitempty"`
+		UserID  string    `json:"user_id"`
+		Created time.Time `json:"created"`
+		Token   string    `json:"token,omitempty"`
+	}
+	// S3KeyList is a list of S3 access keys pushed by authn
+	S3KeyList struct {
+		Keys    []*S3Key `json:"keys"`
+		Version int64    `json:"version,string"`
+	}
+
 	Role struct {
 		Name        string    `json:"name"`
 		Description string    `json:"desc"`
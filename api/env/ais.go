@@ -23,6 +23,9 @@ var (
 		LocalRedirectCIDR string
 		PubIPv4CIDR       string
 
+		// node's failure domain
+		FailureDomain string
+
 		//
 		// HTTPS
 		// for details and background, see: https://github.com/NVIDIA/aistore/blob/main/docs/environment-vars.md#https
@@ -55,6 +58,10 @@ var (
 		LocalRedirectCIDR: "AIS_CLUSTER_CIDR",
 		PubIPv4CIDR:       "AIS_PUBLIC_IP_CIDR",
 
+		// failure domain (e.g., rack or zone) of the node; overrides local config "failure_domain"
+		// (and see ECConf.DomainTolerance)
+		FailureDomain: "AIS_FAILURE_DOMAIN",
+
 		// false: HTTP transport, with all the TLS config (below) ignored
 		// true:  HTTPS/TLS
 		// for details and background, see: https://github.com/NVIDIA/aistore/blob/main/docs/environment-vars.md#https
@@ -0,0 +1,41 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// GetBatch retrieves multiple objects and/or archived files (see apc.GetBatchMsg)
+// and writes them to `w`, in the order of the request's entries, formatted as
+// a single archive (default: .tar).
+// - `bck` (optional) is the default bucket for the entries that do not specify one;
+// - returns the number of bytes written.
+func GetBatch(bp BaseParams, bck cmn.Bck, msg *apc.GetBatchMsg, w io.Writer) (n int64, err error) {
+	var wresp *wrappedResp
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathGetBatch.S
+		if bck.Name != "" {
+			reqParams.Path = apc.URLPathGetBatch.Join(bck.Name)
+			reqParams.Query = bck.NewQuery()
+		}
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	wresp, err = reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err == nil {
+		n = wresp.n
+	}
+	return n, err
+}
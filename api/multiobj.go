@@ -53,16 +53,24 @@ func ETLMultiObj(bp BaseParams, bckFrom cmn.Bck, msg *cmn.TCOMsg, fltPresence ..
 }
 
 func DeleteMultiObj(bp BaseParams, bck cmn.Bck, objNames []string, template string) (string, error) {
+	return DeleteMultiObjLR(bp, bck, &apc.ListRange{ObjNames: objNames, Template: template})
+}
+
+// same as above, with the entire list-range selection, including object tags
+func DeleteMultiObjLR(bp BaseParams, bck cmn.Bck, msg *apc.ListRange) (string, error) {
 	bp.Method = http.MethodDelete
 	q := bck.NewQuery()
-	msg := apc.ListRange{ObjNames: objNames, Template: template}
 	return dolr(bp, bck, apc.ActDeleteObjects, msg, q)
 }
 
 func EvictMultiObj(bp BaseParams, bck cmn.Bck, objNames []string, template string) (string, error) {
+	return EvictMultiObjLR(bp, bck, &apc.ListRange{ObjNames: objNames, Template: template})
+}
+
+// ditto
+func EvictMultiObjLR(bp BaseParams, bck cmn.Bck, msg *apc.ListRange) (string, error) {
 	bp.Method = http.MethodDelete
 	q := bck.NewQuery()
-	msg := apc.ListRange{ObjNames: objNames, Template: template}
 	return dolr(bp, bck, apc.ActEvictObjects, msg, q)
 }
 
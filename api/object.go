@@ -445,7 +445,7 @@ func PrefetchObject(bp BaseParams, bck cmn.Bck, objName string) (string, error)
 }
 
 // Archive the content of a reader (`args.Reader` - e.g., an open file). =======================================
-// Destination, depending on the options, can be an existing (.tar, .tgz or .tar.gz, .zip, .tar.lz4)
+// Destination, depending on the options, can be an existing (.tar, .tgz or .tar.gz, .zip, .tar.lz4, .tar.zst)
 // formatted object (aka "shard") or a new one (or, a new version).
 // ---
 // For the updated list of supported archival formats -- aka MIME types -- see cmn/cos/archive.go.
@@ -576,6 +576,23 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// SetObjectLock sets retention and/or legal hold of an object in a bucket
+// with object lock enabled (see cmn.ObjLockConf and apc.ObjLockMsg)
+func SetObjectLock(bp BaseParams, bck cmn.Bck, objName string, msg *apc.ObjLockMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActObjLock, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // Promote =========================================================================================
 // promote POSIX files and/or directories to (become) in-cluster objects.
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -25,18 +26,28 @@ const (
 	retry503   = time.Minute
 )
 
+// HS256: make sure the cluster shares the secret (compare sha256 checksums);
+// RS256/ES256: make sure the cluster validates tokens signed by the current key
 func (m *mgr) validateSecret(clu *authn.CluACL) (err error) {
 	const tag = "validate-secret"
 	var (
-		secret = Conf.Secret()
-		cksum  = cos.NewCksumHash(cos.ChecksumSHA256)
+		body  []byte
+		token string
 	)
-	cksum.H.Write([]byte(secret))
-	cksum.Finalize()
-
-	body := cos.MustMarshal(&authn.ServerConf{Secret: cksum.Val()})
+	if keys.asymmetric() {
+		body = cos.MustMarshal(&authn.ServerConf{})
+		token, err = tok.AdminJWT(time.Now().Add(syncTokenTime), adminUserID, keys.signing())
+		if err != nil {
+			return err
+		}
+	} else {
+		cksum := cos.NewCksumHash(cos.ChecksumSHA256)
+		cksum.H.Write([]byte(Conf.Secret()))
+		cksum.Finalize()
+		body = cos.MustMarshal(&authn.ServerConf{Secret: cksum.Val()})
+	}
 	for _, u := range clu.URLs {
-		if err = m.call(http.MethodPost, u, apc.Tokens, body, tag); err == nil {
+		if err = m.call(http.MethodPost, u, apc.Tokens, body, token, tag); err == nil {
 			return
 		}
 		err = fmt.Errorf("failed to %s with %s: %v", tag, clu, err)
@@ -48,12 +59,35 @@ func (m *mgr) validateSecret(clu *authn.CluACL) (err error) {
 func (m *mgr) broadcastRevoked(token string) {
 	tokenList := authn.TokenList{Tokens: []string{token}}
 	body := cos.MustMarshal(tokenList)
-	m.broadcast(http.MethodDelete, apc.Tokens, body, "broadcast-revoked")
+	m.broadcast(http.MethodDelete, apc.Tokens, body, "", "broadcast-revoked")
+}
+
+// send the list of all S3 access keys to all clusters
+func (m *mgr) syncS3Keys() {
+	body, token, err := m.s3KeysReq()
+	if err != nil {
+		nlog.Errorf("failed to sync S3 keys: %v", err)
+		return
+	}
+	m.broadcast(http.MethodPut, cos.JoinWords(apc.Tokens, apc.S3Keys), body, token, "sync-s3keys")
+}
+
+// returns S3 key list (body) and the admin token to authenticate the request
+func (m *mgr) s3KeysReq() (body []byte, token string, err error) {
+	list, err := m.generateS3KeyList()
+	if err != nil {
+		return nil, "", err
+	}
+	token, err = tok.AdminJWT(time.Now().Add(syncTokenTime), adminUserID, keys.signing())
+	if err != nil {
+		return nil, "", err
+	}
+	return cos.MustMarshal(list), token, nil
 }
 
 // broadcast the request to all clusters. If a cluster has a few URLS,
 // it sends to the first working one. Clusters are processed in parallel.
-func (m *mgr) broadcast(method, path string, body []byte, tag string) {
+func (m *mgr) broadcast(method, path string, body []byte, token, tag string) {
 	clus, err := m.clus()
 	if err != nil {
 		nlog.Errorf("Failed to read cluster list: %v", err)
@@ -65,7 +99,7 @@ func (m *mgr) broadcast(method, path string, body []byte, tag string) {
 		go func(clu *authn.CluACL) {
 			var err error
 			for _, u := range clu.URLs {
-				if err = m.call(method, u, path, body, tag); err == nil {
+				if err = m.call(method, u, path, body, token, tag); err == nil {
 					break
 				}
 			}
@@ -91,7 +125,26 @@ func (m *mgr) syncTokenList(clu *authn.CluACL) {
 	}
 	body := cos.MustMarshal(authn.TokenList{Tokens: tokenList})
 	for _, u := range clu.URLs {
-		if err = m.call(http.MethodDelete, u, apc.Tokens, body, tag); err == nil {
+		if err = m.call(http.MethodDelete, u, apc.Tokens, body, "", tag); err == nil {
+			break
+		}
+		err = fmt.Errorf("failed to %s with %s: %v", tag, clu, err)
+	}
+	if err != nil {
+		nlog.Errorln(err)
+	}
+}
+
+// Send S3 access keys to a (newly registered) cluster.
+func (m *mgr) syncS3KeyList(clu *authn.CluACL) {
+	const tag = "sync-s3keys"
+	body, token, err := m.s3KeysReq()
+	if err != nil {
+		nlog.Errorf("failed to sync S3 keys with %q(%q): %v", clu.ID, clu.Alias, err)
+		return
+	}
+	for _, u := range clu.URLs {
+		if err = m.call(http.MethodPut, u, cos.JoinWords(apc.Tokens, apc.S3Keys), body, token, tag); err == nil {
 			break
 		}
 		err = fmt.Errorf("failed to %s with %s: %v", tag, clu, err)
@@ -102,7 +155,7 @@ func (m *mgr) syncTokenList(clu *authn.CluACL) {
 }
 
 // TODO: reuse api/client.go reqParams.do()
-func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) error {
+func (m *mgr) call(method, proxyURL, path string, injson []byte, token, tag string) error {
 	var (
 		rerr    error
 		msg     []byte
@@ -121,6 +174,9 @@ func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) err
 			return nerr
 		}
 		req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+		if token != "" {
+			req.Header.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+token)
+		}
 		resp, err := client.Do(req)
 		if resp != nil && resp.Body != nil {
 			var e error
@@ -47,6 +47,10 @@ func httpConfigPut(w http.ResponseWriter, r *http.Request) {
 
 	Conf.Lock()
 	err := Conf.ApplyUpdate(updateCfg)
+	if err == nil && (updateCfg.Server.Secret != nil || updateCfg.Server.SigningKeys != nil ||
+		updateCfg.Server.AllowHS256 != nil) {
+		err = keys.load() // (key rotation)
+	}
 	Conf.Unlock()
 	if err != nil {
 		cmn.WriteErr(w, r, err)
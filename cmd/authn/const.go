@@ -17,6 +17,7 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	s3KeysCollection   = "s3key"
 
 	adminUserID   = "admin"
 	adminUserPass = "admin"
@@ -24,4 +25,12 @@ const (
 	// when user-provided token expiration time is zero it means the token never expires;
 	// we then create a token and set it to expire in 20 years - effectively, never
 	foreverTokenTime = 20 * 365 * 24 * time.Hour
+
+	// S3 access keys: ID = prefix + random uppercase alphanumerics
+	s3KeyIDPrefix = "AK"
+	s3KeyIDLen    = 18
+	s3SecretLen   = 40
+
+	// lifetime of the admin token that authenticates pushing S3 keys to clusters
+	syncTokenTime = time.Hour
 )
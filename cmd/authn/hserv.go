@@ -23,9 +23,10 @@ import (
 const svcName = "AuthN"
 
 type hserv struct {
-	mux *http.ServeMux
-	s   *http.Server
-	mgr *mgr
+	mux  *http.ServeMux
+	s    *http.Server
+	mgr  *mgr
+	oidc *oidcProvider // nil if external identity provider is not configured
 }
 
 func newServer(mgr *mgr) *hserv {
@@ -112,6 +113,7 @@ func (h *hserv) registerPublicHandlers() {
 	h.registerHandler(apc.URLPathClusters.S, h.clusterHandler)
 	h.registerHandler(apc.URLPathRoles.S, h.roleHandler)
 	h.registerHandler(apc.URLPathDae.S, configHandler)
+	h.registerHandler(apc.URLPathOIDC.S, h.oidcHandler)
 }
 
 func (h *hserv) userHandler(w http.ResponseWriter, r *http.Request) {
@@ -133,8 +135,10 @@ func (h *hserv) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		h.httpRevokeToken(w, r)
+	case http.MethodGet:
+		h.httpJWKS(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet)
 	}
 }
 
@@ -166,8 +170,7 @@ func (h *hserv) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty token")
 		return
 	}
-	secret := Conf.Secret()
-	if _, err := tok.DecryptToken(msg.Token, secret); err != nil {
+	if _, err := keys.decrypt(msg.Token); err != nil {
 		cmn.WriteErr(w, r, err)
 		return
 	}
@@ -182,6 +185,10 @@ func (h *hserv) httpUserDel(w http.ResponseWriter, r *http.Request) {
 	if err = validateAdminPerms(w, r); err != nil {
 		return
 	}
+	if len(apiItems) > 1 && apiItems[1] == apc.S3Keys {
+		h.s3KeyDel(w, r, apiItems)
+		return
+	}
 	if err := h.mgr.delUser(apiItems[0]); err != nil {
 		nlog.Errorf("Failed to delete user: %v\n", err)
 		cmn.WriteErrMsg(w, r, "Failed to delete user: "+err.Error())
@@ -193,9 +200,12 @@ func (h *hserv) httpUserPost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if len(apiItems) == 0 {
+	switch {
+	case len(apiItems) == 0:
 		h.userAdd(w, r)
-	} else {
+	case len(apiItems) == 2 && apiItems[1] == apc.S3Keys:
+		h.s3KeyAdd(w, r, apiItems[0])
+	default:
 		h.userLogin(w, r)
 	}
 }
@@ -251,6 +261,10 @@ func (h *hserv) httpUserGet(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if len(items) == 2 && items[1] == apc.S3Keys {
+		h.s3KeyList(w, r, items[0])
+		return
+	}
 	if len(items) > 1 {
 		cmn.WriteErrMsg(w, r, "invalid request")
 		return
@@ -278,6 +292,62 @@ func (h *hserv) httpUserGet(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, uInfo, "get user")
 }
 
+// POST /v1/users/<user-id>/s3keys
+func (h *hserv) s3KeyAdd(w http.ResponseWriter, r *http.Request, userID string) {
+	if err := validateAdminPerms(w, r); err != nil {
+		return
+	}
+	key, err := h.mgr.addS3Key(userID)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			cmn.WriteErr(w, r, err, http.StatusNotFound)
+		} else {
+			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+		}
+		return
+	}
+	if Conf.Verbose() {
+		nlog.Infof("Add S3 key %q for user %q", key.ID, userID)
+	}
+	writeJSON(w, key, "add S3 key")
+}
+
+// GET /v1/users/<user-id>/s3keys
+func (h *hserv) s3KeyList(w http.ResponseWriter, r *http.Request, userID string) {
+	if err := validateAdminPerms(w, r); err != nil {
+		return
+	}
+	keys, err := h.mgr.s3KeyList(userID)
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	for _, key := range keys {
+		key.Secret = ""
+	}
+	writeJSON(w, keys, "list S3 keys")
+}
+
+// DELETE /v1/users/<user-id>/s3keys/<key-id>
+func (h *hserv) s3KeyDel(w http.ResponseWriter, r *http.Request, apiItems []string) {
+	if len(apiItems) != 3 {
+		cmn.WriteErrMsg(w, r, "S3 access key ID is not defined")
+		return
+	}
+	userID, keyID := apiItems[0], apiItems[2]
+	if err := h.mgr.delS3Key(userID, keyID); err != nil {
+		if cos.IsErrNotFound(err) {
+			cmn.WriteErr(w, r, err, http.StatusNotFound)
+		} else {
+			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+		}
+		return
+	}
+	if Conf.Verbose() {
+		nlog.Infof("Delete S3 key %q of user %q", keyID, userID)
+	}
+}
+
 // Checks if the request header contains valid admin credentials.
 // (admin is created at deployment time and cannot be modified via API)
 func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
@@ -286,8 +356,7 @@ func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
-	secret := Conf.Secret()
-	tk, err := tok.DecryptToken(token, secret)
+	tk, err := keys.decrypt(token)
 	if err != nil {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
@@ -0,0 +1,106 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Token signing keys: HS256 with the secret (default) or, when `auth.signing_keys`
+// are configured, RS256/ES256 with the first of the configured private keys
+// (in which case HS256 tokens are no longer accepted unless `auth.allow_hs256`).
+// All public keys are published via GET /v1/tokens/jwks - AIS gateways use them
+// to validate tokens without having to share the secret (see ais/prxauth.go).
+
+type keyring struct {
+	sk   *tok.SigningKey // signs new tokens
+	ks   *tok.KeySet     // validates (all keys)
+	jwks *tok.JWKS       // public keys
+	mu   sync.RWMutex
+}
+
+var keys = &keyring{}
+
+// (re)load signing keys from the current configuration
+func (kr *keyring) load() error {
+	var (
+		secret = Conf.Secret()
+		fqns   = Conf.Server.SigningKeys
+		jwks   = &tok.JWKS{Keys: make([]*tok.JWK, 0, len(fqns))}
+		sk     *tok.SigningKey
+	)
+	for i, fqn := range fqns {
+		data, err := os.ReadFile(fqn)
+		if err != nil {
+			return fmt.Errorf("failed to read signing key: %v", err)
+		}
+		k, err := tok.ParseSigningKey(data)
+		if err != nil {
+			return fmt.Errorf("invalid signing key %q: %v", fqn, err)
+		}
+		if i == 0 {
+			sk = k
+		}
+		jwks.Keys = append(jwks.Keys, k.JWK())
+	}
+	var ks *tok.KeySet
+	if sk == nil {
+		sk, ks = tok.NewHMACKey(secret), tok.NewHMACKeySet(secret)
+	} else {
+		var err error
+		if !Conf.Server.AllowHS256 {
+			secret = "" // asymmetric only
+		}
+		if ks, err = tok.NewKeySet(secret, jwks); err != nil {
+			return err
+		}
+	}
+
+	kr.mu.Lock()
+	kr.sk, kr.ks, kr.jwks = sk, ks, jwks
+	kr.mu.Unlock()
+	nlog.Infof("signing tokens with %s (public keys: %d)", sk.Alg(), len(jwks.Keys))
+	return nil
+}
+
+func (kr *keyring) signing() (sk *tok.SigningKey) {
+	kr.mu.RLock()
+	sk = kr.sk
+	kr.mu.RUnlock()
+	return
+}
+
+func (kr *keyring) asymmetric() bool { return kr.signing().JWK() != nil }
+
+func (kr *keyring) decrypt(token string) (*tok.Token, error) {
+	kr.mu.RLock()
+	ks := kr.ks
+	kr.mu.RUnlock()
+	return ks.DecryptToken(token)
+}
+
+// GET /v1/tokens/jwks (public; no authentication required)
+func (*hserv) httpJWKS(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := parseURL(w, r, 1, apc.URLPathTokens.L)
+	if err != nil {
+		return
+	}
+	if apiItems[0] != apc.JWKS {
+		cmn.WriteErrMsg(w, r, "invalid request: "+r.URL.Path)
+		return
+	}
+	keys.mu.RLock()
+	jwks := keys.jwks
+	keys.mu.RUnlock()
+	writeJSON(w, jwks, "get JWKS")
+}
@@ -74,6 +74,9 @@ func main() {
 	if val := os.Getenv(env.AuthN.SecretKey); val != "" {
 		Conf.SetSecret(&val)
 	}
+	if err := keys.load(); err != nil {
+		cos.ExitLogf("Failed to load token signing keys: %v", err)
+	}
 	if err := updateLogOptions(); err != nil {
 		cos.ExitLogf("Failed to set up logger: %v", err)
 	}
@@ -96,6 +99,11 @@ func main() {
 	go logFlush()
 
 	srv := newServer(mgr)
+	if Conf.OIDC != nil {
+		if srv.oidc, err = newOIDC(Conf.OIDC, mgr); err != nil {
+			cos.ExitLogf("Failed to init OIDC: %v", err)
+		}
+	}
 	err = srv.Run()
 
 	nlog.Flush(nlog.ActExit)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -67,6 +68,11 @@ func (m *mgr) addUser(info *authn.User) error {
 	if info.ID == "" || info.Password == "" {
 		return errInvalidCredentials
 	}
+	if info.RateLimit != nil {
+		if err := info.RateLimit.ValidateAsProps(); err != nil {
+			return err
+		}
+	}
 
 	_, err := m.db.GetString(usersCollection, info.ID)
 	if err == nil {
@@ -81,7 +87,13 @@ func (m *mgr) delUser(userID string) error {
 	if userID == adminUserID {
 		return fmt.Errorf("cannot remove built-in %q account", adminUserID)
 	}
-	return m.db.Delete(usersCollection, userID)
+	if err := m.db.Delete(usersCollection, userID); err != nil {
+		return err
+	}
+	if n := m.delUserS3Keys(userID); n > 0 {
+		go m.syncS3Keys()
+	}
+	return nil
 }
 
 // Updates an existing user. The function invalidates user tokens after
@@ -102,7 +114,19 @@ func (m *mgr) updateUser(userID string, updateReq *authn.User) error {
 	if len(updateReq.Roles) != 0 {
 		uInfo.Roles = updateReq.Roles
 	}
-	return m.db.Set(usersCollection, userID, uInfo)
+	if updateReq.RateLimit != nil {
+		if err := updateReq.RateLimit.ValidateAsProps(); err != nil {
+			return err
+		}
+		uInfo.RateLimit = updateReq.RateLimit
+	}
+	if err := m.db.Set(usersCollection, userID, uInfo); err != nil {
+		return err
+	}
+	if len(updateReq.Roles) != 0 || updateReq.RateLimit != nil {
+		go m.syncS3Keys() // permissions (or limits) may have changed
+	}
+	return nil
 }
 
 func (m *mgr) lookupUser(userID string) (*authn.User, error) {
@@ -302,6 +326,7 @@ func (m *mgr) addCluster(clu *authn.CluACL) error {
 	m.createRolesForCluster(clu)
 
 	go m.syncTokenList(clu)
+	go m.syncS3KeyList(clu)
 	return nil
 }
 
@@ -350,12 +375,7 @@ func (m *mgr) delCluster(cluID string) error {
 // Token includes user ID, permissions, and token expiration time.
 // If a new token was generated then it sends the proxy a new valid token list
 func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, err error) {
-	var (
-		uInfo   = &authn.User{}
-		cid     string
-		cluACLs []*authn.CluACL
-		bckACLs []*authn.BckACL
-	)
+	uInfo := &authn.User{}
 	err = m.db.Get(usersCollection, uid, uInfo)
 	if err != nil {
 		nlog.Errorln(err)
@@ -369,16 +389,21 @@ func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, er
 	}
 
 	// update ACLs with roles' ones
-	for _, role := range uInfo.Roles {
-		cluACLs = mergeClusterACLs(cluACLs, role.ClusterACLs, cid)
-		bckACLs = mergeBckACLs(bckACLs, role.BucketACLs, cid)
-	}
+	cluACLs, bckACLs := userACLs(uInfo)
 
 	// generate token
 	token, err = m._token(msg, uInfo, cluACLs, bckACLs)
 	return token, err
 }
 
+func userACLs(uInfo *authn.User) (cluACLs []*authn.CluACL, bckACLs []*authn.BckACL) {
+	for _, role := range uInfo.Roles {
+		cluACLs = mergeClusterACLs(cluACLs, role.ClusterACLs, "")
+		bckACLs = mergeBckACLs(bckACLs, role.BucketACLs, "")
+	}
+	return cluACLs, bckACLs
+}
+
 func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.CluACL, bckACLs []*authn.BckACL) (token string, err error) {
 	expDelta := Conf.Expire()
 	if msg.ExpiresIn != nil {
@@ -394,10 +419,10 @@ func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.Cl
 	expires := time.Now().Add(expDelta)
 	uid := uInfo.ID
 	if uInfo.IsAdmin() {
-		token, err = tok.AdminJWT(expires, uid, Conf.Secret())
+		token, err = tok.AdminJWT(expires, uid, keys.signing())
 	} else {
 		m.fixClusterIDs(cluACLs)
-		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, Conf.Secret())
+		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, uInfo.RateLimit, keys.signing())
 	}
 	return token, err
 }
@@ -446,9 +471,8 @@ func (m *mgr) generateRevokedTokenList() ([]string, error) {
 
 	now := time.Now()
 	revokeList := make([]string, 0, len(tokens))
-	secret := Conf.Secret()
 	for _, token := range tokens {
-		tk, err := tok.DecryptToken(token, secret)
+		tk, err := keys.decrypt(token)
 		if err != nil {
 			m.db.Delete(revokedCollection, token)
 			continue
@@ -463,6 +487,107 @@ func (m *mgr) generateRevokedTokenList() ([]string, error) {
 	return revokeList, nil
 }
 
+//
+// S3 access keys ============================================================
+//
+
+// Generates a new S3 access key for an existing user.
+// Returns the key including its secret, which is the only time the secret is exposed.
+func (m *mgr) addS3Key(userID string) (*authn.S3Key, error) {
+	if _, err := m.lookupUser(userID); err != nil {
+		return nil, cos.NewErrNotFound(m, "user "+userID)
+	}
+	key := &authn.S3Key{
+		ID:      s3KeyIDPrefix + strings.ToUpper(cos.CryptoRandS(s3KeyIDLen)),
+		Secret:  cos.CryptoRandS(s3SecretLen),
+		UserID:  userID,
+		Created: time.Now(),
+	}
+	if _, err := m.db.GetString(s3KeysCollection, key.ID); err == nil {
+		return nil, fmt.Errorf("duplicate S3 access key %q, please try again", key.ID) // (unlikely)
+	}
+	if err := m.db.Set(s3KeysCollection, key.ID, key); err != nil {
+		return nil, err
+	}
+	go m.syncS3Keys()
+	return key, nil
+}
+
+func (m *mgr) delS3Key(userID, keyID string) error {
+	key := &authn.S3Key{}
+	if err := m.db.Get(s3KeysCollection, keyID, key); err != nil || key.UserID != userID {
+		return cos.NewErrNotFound(m, "S3 access key "+keyID)
+	}
+	if err := m.db.Delete(s3KeysCollection, keyID); err != nil {
+		return err
+	}
+	go m.syncS3Keys()
+	return nil
+}
+
+// Returns all S3 access keys or, if `userID` is defined, the keys of this user.
+func (m *mgr) s3KeyList(userID string) ([]*authn.S3Key, error) {
+	recs, err := m.db.GetAll(s3KeysCollection, "")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*authn.S3Key, 0, len(recs))
+	for _, str := range recs {
+		key := &authn.S3Key{}
+		if err := jsoniter.Unmarshal([]byte(str), key); err != nil {
+			return nil, err
+		}
+		if userID == "" || key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Removes all S3 keys of the deleted user; returns the number of removed keys.
+func (m *mgr) delUserS3Keys(userID string) (n int) {
+	keys, err := m.s3KeyList(userID)
+	if err != nil {
+		nlog.Errorf("failed to list S3 keys of user %q: %v", userID, err)
+		return 0
+	}
+	for _, key := range keys {
+		if err := m.db.Delete(s3KeysCollection, key.ID); err != nil {
+			nlog.Errorf("failed to remove S3 key %q of user %q: %v", key.ID, userID, err)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// Creates the list of all S3 access keys to be sent to clusters.
+// Each key is given a never-expiring token with the current permissions of its user.
+func (m *mgr) generateS3KeyList() (*authn.S3KeyList, error) {
+	keys, err := m.s3KeyList("")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		forever time.Duration
+		msg     = &authn.LoginMsg{ExpiresIn: &forever}
+		list    = &authn.S3KeyList{Keys: make([]*authn.S3Key, 0, len(keys)), Version: time.Now().UnixNano()}
+	)
+	for _, key := range keys {
+		uInfo, err := m.lookupUser(key.UserID)
+		if err != nil {
+			nlog.Errorf("S3 key %q: user %q not found, skipping", key.ID, key.UserID)
+			continue
+		}
+		cluACLs, bckACLs := userACLs(uInfo)
+		if key.Token, err = m._token(msg, uInfo, cluACLs, bckACLs); err != nil {
+			return nil, err
+		}
+		list.Keys = append(list.Keys, key)
+	}
+	return list, nil
+}
+
 //
 // private helpers ============================================================
 //
@@ -0,0 +1,646 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/golang-jwt/jwt/v4"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Login via external OIDC identity provider (IdP):
+// - device flow (RFC 8628): AuthN requests device code from the IdP and returns the verification URL
+//   and user code to the client (CLI), which then polls POST /v1/oidc/token; AuthN, in turn, polls the IdP;
+// - authorization code flow (with PKCE): the client opens the returned URL in a browser; the IdP redirects
+//   back to GET /v1/oidc/callback, where AuthN exchanges the code for ID token; the client polls, as above.
+// Either way, AuthN verifies the IdP-signed ID token, maps its claims (groups) to AuthN roles
+// and bucket ACLs (see authn.OIDCConf), and issues a regular AuthN token.
+// OIDC users are not stored in the AuthN database.
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcGrantDevice   = "urn:ietf:params:oauth:grant-type:device_code"
+	oidcGrantCode     = "authorization_code"
+
+	oidcDfltInterval   = 5 * time.Second // RFC 8628, sec. 3.2
+	oidcCodeInterval   = 2 * time.Second
+	oidcCodeTimeout    = 10 * time.Minute
+	oidcMaxSessions    = 1024
+	oidcSessionIDLen   = 32
+	oidcKeysMinRefresh = 10 * time.Second
+	oidcMaxRespSize    = cos.MiB
+
+	contentForm = "application/x-www-form-urlencoded"
+)
+
+type (
+	oidcProvider struct {
+		conf     *authn.OIDCConf
+		mgr      *mgr
+		meta     *oidcMeta
+		ks       *tok.KeySet
+		sessions map[string]*oidcSession // by session ID
+		fetched  time.Time               // IdP keys
+		mu       sync.Mutex
+	}
+	// OpenID Provider metadata (OpenID Connect Discovery 1.0, sec. 3)
+	oidcMeta struct {
+		Issuer    string `json:"issuer"`
+		AuthURL   string `json:"authorization_endpoint"`
+		TokenURL  string `json:"token_endpoint"`
+		DeviceURL string `json:"device_authorization_endpoint"`
+		JWKSURL   string `json:"jwks_uri"`
+	}
+	oidcSession struct {
+		expiresIn  *time.Duration // requested AuthN token expiration
+		expires    time.Time      // session
+		polled     time.Time
+		err        error
+		flow       string
+		token      string // issued AuthN token
+		deviceCode string
+		state      string
+		nonce      string
+		verifier   string // PKCE code verifier
+		interval   time.Duration
+	}
+	// IdP device authorization response (RFC 8628, sec. 3.2)
+	oidcDeviceResp struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURI string `json:"verification_uri"`
+		VerificationAll string `json:"verification_uri_complete"`
+		ExpiresIn       int    `json:"expires_in"`
+		Interval        int    `json:"interval"`
+	}
+	// IdP token response: success or error (RFC 6749, sec. 5)
+	oidcTokenResp struct {
+		IDToken   string `json:"id_token"`
+		Error     string `json:"error"`
+		ErrorDesc string `json:"error_description"`
+	}
+)
+
+var errOIDCPending = errors.New("authorization pending")
+
+func newOIDC(conf *authn.OIDCConf, m *mgr) (*oidcProvider, error) {
+	if conf.Issuer == "" || conf.ClientID == "" {
+		return nil, errors.New("OIDC issuer and client ID must be defined")
+	}
+	if _, err := url.ParseRequestURI(conf.Issuer); err != nil {
+		return nil, fmt.Errorf("invalid OIDC issuer %q: %v", conf.Issuer, err)
+	}
+	for _, mp := range conf.Mappings {
+		if mp.Value == "" {
+			return nil, errors.New("OIDC mapping: claim value must be defined")
+		}
+	}
+	return &oidcProvider{conf: conf, mgr: m, sessions: make(map[string]*oidcSession, 16)}, nil
+}
+
+func (p *oidcProvider) client(u string) *http.Client {
+	if cos.IsHTTPS(u) {
+		return p.mgr.clientTLS
+	}
+	return p.mgr.clientH
+}
+
+// discover IdP endpoints and fetch its public keys (lazily, upon first login)
+// NOTE: requests the IdP without holding the lock - concurrent first logins may both do it
+func (p *oidcProvider) discover() (*oidcMeta, error) {
+	p.mu.Lock()
+	meta := p.meta
+	p.mu.Unlock()
+	if meta != nil {
+		return meta, nil
+	}
+	u := strings.TrimSuffix(p.conf.Issuer, "/") + oidcDiscoveryPath
+	meta = &oidcMeta{}
+	if err := p.get(u, meta); err != nil {
+		return nil, fmt.Errorf("OIDC discovery: %v", err)
+	}
+	if meta.Issuer != p.conf.Issuer {
+		return nil, fmt.Errorf("OIDC discovery: issuer mismatch %q vs %q", meta.Issuer, p.conf.Issuer)
+	}
+	if meta.TokenURL == "" || meta.JWKSURL == "" {
+		return nil, fmt.Errorf("OIDC discovery: %s does not provide token endpoint and/or keys", p.conf.Issuer)
+	}
+	ks, err := p.fetchKeys(meta.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	if p.meta == nil {
+		p.meta, p.ks, p.fetched = meta, ks, time.Now()
+	}
+	meta = p.meta
+	p.mu.Unlock()
+	return meta, nil
+}
+
+func (p *oidcProvider) fetchKeys(u string) (*tok.KeySet, error) {
+	jwks := &tok.JWKS{}
+	if err := p.get(u, jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC provider keys: %v", err)
+	}
+	return tok.NewKeySet("", jwks)
+}
+
+// re-fetch IdP public keys upon encountering unknown key ID (key rotation)
+func (p *oidcProvider) refreshKeys() bool {
+	p.mu.Lock()
+	if time.Since(p.fetched) < oidcKeysMinRefresh {
+		p.mu.Unlock()
+		return false
+	}
+	p.fetched = time.Now()
+	u := p.meta.JWKSURL
+	p.mu.Unlock()
+
+	ks, err := p.fetchKeys(u)
+	if err != nil {
+		nlog.Errorln(err)
+		return false
+	}
+	p.mu.Lock()
+	p.ks = ks
+	p.mu.Unlock()
+	return true
+}
+
+func (p *oidcProvider) keySet() (ks *tok.KeySet) {
+	p.mu.Lock()
+	ks = p.ks
+	p.mu.Unlock()
+	return
+}
+
+//
+// sessions
+//
+
+func (p *oidcProvider) addSession(sess *oidcSession) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for id, s := range p.sessions {
+		if now.After(s.expires) {
+			delete(p.sessions, id)
+		}
+	}
+	if len(p.sessions) >= oidcMaxSessions {
+		return "", errors.New("too many pending OIDC logins, please try again later")
+	}
+	id := cos.CryptoRandS(oidcSessionIDLen)
+	p.sessions[id] = sess
+	return id, nil
+}
+
+func (p *oidcProvider) getSession(id string) *oidcSession {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sess, ok := p.sessions[id]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(sess.expires) {
+		delete(p.sessions, id)
+		return nil
+	}
+	return sess
+}
+
+// find authorization code flow session by state and mark it consumed, so that
+// replayed (or concurrent) callbacks do not exchange the same code twice
+func (p *oidcProvider) bystate(state string) *oidcSession {
+	if state == "" {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, sess := range p.sessions {
+		if sess.flow == authn.OIDCFlowCode && sess.state == state && sess.token == "" && sess.err == nil {
+			sess.state = "" // consumed
+			return sess
+		}
+	}
+	return nil
+}
+
+func (p *oidcProvider) delSession(id string) {
+	p.mu.Lock()
+	delete(p.sessions, id)
+	p.mu.Unlock()
+}
+
+//
+// login flows
+//
+
+func (p *oidcProvider) login(msg *authn.OIDCLoginMsg) (*authn.OIDCLogin, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+	sess := &oidcSession{flow: msg.Flow, expiresIn: msg.ExpiresIn}
+	switch msg.Flow {
+	case "", authn.OIDCFlowDevice:
+		sess.flow = authn.OIDCFlowDevice
+		return p.loginDevice(meta, sess)
+	case authn.OIDCFlowCode:
+		return p.loginCode(meta, sess)
+	default:
+		return nil, fmt.Errorf("invalid OIDC login flow %q (expecting %q or %q)", msg.Flow, authn.OIDCFlowDevice, authn.OIDCFlowCode)
+	}
+}
+
+func (p *oidcProvider) loginDevice(meta *oidcMeta, sess *oidcSession) (*authn.OIDCLogin, error) {
+	if meta.DeviceURL == "" {
+		return nil, fmt.Errorf("OIDC provider %s does not support device flow", p.conf.Issuer)
+	}
+	form := p.form()
+	form.Set("scope", p.scope())
+	resp := &oidcDeviceResp{}
+	status, err := p.post(meta.DeviceURL, form, resp)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || resp.DeviceCode == "" {
+		return nil, fmt.Errorf("OIDC device authorization failed: status %d", status)
+	}
+	sess.deviceCode = resp.DeviceCode
+	sess.interval = oidcDfltInterval
+	if resp.Interval > 0 {
+		sess.interval = time.Duration(resp.Interval) * time.Second
+	}
+	timeout := oidcCodeTimeout
+	if resp.ExpiresIn > 0 {
+		timeout = time.Duration(resp.ExpiresIn) * time.Second
+	}
+	sess.expires = time.Now().Add(timeout)
+	id, err := p.addSession(sess)
+	if err != nil {
+		return nil, err
+	}
+	return &authn.OIDCLogin{
+		Session:   id,
+		URL:       cos.Left(resp.VerificationAll, resp.VerificationURI),
+		UserCode:  resp.UserCode,
+		Interval:  int(sess.interval / time.Second),
+		ExpiresIn: int(timeout / time.Second),
+	}, nil
+}
+
+func (p *oidcProvider) loginCode(meta *oidcMeta, sess *oidcSession) (*authn.OIDCLogin, error) {
+	if p.conf.RedirectURL == "" || meta.AuthURL == "" {
+		return nil, errors.New("OIDC authorization code flow is not configured (missing redirect URL)")
+	}
+	sess.state = cos.CryptoRandS(oidcSessionIDLen)
+	sess.nonce = cos.CryptoRandS(oidcSessionIDLen)
+	sess.verifier = cos.CryptoRandS(64)
+	sess.interval = oidcCodeInterval
+	sess.expires = time.Now().Add(oidcCodeTimeout)
+	id, err := p.addSession(sess)
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(sess.verifier))
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.conf.ClientID)
+	q.Set("redirect_uri", p.conf.RedirectURL)
+	q.Set("scope", p.scope())
+	q.Set("state", sess.state)
+	q.Set("nonce", sess.nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	sep := "?"
+	if strings.Contains(meta.AuthURL, "?") {
+		sep = "&"
+	}
+	return &authn.OIDCLogin{
+		Session:   id,
+		URL:       meta.AuthURL + sep + q.Encode(),
+		Interval:  int(sess.interval / time.Second),
+		ExpiresIn: int(oidcCodeTimeout / time.Second),
+	}, nil
+}
+
+// authorization code flow: redirected by the IdP
+func (p *oidcProvider) callback(query url.Values) error {
+	sess := p.bystate(query.Get("state"))
+	if sess == nil {
+		return errors.New("OIDC login session not found or expired")
+	}
+	var err error
+	if e := query.Get("error"); e != "" {
+		err = fmt.Errorf("OIDC login failed: %s %s", e, query.Get("error_description"))
+	} else {
+		form := p.form()
+		form.Set("grant_type", oidcGrantCode)
+		form.Set("code", query.Get("code"))
+		form.Set("redirect_uri", p.conf.RedirectURL)
+		form.Set("code_verifier", sess.verifier)
+		var token string
+		if token, err = p.exchange(form, sess); err == nil {
+			p.mu.Lock()
+			sess.token = token
+			p.mu.Unlock()
+			return nil
+		}
+	}
+	p.mu.Lock()
+	sess.err = err
+	p.mu.Unlock()
+	return err
+}
+
+// poll: returns AuthN token, errOIDCPending, or error
+func (p *oidcProvider) token(id string) (string, error) {
+	sess := p.getSession(id)
+	if sess == nil {
+		return "", errors.New("OIDC login session not found or expired")
+	}
+	p.mu.Lock()
+	token, err := sess.token, sess.err
+	pending := token == "" && err == nil
+	if pending && (sess.flow != authn.OIDCFlowDevice || time.Since(sess.polled) < sess.interval) {
+		p.mu.Unlock()
+		return "", errOIDCPending
+	}
+	sess.polled = time.Now()
+	p.mu.Unlock()
+
+	if pending {
+		// device flow: poll the IdP
+		form := p.form()
+		form.Set("grant_type", oidcGrantDevice)
+		form.Set("device_code", sess.deviceCode)
+		token, err = p.exchange(form, sess)
+		if err == errOIDCPending {
+			return "", err
+		}
+	}
+	p.delSession(id)
+	return token, err
+}
+
+// exchange authorization (device) code for ID token and, if valid, issue AuthN token
+func (p *oidcProvider) exchange(form url.Values, sess *oidcSession) (string, error) {
+	resp := &oidcTokenResp{}
+	status, err := p.post(p.meta.TokenURL, form, resp)
+	if err != nil {
+		return "", err
+	}
+	switch resp.Error {
+	case "":
+	case "authorization_pending":
+		return "", errOIDCPending
+	case "slow_down":
+		p.mu.Lock()
+		sess.interval += oidcDfltInterval
+		p.mu.Unlock()
+		return "", errOIDCPending
+	default:
+		return "", fmt.Errorf("OIDC login failed: %s %s", resp.Error, resp.ErrorDesc)
+	}
+	if status != http.StatusOK || resp.IDToken == "" {
+		return "", fmt.Errorf("OIDC login failed: status %d, no ID token", status)
+	}
+	claims, err := p.verify(resp.IDToken, sess.nonce)
+	if err != nil {
+		return "", err
+	}
+	return p.issueToken(claims, sess.expiresIn)
+}
+
+func (p *oidcProvider) verify(idToken, nonce string) (jwt.MapClaims, error) {
+	claims, err := p.keySet().Claims(idToken)
+	if errors.Is(err, tok.ErrUnknownKID) && p.refreshKeys() {
+		claims, err = p.keySet().Claims(idToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %v", err)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("invalid ID token: missing expiration time")
+	}
+	if !claims.VerifyIssuer(p.meta.Issuer, true) {
+		return nil, fmt.Errorf("invalid ID token: issuer %v", claims["iss"])
+	}
+	if !claims.VerifyAudience(p.conf.ClientID, true) {
+		return nil, fmt.Errorf("invalid ID token: audience %v", claims["aud"])
+	}
+	if nonce != "" && claims["nonce"] != nonce {
+		return nil, errors.New("invalid ID token: nonce mismatch")
+	}
+	return claims, nil
+}
+
+// map ID token claims to AuthN roles and bucket ACLs
+func (p *oidcProvider) issueToken(claims jwt.MapClaims, expiresIn *time.Duration) (string, error) {
+	userID, _ := claims[cos.Left(p.conf.UserClaim, "preferred_username")].(string)
+	if userID == "" {
+		userID, _ = claims["sub"].(string)
+	}
+	if userID == "" {
+		return "", errors.New("invalid ID token: missing user ID")
+	}
+	var (
+		names   = append([]string{}, p.conf.DefaultRoles...)
+		bckACLs []*authn.BckACL
+		groups  = cos.Left(p.conf.GroupsClaim, "groups")
+		uInfo   = &authn.User{ID: userID}
+	)
+	for _, mp := range p.conf.Mappings {
+		if !hasClaim(claims[cos.Left(mp.Claim, groups)], mp.Value) {
+			continue
+		}
+		names = append(names, mp.Roles...)
+		bckACLs = mergeBckACLs(bckACLs, mp.BucketACLs, "")
+	}
+	for _, name := range names {
+		role, err := p.mgr.lookupRole(name)
+		if err != nil {
+			nlog.Errorf("OIDC user %q: role %q not found", userID, name)
+			continue
+		}
+		uInfo.Roles = append(uInfo.Roles, role)
+	}
+	if len(uInfo.Roles) == 0 && len(bckACLs) == 0 {
+		return "", fmt.Errorf("OIDC user %q: not authorized (no roles)", userID)
+	}
+	cluACLs, roleACLs := userACLs(uInfo)
+	bckACLs = mergeBckACLs(roleACLs, bckACLs, "")
+
+	nlog.Infof("OIDC login: user %q, roles %v", userID, names)
+	return p.mgr._token(&authn.LoginMsg{ExpiresIn: expiresIn}, uInfo, cluACLs, bckACLs)
+}
+
+// claim value is a string, a list of strings (e.g., groups), or a scalar
+func hasClaim(claim any, value string) bool {
+	switch v := claim.(type) {
+	case nil:
+		return false
+	case string:
+		return v == value
+	case []any:
+		for _, item := range v {
+			if fmt.Sprint(item) == value {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == value
+	}
+}
+
+//
+// IdP requests
+//
+
+func (p *oidcProvider) scope() string {
+	return strings.Join(append([]string{"openid"}, p.conf.Scopes...), " ")
+}
+
+func (p *oidcProvider) form() url.Values {
+	form := url.Values{}
+	form.Set("client_id", p.conf.ClientID)
+	if p.conf.ClientSecret != "" {
+		form.Set("client_secret", p.conf.ClientSecret)
+	}
+	return form
+}
+
+func (p *oidcProvider) get(u string, out any) error {
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	status, err := p.do(req, out)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("%s: status %d", u, status)
+	}
+	return err
+}
+
+func (p *oidcProvider) post(u string, form url.Values, out any) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(cos.HdrContentType, contentForm)
+	return p.do(req, out)
+}
+
+func (p *oidcProvider) do(req *http.Request, out any) (int, error) {
+	req.Header.Set(cos.HdrAccept, cos.ContentJSON)
+	resp, err := p.client(req.URL.String()).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+	}()
+	if err := jsoniter.NewDecoder(io.LimitReader(resp.Body, oidcMaxRespSize)).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%s: failed to decode response (status %d): %v", req.URL.Host, resp.StatusCode, err)
+	}
+	return resp.StatusCode, nil
+}
+
+//
+// http handlers
+//
+
+func (h *hserv) oidcHandler(w http.ResponseWriter, r *http.Request) {
+	if h.oidc == nil {
+		cmn.WriteErrMsg(w, r, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.httpOIDCCallback(w, r)
+	case http.MethodPost:
+		h.httpOIDCPost(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
+// POST /v1/oidc/login | /v1/oidc/token
+func (h *hserv) httpOIDCPost(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := parseURL(w, r, 1, apc.URLPathOIDC.L)
+	if err != nil {
+		return
+	}
+	switch apiItems[0] {
+	case apc.OIDCLogin:
+		msg := &authn.OIDCLoginMsg{}
+		if err := cmn.ReadJSON(w, r, msg); err != nil {
+			return
+		}
+		login, err := h.oidc.login(msg)
+		if err != nil {
+			nlog.Errorln(err)
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		writeJSON(w, login, "OIDC login")
+	case apc.OIDCToken:
+		msg := &authn.OIDCTokenMsg{}
+		if err := cmn.ReadJSON(w, r, msg); err != nil {
+			return
+		}
+		token, err := h.oidc.token(msg.Session)
+		switch {
+		case err == errOIDCPending:
+			w.WriteHeader(http.StatusAccepted)
+		case err != nil:
+			nlog.Errorln(err)
+			cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		default:
+			writeJSON(w, &authn.TokenMsg{Token: token}, "OIDC token")
+		}
+	default:
+		cmn.WriteErrMsg(w, r, "invalid request: "+r.URL.Path)
+	}
+}
+
+// GET /v1/oidc/callback?code=...&state=... (browser, redirected by the IdP)
+func (h *hserv) httpOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := parseURL(w, r, 1, apc.URLPathOIDC.L)
+	if err != nil {
+		return
+	}
+	if apiItems[0] != apc.OIDCCallback {
+		cmn.WriteErrMsg(w, r, "invalid request: "+r.URL.Path)
+		return
+	}
+	if err := h.oidc.callback(r.URL.Query()); err != nil {
+		nlog.Errorln(err)
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set(cos.HdrContentType, "text/plain; charset=utf-8")
+	io.WriteString(w, "Logged in. You can close this window and return to the command line.\n")
+}
@@ -0,0 +1,316 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Token signing and verification keys:
+// - HS256: shared secret (legacy) - every AIS gateway must hold the secret and, therefore,
+//   can also mint tokens;
+// - RS256 and ES256 (P-256): AuthN signs with a private key and publishes the corresponding
+//   public keys as JWK Set (RFC 7517); gateways validate tokens using public keys only.
+// Asymmetric keys are identified by `kid` (RFC 7638 thumbprint) carried in the token header,
+// which allows to rotate signing keys while still accepting tokens signed by the previous ones.
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+
+	minRSABits = 2048
+)
+
+type (
+	SigningKey struct {
+		method jwt.SigningMethod
+		key    any // []byte (secret) | *rsa.PrivateKey | *ecdsa.PrivateKey
+		jwk    *JWK
+	}
+
+	// JSON Web Key (public)
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		// RSA
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// EC
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+	JWKS struct {
+		Keys []*JWK `json:"keys"`
+	}
+
+	// KeySet validates tokens signed with the shared secret (if any)
+	// and/or any of the public keys (by kid)
+	KeySet struct {
+		pub    map[string]any // kid => *rsa.PublicKey | *ecdsa.PublicKey
+		secret string
+		hs256  bool
+	}
+)
+
+var ErrUnknownKID = errors.New("unknown signing key ID")
+
+////////////////
+// SigningKey //
+////////////////
+
+func NewHMACKey(secret string) *SigningKey {
+	return &SigningKey{method: jwt.SigningMethodHS256, key: []byte(secret)}
+}
+
+// ParseSigningKey parses PEM-encoded RSA (PKCS #1 or #8) or EC P-256 (SEC 1 or PKCS #8) private key
+func ParseSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM private key")
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sk := &SigningKey{key: key}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if bits := k.N.BitLen(); bits < minRSABits {
+			return nil, fmt.Errorf("RSA key size %d is too small (expecting at least %d bits)", bits, minRSABits)
+		}
+		sk.method = jwt.SigningMethodRS256
+		sk.jwk, err = NewJWK(&k.PublicKey)
+	case *ecdsa.PrivateKey:
+		sk.method = jwt.SigningMethodES256
+		sk.jwk, err = NewJWK(&k.PublicKey)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+func (sk *SigningKey) Alg() string { return sk.method.Alg() }
+
+// public key (nil for HS256)
+func (sk *SigningKey) JWK() *JWK { return sk.jwk }
+
+func (sk *SigningKey) Sign(claims jwt.MapClaims) (string, error) {
+	t := jwt.NewWithClaims(sk.method, claims)
+	if sk.jwk != nil {
+		t.Header["kid"] = sk.jwk.Kid
+	}
+	return t.SignedString(sk.key)
+}
+
+/////////
+// JWK //
+/////////
+
+func NewJWK(pub any) (*JWK, error) {
+	var jwk *JWK
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		jwk = &JWK{
+			Kty: "RSA",
+			Alg: AlgRS256,
+			N:   b64(k.N.Bytes()),
+			E:   b64(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported elliptic curve %s (expecting P-256)", k.Curve.Params().Name)
+		}
+		jwk = &JWK{
+			Kty: "EC",
+			Alg: AlgES256,
+			Crv: "P-256",
+			X:   b64(k.X.FillBytes(make([]byte, 32))),
+			Y:   b64(k.Y.FillBytes(make([]byte, 32))),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	jwk.Use = "sig"
+	jwk.Kid = jwk.thumbprint()
+	return jwk, nil
+}
+
+// RFC 7638: SHA-256 over the required members in lexicographic order
+func (jwk *JWK) thumbprint() string {
+	var s string
+	if jwk.Kty == "RSA" {
+		s = `{"e":"` + jwk.E + `","kty":"RSA","n":"` + jwk.N + `"}`
+	} else {
+		s = `{"crv":"` + jwk.Crv + `","kty":"EC","x":"` + jwk.X + `","y":"` + jwk.Y + `"}`
+	}
+	sum := sha256.Sum256([]byte(s))
+	return b64(sum[:])
+}
+
+func (jwk *JWK) PublicKey() (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := unb64(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if pub.N.BitLen() < minRSABits || pub.E < 3 {
+			return nil, fmt.Errorf("JWK %q: invalid RSA public key", jwk.Kid)
+		}
+		return pub, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("JWK %q: unsupported curve %q", jwk.Kid, jwk.Crv)
+		}
+		x, err := unb64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("JWK %q: invalid EC public key", jwk.Kid)
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("JWK %q: unsupported key type %q", jwk.Kid, jwk.Kty)
+	}
+}
+
+////////////
+// KeySet //
+////////////
+
+// HS256 only
+func NewHMACKeySet(secret string) *KeySet { return &KeySet{secret: secret, hs256: true} }
+
+// empty secret - HS256 tokens are not accepted; nil jwks - RS256 and ES256 are not accepted
+// (callers pass the secret along with public keys only when HS256 is explicitly allowed)
+func NewKeySet(secret string, jwks *JWKS) (*KeySet, error) {
+	ks := &KeySet{secret: secret, hs256: secret != "", pub: make(map[string]any, 4)}
+	if jwks == nil {
+		return ks, nil
+	}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Alg != "" && jwk.Alg != AlgRS256 && jwk.Alg != AlgES256 {
+			continue // (external key sets may include keys for other algorithms)
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Kid == "" {
+			return nil, fmt.Errorf("JWK (%s) without key ID", jwk.Kty)
+		}
+		ks.pub[jwk.Kid] = pub
+	}
+	return ks, nil
+}
+
+func (ks *KeySet) Len() int { return len(ks.pub) }
+
+func (ks *KeySet) DecryptToken(tokenStr string) (*Token, error) {
+	claims, err := ks.Claims(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	tk := &Token{}
+	if err := cos.MorphMarshal(claims, tk); err != nil {
+		return nil, ErrInvalidToken
+	}
+	return tk, nil
+}
+
+// Claims verifies the signature and standard time-based claims (exp, iat, nbf) of
+// any JWT signed with one of the keys, e.g., ID token issued by an external OIDC provider
+func (ks *KeySet) Claims(tokenStr string) (jwt.MapClaims, error) {
+	jwtToken, err := jwt.Parse(tokenStr, ks.keyfunc, jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgES256}))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !jwtToken.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (ks *KeySet) keyfunc(t *jwt.Token) (any, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if !ks.hs256 {
+			return nil, fmt.Errorf("signing method %v is not accepted", t.Header["alg"])
+		}
+		return []byte(ks.secret), nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	pub, ok := ks.pub[kid]
+	if !ok {
+		return nil, ErrUnknownKID
+	}
+	// (prevent algorithm confusion)
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("key %q: unexpected signing method %v", kid, t.Header["alg"])
+		}
+	case *ecdsa.PublicKey:
+		if t.Method != jwt.SigningMethodES256 {
+			return nil, fmt.Errorf("key %q: unexpected signing method %v", kid, t.Header["alg"])
+		}
+	}
+	return pub, nil
+}
+
+//
+// private
+//
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func unb64(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
@@ -1,7 +1,7 @@
 // Package tok provides AuthN token (structure and methods)
 // for validation by AIS gateways
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package tok
 
@@ -15,7 +15,6 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/golang-jwt/jwt/v4"
 )
 
@@ -26,6 +25,8 @@ type Token struct {
 	ClusterACLs []*authn.CluACL `json:"clusters"`
 	BucketACLs  []*authn.BckACL `json:"buckets,omitempty"`
 	IsAdmin     bool            `json:"admin"`
+	// (non-admin users only)
+	RateLimit *cmn.RateLimitConf `json:"rate_limit,omitempty"`
 }
 
 var (
@@ -39,24 +40,26 @@ var (
 
 // TODO: cos.Unsafe* and other micro-optimization and refactoring
 
-func AdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func AdminJWT(expires time.Time, userID string, sk *SigningKey) (string, error) {
+	return sk.Sign(jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"admin":    true,
 	})
-	return t.SignedString([]byte(secret))
 }
 
 func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	rateLimit *cmn.RateLimitConf, sk *SigningKey) (string, error) {
+	claims := jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
-	})
-	return t.SignedString([]byte(secret))
+	}
+	if rateLimit != nil && rateLimit.IsSet() {
+		claims["rate_limit"] = rateLimit
+	}
+	return sk.Sign(claims)
 }
 
 // Header format: 'Authorization: Bearer <token>'
@@ -72,25 +75,9 @@ func ExtractToken(hdr http.Header) (string, error) {
 	return s[idx+1:], nil
 }
 
+// HS256 only (see also: KeySet)
 func DecryptToken(tokenStr, secret string) (*Token, error) {
-	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	claims, ok := jwtToken.Claims.(jwt.MapClaims)
-	if !ok || !jwtToken.Valid {
-		return nil, ErrInvalidToken
-	}
-	tk := &Token{}
-	if err := cos.MorphMarshal(claims, tk); err != nil {
-		return nil, ErrInvalidToken
-	}
-	return tk, nil
+	return NewHMACKeySet(secret).DecryptToken(tokenStr)
 }
 
 ///////////
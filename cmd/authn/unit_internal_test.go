@@ -9,6 +9,20 @@ package main
 // NOTE go:build debug (above) =====================================
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -19,6 +33,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/golang-jwt/jwt/v4"
+	jsoniter "github.com/json-iterator/go"
 )
 
 var (
@@ -38,6 +54,9 @@ func init() {
 	if Conf.Server.Expire == 0 {
 		Conf.Server.Expire = cos.Duration(time.Minute * 30) // NOTE: default token expiration time
 	}
+	if err := keys.load(); err != nil {
+		panic(err)
+	}
 }
 
 func createUsers(mgr *mgr, t *testing.T) {
@@ -204,6 +223,237 @@ func TestToken(t *testing.T) {
 	}
 }
 
+func TestSigningKeys(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+		ecKey, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		rsaFQN    = filepath.Join(dir, "rsa.pem")
+		ecFQN     = filepath.Join(dir, "ec.pem")
+		expires   = time.Now().Add(time.Hour)
+	)
+	b, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, os.WriteFile(rsaFQN, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), 0o600))
+	b, err = x509.MarshalECPrivateKey(ecKey)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, os.WriteFile(ecFQN, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}), 0o600))
+
+	secret := Conf.Secret()
+	if secret == "" {
+		s := "test-secret"
+		Conf.SetSecret(&s)
+		secret = s
+	}
+	defer func() {
+		Conf.Server.SigningKeys = nil
+		Conf.Server.AllowHS256 = false
+		tassert.CheckError(t, keys.load())
+	}()
+
+	// sign with RSA
+	Conf.Server.SigningKeys = []string{rsaFQN}
+	tassert.CheckFatal(t, keys.load())
+	tassert.Fatalf(t, keys.signing().Alg() == tok.AlgRS256, "expecting %s, got %s", tok.AlgRS256, keys.signing().Alg())
+	token1, err := tok.AdminJWT(expires, "admin", keys.signing())
+	tassert.CheckFatal(t, err)
+
+	// rotate: sign with EC, keep publishing RSA
+	Conf.Server.SigningKeys = []string{ecFQN, rsaFQN}
+	tassert.CheckFatal(t, keys.load())
+	tassert.Fatalf(t, keys.signing().Alg() == tok.AlgES256, "expecting %s, got %s", tok.AlgES256, keys.signing().Alg())
+	token2, err := tok.JWT(expires, users[0], nil, nil, nil, keys.signing())
+	tassert.CheckFatal(t, err)
+	hsToken, err := tok.AdminJWT(expires, "admin", tok.NewHMACKey("secret"))
+	tassert.CheckFatal(t, err)
+
+	// validate the way AIS gateways do: public keys only (JWKS round-trip)
+	jwks := &tok.JWKS{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal(cos.MustMarshal(keys.jwks), jwks))
+	tassert.Fatalf(t, len(jwks.Keys) == 2, "expecting 2 public keys, got %d", len(jwks.Keys))
+	ks, err := tok.NewKeySet("", jwks)
+	tassert.CheckFatal(t, err)
+	tk, err := ks.DecryptToken(token1)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.IsAdmin, "expecting admin token")
+	tk, err = ks.DecryptToken(token2)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == users[0], "expecting user %q, got %q", users[0], tk.UserID)
+	_, err = ks.DecryptToken(hsToken)
+	tassert.Errorf(t, err != nil, "HS256 token must be rejected without secret")
+
+	// unknown key ID
+	ks, err = tok.NewKeySet("", &tok.JWKS{Keys: jwks.Keys[1:]})
+	tassert.CheckFatal(t, err)
+	_, err = ks.DecryptToken(token2)
+	tassert.Errorf(t, errors.Is(err, tok.ErrUnknownKID), "expecting %v, got %v", tok.ErrUnknownKID, err)
+
+	// with signing keys, HS256 tokens signed with the (still configured) secret are rejected...
+	hsToken, err = tok.AdminJWT(expires, "admin", tok.NewHMACKey(secret))
+	tassert.CheckFatal(t, err)
+	_, err = keys.decrypt(hsToken)
+	tassert.Errorf(t, err != nil, "HS256 token must be rejected when signing with %s", keys.signing().Alg())
+
+	// ...unless explicitly allowed
+	Conf.Server.AllowHS256 = true
+	tassert.CheckFatal(t, keys.load())
+	tk, err = keys.decrypt(hsToken)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.IsAdmin, "expecting admin token")
+	_, err = keys.decrypt(token2)
+	tassert.CheckError(t, err)
+}
+
+// stub OIDC identity provider
+type stubIdP struct {
+	srv      *httptest.Server
+	sk       *tok.SigningKey
+	claims   jwt.MapClaims // ID token claims (iss, exp, nonce added by the stub)
+	approved bool          // device flow: the user has entered the code
+	code     string        // authorization code flow: issued code
+	nonce    string
+	verifier string // expected PKCE code verifier
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tassert.CheckFatal(t, err)
+	b, err := x509.MarshalECPrivateKey(ecKey)
+	tassert.CheckFatal(t, err)
+	idp := &stubIdP{}
+	idp.sk, err = tok.ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}))
+	tassert.CheckFatal(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &oidcMeta{
+			Issuer:    idp.srv.URL,
+			AuthURL:   idp.srv.URL + "/authorize",
+			TokenURL:  idp.srv.URL + "/token",
+			DeviceURL: idp.srv.URL + "/device",
+			JWKSURL:   idp.srv.URL + "/jwks",
+		}, "")
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &tok.JWKS{Keys: []*tok.JWK{idp.sk.JWK()}}, "")
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &oidcDeviceResp{DeviceCode: "device-code", UserCode: "ABCD-EFGH", VerificationURI: idp.srv.URL + "/verify"}, "")
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tassert.CheckFatal(t, r.ParseForm())
+		nonce := ""
+		switch r.Form.Get("grant_type") {
+		case oidcGrantDevice:
+			if !idp.approved {
+				w.WriteHeader(http.StatusBadRequest)
+				writeJSON(w, &oidcTokenResp{Error: "authorization_pending"}, "")
+				return
+			}
+		case oidcGrantCode:
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if r.Form.Get("code") != idp.code || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.verifier {
+				w.WriteHeader(http.StatusBadRequest)
+				writeJSON(w, &oidcTokenResp{Error: "invalid_grant"}, "")
+				return
+			}
+			nonce = idp.nonce
+		}
+		claims := jwt.MapClaims{"iss": idp.srv.URL, "exp": time.Now().Add(time.Minute).Unix()}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+		if nonce != "" {
+			claims["nonce"] = nonce
+		}
+		idToken, err := idp.sk.Sign(claims)
+		tassert.CheckFatal(t, err)
+		writeJSON(w, &oidcTokenResp{IDToken: idToken}, "")
+	})
+	idp.srv = httptest.NewServer(mux)
+	return idp
+}
+
+func TestOIDC(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.srv.Close()
+
+	mgr, err := newMgr(mock.NewDBDriver())
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, mgr.addRole(guestRole))
+	mlBck := cmn.Bck{Name: "ml", Provider: apc.AIS}
+	p, err := newOIDC(&authn.OIDCConf{
+		Issuer:      idp.srv.URL,
+		ClientID:    "ais",
+		RedirectURL: "http://localhost/v1/oidc/callback",
+		Mappings: []*authn.OIDCMapping{
+			{Value: "readers", Roles: []string{GuestRole}},
+			{Value: "admins", Roles: []string{authn.AdminRole}},
+			{Claim: "department", Value: "ml", BucketACLs: []*authn.BckACL{
+				{Bck: cmn.Bck{Name: mlBck.Name, Provider: apc.AIS, Ns: cmn.Ns{UUID: "test-clu-id"}}, Access: apc.AccessRW},
+			}},
+		},
+	}, mgr)
+	tassert.CheckFatal(t, err)
+
+	// device flow
+	idp.claims = jwt.MapClaims{"aud": "ais", "sub": "u-1", "preferred_username": "alice", "groups": []string{"readers"}, "department": "ml"}
+	login, err := p.login(&authn.OIDCLoginMsg{})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, login.UserCode == "ABCD-EFGH" && login.Interval == int(oidcDfltInterval/time.Second), "unexpected %+v", login)
+	_, err = p.token(login.Session)
+	tassert.Fatalf(t, err == errOIDCPending, "expecting pending, got %v", err)
+	_, err = p.token(login.Session)
+	tassert.Fatalf(t, err == errOIDCPending, "expecting pending (polling interval), got %v", err)
+	idp.approved = true
+	p.sessions[login.Session].polled = time.Time{}
+	token, err := p.token(login.Session)
+	tassert.CheckFatal(t, err)
+	tk, err := keys.decrypt(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "alice" && !tk.IsAdmin, "unexpected token %s", tk)
+	tassert.Errorf(t, tk.CheckPermissions("test-clu-id", &cmn.Bck{Name: "any", Provider: apc.AIS}, apc.AceGET) == nil, "expecting read access: %s", tk)
+	tassert.Errorf(t, tk.CheckPermissions("test-clu-id", &mlBck, apc.AcePUT) == nil, "expecting bucket write access: %s", tk)
+	_, err = p.token(login.Session)
+	tassert.Errorf(t, err != nil, "expecting session to be removed")
+
+	// authorization code flow
+	idp.claims = jwt.MapClaims{"aud": []string{"ais", "other"}, "sub": "u-2", "groups": []string{"admins"}}
+	login, err = p.login(&authn.OIDCLoginMsg{Flow: authn.OIDCFlowCode})
+	tassert.CheckFatal(t, err)
+	u, err := url.Parse(login.URL)
+	tassert.CheckFatal(t, err)
+	q := u.Query()
+	tassert.Fatalf(t, u.Path == "/authorize" && q.Get("code_challenge_method") == "S256", "unexpected URL %s", login.URL)
+	idp.code, idp.nonce, idp.verifier = "auth-code", q.Get("nonce"), q.Get("code_challenge")
+	_, err = p.token(login.Session)
+	tassert.Fatalf(t, err == errOIDCPending, "expecting pending, got %v", err)
+	err = p.callback(url.Values{"state": []string{"wrong"}, "code": []string{idp.code}})
+	tassert.Errorf(t, err != nil, "expecting invalid state error")
+	tassert.CheckFatal(t, p.callback(url.Values{"state": []string{q.Get("state")}, "code": []string{idp.code}}))
+	err = p.callback(url.Values{"state": []string{q.Get("state")}, "code": []string{idp.code}})
+	tassert.Errorf(t, err != nil, "expecting replayed callback to fail")
+	err = p.callback(url.Values{"state": []string{""}, "code": []string{idp.code}})
+	tassert.Errorf(t, err != nil, "expecting empty state to fail")
+	token, err = p.token(login.Session)
+	tassert.CheckFatal(t, err)
+	tk, err = keys.decrypt(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "u-2" && tk.IsAdmin, "expecting admin token, got %s", tk)
+
+	// wrong audience; no mapped roles
+	for _, claims := range []jwt.MapClaims{
+		{"aud": "other", "sub": "u-3", "groups": []string{"readers"}},
+		{"aud": "ais", "sub": "u-4", "groups": []string{"unknown"}},
+	} {
+		idp.claims = claims
+		login, err = p.login(&authn.OIDCLoginMsg{})
+		tassert.CheckFatal(t, err)
+		_, err = p.token(login.Session)
+		tassert.Errorf(t, err != nil && err != errOIDCPending, "expecting login to fail (%v)", claims)
+	}
+}
+
 func TestMergeCluACLS(t *testing.T) {
 	tests := []struct {
 		title    string
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
 	"github.com/vbauerster/mpb/v4/decor"
@@ -68,6 +70,13 @@ const archGetUsage = "get a shard and extract its content; get an archived file;
 	indent4 + "\t- ais://abc/trunk-0123.tar 222.tar --archregx=file45 --archmode=wdskey - return 222.tar with all file45.* files --/--\n" +
 	indent4 + "\t- ais://abc/trunk-0123.tar 333.tar --archregx=subdir/ --archmode=prefix - 333.tar with all subdir/* files --/--"
 
+const getBatchUsage = "get multiple objects and/or archived files in one shot, as a single " + archExts + "-formatted output\n" +
+	indent4 + "\t(the format is determined by OUT_FILE extension; default: .tar), e.g.:\n" +
+	indent4 + "\t- 'ais://abc out.tar --list \"o1, o2, o3\"' - get three objects from a given bucket\n" +
+	indent4 + "\t- 'ais://abc out.tar.lz4 --spec list.json --cont-on-err' - get the entries specified in list.json; see '--spec' for details\n" +
+	indent4 + "\t- 'out.zip --spec list.json' - same as above; each entry must specify its bucket\n" +
+	indent4 + "\t- 'ais://abc - --list \"o1, o2\" | tar tv' - write to standard output"
+
 const genShardsUsage = "generate random " + archExts + "-formatted objects (\"shards\"), e.g.:\n" +
 	indent4 + "\t- gen-shards 'ais://bucket1/shard-{001..999}.tar' - write 999 random shards (default sizes) to ais://bucket1\n" +
 	indent4 + "\t- gen-shards \"gs://bucket2/shard-{01..20..2}.tgz\" - 10 random gzipped tarfiles to Cloud bucket\n" +
@@ -102,6 +111,12 @@ var (
 			archSrcDirNameFlag,
 			skipVerCksumFlag,
 		),
+		cmdGetBatch: {
+			listFlag,
+			getBatchSpecFlag,
+			getBatchContOnErrFlag,
+			omitSrcBucketNameFlag,
+		},
 		cmdGenShards: {
 			cleanupFlag,
 			numGenShardWorkersFlag,
@@ -151,6 +166,16 @@ var (
 		BashComplete: bucketCompletions(bcmplop{}),
 	}
 
+	// archive get-batch
+	archGetBatchCmd = cli.Command{
+		Name:         cmdGetBatch,
+		Usage:        getBatchUsage,
+		ArgsUsage:    getBatchArgument,
+		Flags:        archCmdsFlags[cmdGetBatch],
+		Action:       getBatchHandler,
+		BashComplete: bucketCompletions(bcmplop{}),
+	}
+
 	// gen shards
 	genShardsCmd = cli.Command{
 		Name:      cmdGenShards,
@@ -169,6 +194,7 @@ var (
 			archBucketCmd,
 			archPutCmd,
 			archGetCmd,
+			archGetBatchCmd,
 			archLsCmd,
 			genShardsCmd,
 		},
@@ -456,6 +482,94 @@ func listArchHandler(c *cli.Context) error {
 	return listObjects(c, bck, prefix, true /*list arch*/, true /*print empty*/)
 }
 
+//
+// get-batch
+//
+
+func getBatchHandler(c *cli.Context) error {
+	var (
+		bck     cmn.Bck
+		outFile string
+		msg     = &apc.GetBatchMsg{}
+		err     error
+	)
+	switch c.NArg() {
+	case 0:
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	case 1:
+		outFile = c.Args().Get(0)
+	case 2:
+		if bck, err = parseBckURI(c, c.Args().Get(0), false); err != nil {
+			return err
+		}
+		outFile = c.Args().Get(1)
+	default:
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+
+	// entries
+	switch {
+	case flagIsSet(c, listFlag) && flagIsSet(c, getBatchSpecFlag):
+		return incorrectUsageMsg(c, "%s and %s options are mutually exclusive", qflprn(listFlag), qflprn(getBatchSpecFlag))
+	case flagIsSet(c, listFlag):
+		if bck.Name == "" {
+			return missingArgumentsError(c, bucketArgument)
+		}
+		for _, name := range splitCsv(parseStrFlag(c, listFlag)) {
+			msg.In = append(msg.In, apc.GetBatchIn{ObjName: name})
+		}
+	case flagIsSet(c, getBatchSpecFlag):
+		b, err := os.ReadFile(parseStrFlag(c, getBatchSpecFlag))
+		if err != nil {
+			return err
+		}
+		if err := jsoniter.Unmarshal(b, &msg.In); err != nil {
+			return fmt.Errorf("failed to parse %s: %v", qflprn(getBatchSpecFlag), err)
+		}
+	default:
+		return missingArgumentsError(c, qflprn(listFlag)+" or "+qflprn(getBatchSpecFlag))
+	}
+	if len(msg.In) == 0 {
+		return errors.New("empty list of entries")
+	}
+	msg.ContinueOnError = flagIsSet(c, getBatchContOnErrFlag)
+	msg.OnlyObjName = flagIsSet(c, omitSrcBucketNameFlag)
+
+	// output
+	var w io.Writer
+	switch {
+	case outFile == fileStdIO:
+		w = os.Stdout
+	case discardOutput(outFile):
+		w = io.Discard
+	default:
+		if msg.Mime, err = archive.Strict("", outFile); err != nil {
+			msg.Mime = archive.ExtTar
+		}
+		var fh *os.File
+		if fh, err = os.Create(outFile); err != nil {
+			return err
+		}
+		w = fh
+		defer func() {
+			fh.Close()
+			if err != nil {
+				os.Remove(outFile)
+			}
+		}()
+	}
+
+	var n int64
+	n, err = api.GetBatch(apiBP, bck, msg, w)
+	if err != nil {
+		return V(err)
+	}
+	if outFile != fileStdIO {
+		actionDone(c, fmt.Sprintf("GET %d entries (%s) => %s", len(msg.In), cos.ToSizeIEC(n, 2), outFile))
+	}
+	return nil
+}
+
 //
 // generate shards
 //
@@ -40,11 +40,14 @@ const (
 	flagsAuthConfShow    = "conf_show"
 )
 
+// OIDC device flow: default polling interval (RFC 8628, sec. 3.2)
+const oidcDfltInterval = 5 * time.Second
+
 const authnUnreachable = `AuthN unreachable at %s. You may need to update AIS CLI configuration or environment variable %s`
 
 var (
 	authFlags = map[string][]cli.Flag{
-		flagsAuthUserLogin:   {tokenFileFlag, passwordFlag, expireFlag, clusterTokenFlag},
+		flagsAuthUserLogin:   {tokenFileFlag, passwordFlag, expireFlag, clusterTokenFlag, oidcFlag},
 		flagsAuthUserLogout:  {tokenFileFlag},
 		cmdAuthUser:          {passwordFlag},
 		flagsAuthRoleAddSet:  {descRoleFlag, clusterRoleFlag, bucketRoleFlag},
@@ -198,7 +201,7 @@ var (
 			// login, logout
 			{
 				Name:      cmdAuthLogin,
-				Usage:     "log in with existing user ID and password",
+				Usage:     "log in with existing user ID and password or via external OIDC identity provider",
 				Flags:     authFlags[flagsAuthUserLogin],
 				ArgsUsage: userLoginArgument,
 				Action:    wrapAuthN(loginUserHandler),
@@ -354,8 +357,7 @@ func deleteRoleHandler(c *cli.Context) (err error) {
 func loginUserHandler(c *cli.Context) (err error) {
 	var (
 		expireIn *time.Duration
-		name     = cliAuthnUserName(c)
-		password = cliAuthnUserPassword(c, false)
+		token    *authn.TokenMsg
 		cluID    = parseStrFlag(c, clusterTokenFlag)
 	)
 	if flagIsSet(c, expireFlag) {
@@ -366,7 +368,15 @@ func loginUserHandler(c *cli.Context) (err error) {
 			return err
 		}
 	}
-	token, err := authn.LoginUser(authParams, name, password, expireIn)
+	if flagIsSet(c, oidcFlag) {
+		token, err = loginOIDC(c, expireIn)
+	} else {
+		var (
+			name     = cliAuthnUserName(c)
+			password = cliAuthnUserPassword(c, false)
+		)
+		token, err = authn.LoginUser(authParams, name, password, expireIn)
+	}
 	if err != nil {
 		return err
 	}
@@ -381,6 +391,40 @@ func loginUserHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+// login via external OIDC identity provider: display the URL (and user code)
+// and wait for the user to authenticate
+func loginOIDC(c *cli.Context, expireIn *time.Duration) (*authn.TokenMsg, error) {
+	flow := parseStrFlag(c, oidcFlag)
+	if flow != authn.OIDCFlowDevice && flow != authn.OIDCFlowCode {
+		return nil, incorrectUsageMsg(c, "invalid %s value %q (expecting %q or %q)",
+			qflprn(oidcFlag), flow, authn.OIDCFlowDevice, authn.OIDCFlowCode)
+	}
+	login, err := authn.LoginOIDC(authParams, &authn.OIDCLoginMsg{Flow: flow, ExpiresIn: expireIn})
+	if err != nil {
+		return nil, err
+	}
+	if login.UserCode != "" {
+		fmt.Fprintf(c.App.Writer, "To log in, open %s and enter the code: %s\n", login.URL, fcyan(login.UserCode))
+	} else {
+		fmt.Fprintf(c.App.Writer, "To log in, open the following URL in a browser:\n\n%s\n\n", login.URL)
+	}
+	var (
+		interval = time.Duration(login.Interval) * time.Second
+		deadline = time.Now().Add(time.Duration(login.ExpiresIn) * time.Second)
+	)
+	if interval <= 0 {
+		interval = oidcDfltInterval
+	}
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+		token, err := authn.PollOIDCToken(authParams, login.Session)
+		if err != nil || token != nil {
+			return token, err
+		}
+	}
+	return nil, errors.New("timed out waiting for OIDC login")
+}
+
 func logoutUserHandler(c *cli.Context) (err error) {
 	tokenFilePath, err := getTokenFilePath(c)
 	if err != nil {
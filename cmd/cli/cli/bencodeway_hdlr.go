@@ -19,7 +19,8 @@ const mirrorUsage = "configure (or unconfigure) bucket as n-way mirror, and run
 	indent1 + "(see also: 'ais start ec-encode')"
 
 const bencodeUsage = "erasure code entire bucket, e.g.:\n" +
-	indent1 + "\t- 'ais start ec-encode ais://m -d 8 -p 2'\t- erasure-code ais://m for (D=8, P=2).\n" +
+	indent1 + "\t- 'ais start ec-encode ais://m -d 8 -p 2'\t- erasure-code ais://m for (D=8, P=2);\n" +
+	indent1 + "\t- 'ais start ec-encode ais://m -d 8 -p 4'\t- re-encode already erasure-coded ais://m in place for (D=8, P=4).\n" +
 	indent1 + "(see also: 'ais start mirror')"
 
 var (
@@ -127,11 +128,10 @@ func ecEncodeHandler(c *cli.Context) error {
 
 	if bprops.EC.Enabled {
 		if bprops.EC.DataSlices != numd || bprops.EC.ParitySlices != nump {
-			// not supported yet:
-			warn := fmt.Sprintf("%s is already erasure-coded, cannot change existing (D=%d, P=%d) configuration to (D=%d, P=%d)",
+			warn := fmt.Sprintf("%s is already erasure-coded for (D=%d, P=%d) - proceeding to re-encode all objects for (D=%d, P=%d)",
 				bck.Cname(""), bprops.EC.DataSlices, bprops.EC.ParitySlices, numd, nump)
 			actionWarn(c, warn)
-			return nil
+			return ecEncode(c, bck, bprops, numd, nump, true /*warned*/)
 		}
 		var warn string
 		if bprops.EC.ObjSizeLimit == cmn.ObjSizeToAlwaysReplicate {
@@ -43,6 +43,7 @@ const (
 // advanced command and subcommands
 const (
 	cmdGenShards     = "gen-shards"
+	cmdGetBatch      = "get-batch"
 	cmdPreload       = "preload"
 	cmdRmSmap        = "remove-from-smap"
 	cmdRandNode      = "random-node"
@@ -175,10 +176,11 @@ const (
 	cmdK8sCluster = commandCluster
 
 	// ETL subcommands
-	cmdInit    = "init"
-	cmdSpec    = "spec"
-	cmdCode    = "code"
-	cmdDetails = "details"
+	cmdInit     = "init"
+	cmdSpec     = "spec"
+	cmdCode     = "code"
+	cmdPipeline = "pipeline"
+	cmdDetails  = "details"
 
 	// config subcommands
 	cmdCLI        = "cli"
@@ -226,7 +228,7 @@ const (
 )
 
 const (
-	archFormats = ".tar, .tgz or .tar.gz, .zip, .tar.lz4" // namely, archive.FileExtensions
+	archFormats = ".tar, .tgz or .tar.gz, .zip, .tar.lz4, .tar.zst" // namely, archive.FileExtensions
 	archExts    = "(" + archFormats + ")"
 )
 
@@ -246,6 +248,7 @@ const (
 	// ETL
 	etlNameArgument     = "ETL_NAME"
 	etlNameListArgument = "ETL_NAME [ETL_NAME ...]"
+	etlStagesArgument   = "STAGE_ETL_NAME STAGE_ETL_NAME [STAGE_ETL_NAME ...]"
 
 	// key/value
 	keyValuePairsArgument = "KEY=VALUE [KEY=VALUE...]"
@@ -282,6 +285,7 @@ const (
 	optionalShardArgument = "BUCKET[/SHARD_NAME]"
 	putApndArchArgument   = "[-|FILE|DIRECTORY[/PATTERN]] " + shardArgument
 	getShardArgument      = optionalShardArgument + " [OUT_FILE|OUT_DIR|-]"
+	getBatchArgument      = "[BUCKET] OUT_FILE|-"
 
 	concatObjectArgument = "FILE|DIRECTORY[/PATTERN] [ FILE|DIRECTORY[/PATTERN] ...] " + objectArgument
 
@@ -606,7 +610,8 @@ var (
 	logSevFlag = cli.StringFlag{
 		Name: "severity",
 		Usage: "log severity is either 'i' or 'info' (default, can be omitted), or 'error', whereby error logs contain\n" +
-			indent4 + "\tonly errors and warnings, e.g.: '--severity info', '--severity error', '--severity e'",
+			indent4 + "\tonly errors and warnings, e.g.: '--severity info', '--severity error', '--severity e';\n" +
+			indent4 + "\talso, 'audit' to get the audit log (see docs/audit.md)",
 	}
 	logFlushFlag = DurationFlag{
 		Name:  "log-flush",
@@ -969,6 +974,22 @@ var (
 		Name:  "cont-on-err",
 		Usage: "keep running archiving xaction (job) in presence of errors in a any given multi-object transaction",
 	}
+
+	// 'ais archive get-batch'
+	getBatchSpecFlag = cli.StringFlag{
+		Name: "spec,f",
+		Usage: "path to JSON-formatted list of entries, e.g.:\n" +
+			indent4 + "\t[{\"objname\": \"o1\"}, {\"objname\": \"shard.tar\", \"archpath\": \"a/b.jpeg\"}, {\"objname\": \"o2\", \"bucket\": \"abc\", \"provider\": \"s3\"}]",
+	}
+	getBatchContOnErrFlag = cli.BoolFlag{
+		Name: continueOnErrorFlag.Name,
+		Usage: "do not terminate the output upon the first missing or failed entry; instead, add the entry named\n" +
+			indent4 + "\t" + apc.GetBatchMissingDir + "/NAME (missing) or " + apc.GetBatchErrDir + "/NAME (failed, with error message as content)",
+	}
+	omitSrcBucketNameFlag = cli.BoolFlag{
+		Name:  "omit-src-bck",
+		Usage: "do not prefix the names of the output entries with their respective source bucket names",
+	}
 	// end archive
 
 	// AuthN
@@ -980,6 +1001,12 @@ var (
 			indent4 + "\tvalid time units: " + timeUnits,
 		Value: 24 * time.Hour,
 	}
+	oidcFlag = cli.StringFlag{
+		Name: "oidc",
+		Usage: "log in via external OIDC identity provider configured in AuthN, one of:\n" +
+			indent4 + "\t--oidc=device\t- enter the displayed code at the displayed URL (on any device);\n" +
+			indent4 + "\t--oidc=code\t- open the displayed URL in a browser",
+	}
 
 	// Copy Bucket
 	copyDryRunFlag = cli.BoolFlag{
@@ -1036,6 +1063,13 @@ var (
 		Value: "transform", // NOTE: default name of the transform() function
 		Usage: "receives and _transforms_ the payload",
 	}
+	etlPlatformFlag = cli.StringFlag{
+		Name: "platform",
+		Usage: "where and how to run the transformer:\n" +
+			indent4 + "\t - 'k8s' - K8s pod next to each target (default, can be omitted)\n" +
+			indent4 + "\t - 'local' - local process spawned by each target (does not require Kubernetes; requires 'Allow-Local-ETL' feature flag);\n" +
+			indent4 + "\t   with '--comm-type io' the process runs once per object",
+	}
 	argTypeFlag = cli.StringFlag{
 		Name: "arg-type",
 		Usage: "Specifies _how_ an object to transform gets passed from aistore to ETL container:\n" +
@@ -35,6 +35,7 @@ var (
 			funcTransformFlag,
 			argTypeFlag,
 			chunkSizeFlag,
+			etlPlatformFlag,
 			waitPodReadyTimeoutFlag,
 			etlNameFlag,
 		},
@@ -42,9 +43,13 @@ var (
 			fromFileFlag,
 			commTypeFlag,
 			argTypeFlag,
+			etlPlatformFlag,
 			waitPodReadyTimeoutFlag,
 			etlNameFlag,
 		},
+		cmdPipeline: {
+			etlNameFlag,
+		},
 		cmdStop: {
 			allRunningJobsFlag,
 		},
@@ -97,7 +102,7 @@ var (
 	}
 	initCmdETL = cli.Command{
 		Name:  cmdInit,
-		Usage: "start ETL job: 'spec' job (requires pod yaml specification) or 'code' job (with transforming function or script in a local file)",
+		Usage: "start ETL job: 'spec' job (requires pod yaml specification), 'code' job (with transforming function or script in a local file), or 'pipeline' (of existing ETLs)",
 		Subcommands: []cli.Command{
 			{
 				Name:   cmdSpec,
@@ -111,18 +116,27 @@ var (
 				Flags:  etlSubFlags[cmdCode],
 				Action: etlInitCodeHandler,
 			},
+			{
+				Name:         cmdPipeline,
+				Usage:        "start named ETL pipeline: chain existing ETLs, in the order given (e.g., decode, resize, normalize)",
+				ArgsUsage:    etlStagesArgument,
+				Flags:        etlSubFlags[cmdPipeline],
+				Action:       etlInitPipelineHandler,
+				BashComplete: etlIDCompletions,
+			},
 		},
 	}
 	objCmdETL = cli.Command{
 		Name:         cmdObject,
-		Usage:        "transform object",
+		Usage:        "transform object (ETL_NAME can be a comma-separated list of ETLs to run as a pipeline, e.g. 'a,b,c')",
 		ArgsUsage:    etlNameArgument + " " + objectArgument + " OUTPUT",
 		Action:       etlObjectHandler,
 		BashComplete: etlIDCompletions,
 	}
 	bckCmdETL = cli.Command{
-		Name:         cmdBucket,
-		Usage:        "transform entire bucket or selected objects (to select, use '--list', '--template', or '--prefix')",
+		Name: cmdBucket,
+		Usage: "transform entire bucket or selected objects (to select, use '--list', '--template', or '--prefix');\n" +
+			indent1 + "ETL_NAME can be a comma-separated list of ETLs to run as a pipeline, e.g. 'a,b,c'",
 		ArgsUsage:    etlNameArgument + " " + bucketObjectSrcArgument + " " + bucketDstArgument,
 		Action:       etlBucketHandler,
 		Flags:        etlSubFlags[cmdBucket],
@@ -207,6 +221,7 @@ func etlInitSpecHandler(c *cli.Context) (err error) {
 		msg.IDX = parseStrFlag(c, etlNameFlag)
 		msg.CommTypeX = parseStrFlag(c, commTypeFlag)
 		msg.ArgTypeX = parseStrFlag(c, argTypeFlag)
+		msg.PlatformX = parseStrFlag(c, etlPlatformFlag)
 		msg.Spec = spec
 	}
 	if !strings.HasSuffix(msg.CommTypeX, etl.CommTypeSeparator) {
@@ -269,6 +284,7 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 		msg.CommTypeX += etl.CommTypeSeparator
 	}
 	msg.ArgTypeX = parseStrFlag(c, argTypeFlag)
+	msg.PlatformX = parseStrFlag(c, etlPlatformFlag)
 
 	if flagIsSet(c, chunkSizeFlag) {
 		msg.ChunkSize, err = parseSizeFlag(c, chunkSizeFlag)
@@ -299,6 +315,29 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func etlInitPipelineHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	msg := &etl.InitPipelineMsg{}
+	{
+		msg.IDX = parseStrFlag(c, etlNameFlag)
+		msg.Stages = c.Args()
+	}
+	if err = msg.Validate(); err != nil {
+		return err
+	}
+	if err = etlAlreadyExists(msg.Name()); err != nil {
+		return
+	}
+	xid, err := api.ETLInit(apiBP, msg)
+	if err != nil {
+		return V(err)
+	}
+	fmt.Fprintf(c.App.Writer, "ETL[%s]: pipeline %s, job %q\n", msg.Name(), strings.Join(msg.Stages, " -> "), xid)
+	return nil
+}
+
 func etlListHandler(c *cli.Context) (err error) {
 	_, err = etlList(c, false)
 	return
@@ -346,8 +385,13 @@ func etlPrintDetails(c *cli.Context, id string) error {
 	}
 
 	fmt.Fprintln(c.App.Writer, fblue("NAME: "), msg.Name())
+	if initMsg, ok := msg.(*etl.InitPipelineMsg); ok {
+		fmt.Fprintln(c.App.Writer, fblue("PIPELINE: "), strings.Join(initMsg.Stages, " -> "))
+		return nil
+	}
 	fmt.Fprintln(c.App.Writer, fblue("COMMUNICATION TYPE: "), msg.CommType())
 	fmt.Fprintln(c.App.Writer, fblue("ARGUMENT TYPE: "), msg.ArgType())
+	fmt.Fprintln(c.App.Writer, fblue("PLATFORM: "), msg.Platform())
 
 	if initMsg, ok := msg.(*etl.InitCodeMsg); ok {
 		fmt.Fprintln(c.App.Writer, fblue("RUNTIME: "), initMsg.Runtime)
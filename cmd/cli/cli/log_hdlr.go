@@ -342,9 +342,11 @@ func parseLogSev(c *cli.Context) (sev string, err error) {
 			sev = apc.LogWarn
 		case apc.LogErr[0]:
 			sev = apc.LogErr
+		case apc.LogAudit[0]:
+			sev = apc.LogAudit
 		default:
-			err = fmt.Errorf("invalid log severity, expecting empty string or one of: %s, %s, %s",
-				apc.LogInfo, apc.LogWarn, apc.LogErr)
+			err = fmt.Errorf("invalid log severity, expecting empty string or one of: %s, %s, %s, %s",
+				apc.LogInfo, apc.LogWarn, apc.LogErr, apc.LogAudit)
 		}
 	}
 	return
@@ -88,7 +88,7 @@ var (
 	}
 	showLatency = cli.Command{
 		Name:         cmdShowLatency,
-		Usage:        "show GET, PUT, and APPEND latencies and average sizes",
+		Usage:        "show GET, PUT, and APPEND latencies (average and p50, p95, p99, p99.9 percentiles) and average sizes",
 		ArgsUsage:    optionalTargetIDArgument,
 		Flags:        showPerfFlags,
 		Action:       showLatencyHandler,
@@ -274,6 +274,12 @@ func showLatencyHandler(c *cli.Context) error {
 		}
 		selected[name] = kind
 		selected[ncounter] = stats.KindCounter
+		if kind == stats.KindLatency {
+			// percentiles computed by targets (e.g. "get.ns.p99")
+			for _, sfx := range stats.PctlSuffixes {
+				selected[name+sfx] = kind
+			}
+		}
 	}
 
 	// `true` to show (and put request latency numbers in perspective)
@@ -316,6 +322,18 @@ func _latency(c *cli.Context, metrics cos.StrKVs, mapBegin, mapEnd teb.StstMap,
 			v.Value = 0
 			begin.Tracker[name] = v
 		}
+		// percentiles: not cumulative - show the most recent ones
+		// (as in: computed by the target over the last "periodic.stats_time" interval)
+		for name := range metrics {
+			if !stats.IsPctlMetric(name) {
+				continue
+			}
+			if v, ok := end.Tracker[name]; ok {
+				begin.Tracker[name] = v
+			} else {
+				delete(begin.Tracker, name)
+			}
+		}
 	}
 	idle = num == 0
 	return
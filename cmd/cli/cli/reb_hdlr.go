@@ -23,7 +23,7 @@ import (
 )
 
 const (
-	showRebHdr = "REB ID\t NODE\t OBJECTS RECV\t SIZE RECV\t OBJECTS SENT\t SIZE SENT\t START\t END\t STATE"
+	showRebHdr = "REB ID\t NODE\t OBJECTS RECV\t SIZE RECV\t OBJECTS SENT\t SIZE SENT\t START\t END\t PROGRESS\t STATE"
 )
 
 type targetRebSnap struct {
@@ -137,7 +137,7 @@ func showRebalanceHandler(c *cli.Context) error {
 			for _, sts := range allSnaps {
 				if flagIsSet(c, allJobsFlag) {
 					if prevID != "" && sts.snap.ID != prevID {
-						fmt.Fprintln(tw, strings.Repeat("\t ", 9 /*colCount*/))
+						fmt.Fprintln(tw, strings.Repeat("\t ", 10 /*colCount*/))
 						numMigratedObjs, sizeMigratedBytes = 0, 0
 					}
 					displayRebStats(tw, sts, units, datedTime)
@@ -197,10 +197,28 @@ func displayRebStats(tw *tabwriter.Writer, st *targetRebSnap, units string, date
 		endTime = teb.FmtTime(st.snap.EndTime)
 	}
 	fmt.Fprintf(tw,
-		"%s\t %s\t %d\t %s\t %d\t %s\t %s\t %s\t %s\n",
+		"%s\t %s\t %d\t %s\t %d\t %s\t %s\t %s\t %s\t %s\n",
 		st.snap.ID, st.tid,
 		st.snap.Stats.InObjs, teb.FmtSize(st.snap.Stats.InBytes, units, 2),
 		st.snap.Stats.OutObjs, teb.FmtSize(st.snap.Stats.OutBytes, units, 2),
-		startTime, endTime, teb.FmtXactStatus(st.snap),
+		startTime, endTime, fmtRebProgress(st.snap), teb.FmtXactStatus(st.snap),
 	)
 }
+
+// percent complete and ETA, e.g. "45% (ETA 2m10s)", as reported by the target
+// (computed from the number of walked vs. total objects)
+func fmtRebProgress(snap *core.Snap) string {
+	ext, ok := snap.Ext.(map[string]any)
+	if !ok {
+		return teb.NotSetVal
+	}
+	pct, ok := ext["reb.pct"].(float64)
+	if !ok {
+		return teb.NotSetVal
+	}
+	s := fmt.Sprintf("%d%%", int(pct))
+	if eta, ok := ext["reb.eta"].(string); ok && eta != "" && eta != "0s" {
+		s += " (ETA " + eta + ")"
+	}
+	return s
+}
@@ -312,6 +312,14 @@ func _metricToPrintedColName(mname string, cols []*header, metrics, n2n cos.StrK
 		printedName = strings.ToUpper(parts[0])
 	}
 
+	// latency percentile, e.g. "get.ns.p99" => "GET(p99)"
+	if stats.IsPctlMetric(mname) {
+		for j := 1; j < len(parts)-2; j++ {
+			printedName += "-" + strings.ToUpper(parts[j])
+		}
+		return printedName + "(" + parts[len(parts)-1] + ")"
+	}
+
 	// middle name
 	l := len(parts) - 1
 	if parts[l] == "total" { // latency; see related: `stats.LatencyToCounter`
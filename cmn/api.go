@@ -49,13 +49,19 @@ type (
 		Renamed     string          `list:"omit"`                           // non-empty if the bucket has been renamed
 		Cksum       CksumConf       `json:"checksum"`                       // the bucket's checksum
 		EC          ECConf          `json:"ec"`                             // erasure coding
-		LRU         LRUConf         `json:"lru"`                            // LRU (watermarks and enabled/disabled)
+		LRU         LRUConf         `json:"lru"`                            // LRU (enabled/disabled, eviction policy, priority)
 		Mirror      MirrorConf      `json:"mirror"`                         // mirroring
 		Access      apc.AccessAttrs `json:"access,string"`                  // access permissions
 		Features    feat.Flags      `json:"features,string"`                // assorted features from feat.Bucket
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit")
+		Lifecycle   LifecycleConf   `json:"lifecycle" list:"omit"`          // expiration rules (see lifecycle.go)
+		CORS        CORSConf        `json:"cors" list:"omit"`               // cross-origin access rules (see cors.go)
+		ObjLock     ObjLockConf     `json:"object_lock"`                    // WORM retention and legal hold (see objlock.go)
+		Quota       QuotaConf       `json:"quota"`                          // hard and soft limits on size and number of objects (see quota.go)
+		RateLimit   RateLimitConf   `json:"rate_limit"`                     // requests and bytes per second (see ratelimit.go)
+		Audit       BckAuditConf    `json:"audit"`                          // audit log: inherit (cluster config) | enabled | disabled
 	}
 
 	ExtraProps struct {
@@ -126,6 +132,12 @@ type (
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
+		Lifecycle   *LifecycleConfToSet   `json:"lifecycle,omitempty" list:"omit"`
+		CORS        *CORSConfToSet        `json:"cors,omitempty" list:"omit"`
+		ObjLock     *ObjLockConfToSet     `json:"object_lock,omitempty"`
+		Quota       *QuotaConfToSet       `json:"quota,omitempty"`
+		RateLimit   *RateLimitConfToSet   `json:"rate_limit,omitempty"`
+		Audit       *BckAuditConfToSet    `json:"audit,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -218,7 +230,7 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Lifecycle, &bp.CORS, &bp.ObjLock, &bp.Quota, &bp.RateLimit, &bp.Audit} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
@@ -237,6 +249,17 @@ func (bp *Bprops) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		nlog.Warningln("n-way mirroring and EC are both enabled at the same time on the same bucket")
 	}
+	if bp.Versioning.KeepPrior != 0 {
+		if err := bp.Versioning.Validate(); err != nil {
+			return err
+		}
+		if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+			return errors.New("versioning.keep_prior is supported only for ais buckets (without remote backend)")
+		}
+	}
+	if bp.ObjLock.Enabled && bp.Provider != apc.AIS {
+		return errors.New("object lock is supported only for ais buckets")
+	}
 
 	// not inheriting cluster-scope features
 	names := bp.Features.Names()
@@ -357,7 +380,7 @@ type (
 	// ArchiveBckMsg contains parameters to archive mutiple objects from the specified (source) bucket.
 	// Destination bucket may the same as the source or a different one.
 	// --------------------  NOTE on terminology:   ---------------------
-	// "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4) formatted object often also called "shard"
+	// "archive" is any (.tar, .tgz/.tar.gz, .zip, .tar.lz4, .tar.zst) formatted object often also called "shard"
 	//
 	// See also: apc.PutApndArchArgs
 	ArchiveBckMsg struct {
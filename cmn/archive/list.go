@@ -51,6 +51,8 @@ func List(fqn string) ([]*Entry, error) {
 		}
 	case ExtTarLz4:
 		lst, err = lsLz4(fh)
+	case ExtTarZst:
+		lst, err = lsZstd(fh)
 	default:
 		debug.Assert(false, mime)
 	}
@@ -113,3 +115,13 @@ func lsLz4(reader io.Reader) ([]*Entry, error) {
 	lzr := lz4.NewReader(reader)
 	return lsTar(lzr)
 }
+
+func lsZstd(reader io.Reader) ([]*Entry, error) {
+	zdr, err := newZstdDecoder(reader)
+	if err != nil {
+		return nil, err
+	}
+	lst, err := lsTar(zdr)
+	zdr.Close()
+	return lst, err
+}
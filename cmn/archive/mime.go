@@ -29,6 +29,7 @@ const (
 	ExtTarGz  = ".tar.gz"
 	ExtZip    = ".zip"
 	ExtTarLz4 = ".tar.lz4"
+	ExtTarZst = ".tar.zst"
 )
 
 const (
@@ -47,7 +48,7 @@ type detect struct {
 	offset int
 }
 
-var FileExtensions = [...]string{ExtTar, ExtTgz, ExtTarGz, ExtZip, ExtTarLz4}
+var FileExtensions = [...]string{ExtTar, ExtTgz, ExtTarGz, ExtZip, ExtTarLz4, ExtTarZst}
 
 // standard file signatures
 var (
@@ -55,8 +56,9 @@ var (
 	magicGzip = detect{sig: []byte{0x1f, 0x8b}, mime: ExtTarGz}
 	magicZip  = detect{sig: []byte{0x50, 0x4b}, mime: ExtZip}
 	magicLz4  = detect{sig: []byte{0x04, 0x22, 0x4d, 0x18}, mime: ExtTarLz4}
+	magicZstd = detect{sig: []byte{0x28, 0xb5, 0x2f, 0xfd}, mime: ExtTarZst}
 
-	allMagics = []detect{magicTar, magicGzip, magicZip, magicLz4} // NOTE: must contain all
+	allMagics = []detect{magicTar, magicGzip, magicZip, magicLz4, magicZstd} // NOTE: must contain all
 )
 
 // motivation: prevent from creating archives with non-standard extensions
@@ -91,6 +93,8 @@ func normalize(mime string) (string, error) {
 		return ExtTarGz, nil
 	case strings.Contains(mime, ExtTarLz4[1:]): // ditto
 		return ExtTarLz4, nil
+	case strings.Contains(mime, ExtTarZst[1:]), strings.Contains(mime, "zstd"): // ditto; also "application/zstd"
+		return ExtTarZst, nil
 	default:
 		for _, ext := range FileExtensions {
 			if strings.Contains(mime, ext[1:]) {
@@ -184,6 +188,10 @@ func _detect(file cos.LomReader, archname, mime string, buf []byte) (string, int
 		if l := magicLz4.offset + len(magicLz4.sig) + 4; n < l {
 			return "", n, NewErrUnknownFileExt(archname, fmt.Sprintf(fmtErrTooShort, ExtTarGz, l))
 		}
+	case ExtTarZst:
+		if l := magicZstd.offset + len(magicZstd.sig) + 4; n < l {
+			return "", n, NewErrUnknownFileExt(archname, fmt.Sprintf(fmtErrTooShort, ExtTarZst, l))
+		}
 	}
 	for _, magic := range allMagics {
 		if n > magic.offset && bytes.HasPrefix(buf[magic.offset:], magic.sig) {
@@ -17,6 +17,7 @@ import (
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -93,6 +94,10 @@ type (
 		tr  tarReader
 		lzr *lz4.Reader
 	}
+	zstdReader struct {
+		tr  tarReader
+		zdr *zstd.Decoder
+	}
 )
 
 // interface guard
@@ -101,6 +106,7 @@ var (
 	_ Reader = (*tgzReader)(nil)
 	_ Reader = (*zipReader)(nil)
 	_ Reader = (*lz4Reader)(nil)
+	_ Reader = (*zstdReader)(nil)
 )
 
 func NewReader(mime string, fh io.Reader, size ...int64) (ar Reader, err error) {
@@ -114,6 +120,8 @@ func NewReader(mime string, fh io.Reader, size ...int64) (ar Reader, err error)
 		ar = &zipReader{size: size[0]}
 	case ExtTarLz4:
 		ar = &lz4Reader{}
+	case ExtTarZst:
+		ar = &zstdReader{}
 	default:
 		debug.Assert(false, mime)
 	}
@@ -322,6 +330,39 @@ func (lzr *lz4Reader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
 	return lzr.tr.ReadOne(filename)
 }
 
+// zstdReader
+
+// NOTE: synchronous (single-goroutine) decoding - shards are typically read concurrently, many at a time
+func newZstdDecoder(r io.Reader) (*zstd.Decoder, error) {
+	return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+}
+
+func (zdr *zstdReader) init(fh io.Reader) (err error) {
+	zdr.zdr, err = newZstdDecoder(fh)
+	if err != nil {
+		return
+	}
+	zdr.tr.baseR.init(zdr.zdr)
+	zdr.tr.tr = tar.NewReader(zdr.zdr)
+	return
+}
+
+func (zdr *zstdReader) ReadUntil(rcb ArchRCB, regex, mmode string) error {
+	err := zdr.tr.ReadUntil(rcb, regex, mmode)
+	zdr.zdr.Close()
+	return err
+}
+
+func (zdr *zstdReader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
+	reader, err := zdr.tr.ReadOne(filename)
+	if err != nil || reader == nil {
+		zdr.zdr.Close()
+		return reader, err
+	}
+	// same as tgzReader (above): the caller must close the returned reader
+	return &cslClose{gzr: zdr.zdr.IOReadCloser(), R: reader, N: reader.Size()}, nil
+}
+
 //
 // more limited readers
 //
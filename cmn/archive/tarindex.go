@@ -0,0 +1,146 @@
+// Package archive: write, read, copy, append, list primitives
+// across all supported formats
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// TAR index: names, offsets, and sizes of all archived files in a given (uncompressed) .tar,
+// to read any one of them directly, without scanning the archive
+// - the index is built with a single pass over tar headers (see BuildTarIndex)
+//   and is only valid for the exact archive it was built from (see Valid);
+// - persistence and invalidation are the caller's responsibility (see core.LOM.TarIndex).
+
+type (
+	TarIndex struct {
+		Entries []TarIdxEntry `json:"e"`        // in the archive order
+		Size    int64         `json:"s,string"` // archive size at the time of indexing
+		Mtime   int64         `json:"m,string"` // archive mtime --/--
+		byName  map[string]int
+	}
+	TarIdxEntry struct {
+		Name string `json:"n"`
+		Off  int64  `json:"o,string"` // offset of the file's content
+		Size int64  `json:"s,string"`
+		Dir  bool   `json:"d,omitempty"`
+	}
+)
+
+var ErrTarIdxSparse = errors.New("tar index: sparse files are not supported")
+
+func BuildTarIndex(fh *os.File) (*TarIndex, error) {
+	finfo, err := fh.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var (
+		idx = &TarIndex{Size: finfo.Size(), Mtime: finfo.ModTime().UnixNano()}
+		tr  = tar.NewReader(fh)
+	)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return idx, nil
+			}
+			return nil, err
+		}
+		if _isSparse(hdr) {
+			return nil, ErrTarIdxSparse
+		}
+		// (tar reader does not read ahead: current position is where the content starts)
+		off, err := fh.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		idx.Entries = append(idx.Entries, TarIdxEntry{
+			Name: hdr.Name,
+			Off:  off,
+			Size: hdr.Size,
+			Dir:  hdr.FileInfo().IsDir(),
+		})
+	}
+}
+
+// whether the index corresponds to the archive (that may have been since overwritten or appended)
+func (idx *TarIndex) Valid(fh *os.File) bool {
+	finfo, err := fh.Stat()
+	return err == nil && finfo.Size() == idx.Size && finfo.ModTime().UnixNano() == idx.Mtime
+}
+
+// builds name lookup; must be called prior to sharing the index between goroutines
+func (idx *TarIndex) Init() {
+	idx.byName = make(map[string]int, len(idx.Entries))
+	for i := range idx.Entries {
+		name := idx.Entries[i].Name
+		if name != "" && name[0] == '/' {
+			name = name[1:]
+		}
+		if _, ok := idx.byName[name]; !ok { // (the first one, same as sequential read)
+			idx.byName[name] = i
+		}
+	}
+}
+
+// returns nil when not found (compare with tarReader.ReadOne)
+func (idx *TarIndex) Find(filename string) *TarIdxEntry {
+	if idx.byName == nil {
+		idx.Init()
+	}
+	if filename != "" && filename[0] == '/' {
+		filename = filename[1:]
+	}
+	i, ok := idx.byName[filename]
+	if !ok {
+		return nil
+	}
+	return &idx.Entries[i]
+}
+
+func (idx *TarIndex) ReadOne(fh io.ReaderAt, filename string) cos.ReadCloseSizer {
+	e := idx.Find(filename)
+	if e == nil {
+		return nil
+	}
+	return &cslLimited{LimitedReader: io.LimitedReader{R: io.NewSectionReader(fh, e.Off, e.Size), N: e.Size}}
+}
+
+// same as List (and lsTar) but without reading the archive
+func (idx *TarIndex) List() []*Entry {
+	lst := make([]*Entry, 0, len(idx.Entries))
+	for i := range idx.Entries {
+		e := &idx.Entries[i]
+		if !e.Dir {
+			lst = append(lst, &Entry{Name: e.Name, Size: e.Size})
+		}
+	}
+	sort.Slice(lst, func(i, j int) bool { return lst[i].Name < lst[j].Name })
+	return lst
+}
+
+// sparse files' content is not contiguous
+func _isSparse(hdr *tar.Header) bool {
+	if hdr.Typeflag == tar.TypeGNUSparse {
+		return true
+	}
+	for k := range hdr.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -67,6 +68,10 @@ type (
 		tw  tarWriter
 		lzw *lz4.Writer
 	}
+	zstdWriter struct {
+		tw  tarWriter
+		zdw *zstd.Encoder
+	}
 )
 
 // interface guard
@@ -75,6 +80,7 @@ var (
 	_ Writer = (*tgzWriter)(nil)
 	_ Writer = (*zipWriter)(nil)
 	_ Writer = (*lz4Writer)(nil)
+	_ Writer = (*zstdWriter)(nil)
 )
 
 // calls init() -> open(),alloc()
@@ -88,6 +94,8 @@ func NewWriter(mime string, w io.Writer, cksum *cos.CksumHashSize, opts *Opts) (
 		aw = &zipWriter{}
 	case ExtTarLz4:
 		aw = &lz4Writer{}
+	case ExtTarZst:
+		aw = &zstdWriter{}
 	default:
 		debug.Assert(false, mime)
 	}
@@ -263,3 +271,33 @@ func (lzw *lz4Writer) Copy(src io.Reader, _ ...int64) error {
 	lzr := lz4.NewReader(src)
 	return cpTar(lzr, lzw.tw.tw, lzw.tw.buf)
 }
+
+// zstdWriter
+
+func (zdw *zstdWriter) init(w io.Writer, cksum *cos.CksumHashSize, opts *Opts) {
+	var err error
+	zdw.tw.baseW.init(w, cksum, opts)
+	zdw.zdw, err = zstd.NewWriter(zdw.tw.wmul, zstd.WithEncoderConcurrency(1))
+	debug.AssertNoErr(err) // (can only fail on invalid options)
+
+	zdw.tw.tw = tar.NewWriter(zdw.zdw)
+}
+
+func (zdw *zstdWriter) Fini() {
+	zdw.tw.Fini()
+	zdw.zdw.Close()
+}
+
+func (zdw *zstdWriter) Write(fullname string, oah cos.OAH, reader io.Reader) error {
+	return zdw.tw.Write(fullname, oah, reader)
+}
+
+func (zdw *zstdWriter) Copy(src io.Reader, _ ...int64) error {
+	zdr, err := newZstdDecoder(src)
+	if err != nil {
+		return err
+	}
+	err = cpTar(zdr, zdw.tw.tw, zdw.tw.buf)
+	zdr.Close()
+	return err
+}
@@ -0,0 +1,52 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+)
+
+// Audit log: one record per mutating request (see AuditConf and ais/audit.go).
+// Bucket-level setting overrides the cluster-wide `audit.enabled` for the requests
+// that target the bucket and its objects.
+
+// BckAuditConf.Mode enum
+const (
+	AuditInherit  = "inherit" // (default) as per cluster config
+	AuditEnabled  = "enabled"
+	AuditDisabled = "disabled"
+)
+
+type (
+	BckAuditConf struct {
+		Mode string `json:"mode,omitempty"`
+	}
+	BckAuditConfToSet struct {
+		Mode *string `json:"mode,omitempty"`
+	}
+)
+
+func (c *BckAuditConf) ValidateAsProps(...any) error {
+	switch c.Mode {
+	case "", AuditInherit, AuditEnabled, AuditDisabled:
+		return nil
+	default:
+		return fmt.Errorf("invalid audit.mode %q (expecting one of: %q, %q, %q)",
+			c.Mode, AuditInherit, AuditEnabled, AuditDisabled)
+	}
+}
+
+// whether to audit given the cluster-wide setting
+func (c *BckAuditConf) Enabled(dflt bool) bool {
+	switch c.Mode {
+	case AuditEnabled:
+		return true
+	case AuditDisabled:
+		return false
+	default:
+		return dflt
+	}
+}
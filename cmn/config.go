@@ -57,6 +57,9 @@ type (
 		HostNet   LocalNetConfig `json:"host_net"`
 		FSP       FSPConf        `json:"fspaths"`
 		TestFSP   TestFSPConf    `json:"test_fspaths"`
+		// failure domain (e.g., rack or zone) this node belongs to;
+		// EC slices and replicas are spread across domains (see meta.HrwTargetList)
+		Domain string `json:"failure_domain,omitempty"`
 	}
 
 	// ais node: (local) network config
@@ -110,6 +113,9 @@ type (
 		Net        NetConf        `json:"net"`
 		FSHC       FSHCConf       `json:"fshc"`
 		Auth       AuthConf       `json:"auth"`
+		Audit      AuditConf      `json:"audit"`
+		Tracing    TracingConf    `json:"tracing"`
+		BckMetrics BckMetricsConf `json:"bucket_metrics"`
 		Keepalive  KeepaliveConf  `json:"keepalivetracker"`
 		Downloader DownloaderConf `json:"downloader"`
 		Dsort      DsortConf      `json:"distributed_sort"`
@@ -150,6 +156,9 @@ type (
 		Net         *NetConfToSet         `json:"net,omitempty"`
 		FSHC        *FSHCConfToSet        `json:"fshc,omitempty"`
 		Auth        *AuthConfToSet        `json:"auth,omitempty"`
+		Audit       *AuditConfToSet       `json:"audit,omitempty"`
+		Tracing     *TracingConfToSet     `json:"tracing,omitempty"`
+		BckMetrics  *BckMetricsConfToSet  `json:"bucket_metrics,omitempty"`
 		Keepalive   *KeepaliveConfToSet   `json:"keepalivetracker,omitempty"`
 		Downloader  *DownloaderConfToSet  `json:"downloader,omitempty"`
 		Dsort       *DsortConfToSet       `json:"distributed_sort,omitempty"`
@@ -211,17 +220,30 @@ type (
 
 		SbundleMult int `json:"bundle_multiplier"` // stream-bundle multiplier: num streams to destination
 
+		// ScrubIval is how often each target verifies (and repairs) erasure-coded content
+		// of the bucket in the background (see ec/scrub.go); zero value disables scrubbing
+		ScrubIval cos.Duration `json:"scrub_interval"`
+
+		// DomainTolerance is the number of failure domains (racks, zones - see meta.Snode.Domain)
+		// that can be lost at the same time without incurring loss of data; given the number
+		// of domains in the cluster, the value is validated against (D, P) configuration.
+		// Zero value (default) means no requirement. Note that slices and replicas are always
+		// spread across failure domains, if any (see meta.HrwTargetList).
+		DomainTolerance int `json:"domain_tolerance"`
+
 		Enabled  bool `json:"enabled"`   // EC is enabled
 		DiskOnly bool `json:"disk_only"` // if true, EC does not use SGL - data goes directly to drives
 	}
 	ECConfToSet struct {
-		ObjSizeLimit *int64  `json:"objsize_limit,omitempty"`
-		Compression  *string `json:"compression,omitempty"`
-		SbundleMult  *int    `json:"bundle_multiplier,omitempty"`
-		DataSlices   *int    `json:"data_slices,omitempty"`
-		ParitySlices *int    `json:"parity_slices,omitempty"`
-		Enabled      *bool   `json:"enabled,omitempty"`
-		DiskOnly     *bool   `json:"disk_only,omitempty"`
+		ObjSizeLimit    *int64        `json:"objsize_limit,omitempty"`
+		Compression     *string       `json:"compression,omitempty"`
+		SbundleMult     *int          `json:"bundle_multiplier,omitempty"`
+		DataSlices      *int          `json:"data_slices,omitempty"`
+		ParitySlices    *int          `json:"parity_slices,omitempty"`
+		ScrubIval       *cos.Duration `json:"scrub_interval,omitempty"`
+		DomainTolerance *int          `json:"domain_tolerance,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
+		DiskOnly        *bool         `json:"disk_only,omitempty"`
 	}
 
 	LogConf struct {
@@ -315,12 +337,17 @@ type (
 		// Out-of-Space: if exceeded, the target starts failing new PUTs and keeps
 		// failing them until its local used-cap gets back below HighWM (see above)
 		OOS int64 `json:"out_of_space"`
+
+		// MptAbandonTime: S3 multipart uploads that were not updated for this long
+		// are considered abandoned and get removed by storage cleanup
+		MptAbandonTime cos.Duration `json:"mpt_abandon_time"`
 	}
 	SpaceConfToSet struct {
-		CleanupWM *int64 `json:"cleanupwm,omitempty"`
-		LowWM     *int64 `json:"lowwm,omitempty"`
-		HighWM    *int64 `json:"highwm,omitempty"`
-		OOS       *int64 `json:"out_of_space,omitempty"`
+		CleanupWM      *int64        `json:"cleanupwm,omitempty"`
+		LowWM          *int64        `json:"lowwm,omitempty"`
+		HighWM         *int64        `json:"highwm,omitempty"`
+		OOS            *int64        `json:"out_of_space,omitempty"`
+		MptAbandonTime *cos.Duration `json:"mpt_abandon_time,omitempty"`
 	}
 
 	LRUConf struct {
@@ -331,12 +358,26 @@ type (
 		// CapacityUpdTimeStr denotes the frequency at which AIStore updates local capacity utilization
 		CapacityUpdTime cos.Duration `json:"capacity_upd_time"`
 
+		// Policy: which objects to evict first (see LRUPolicy* enum)
+		Policy string `json:"policy,omitempty"`
+
+		// Priority (bucket level): buckets with lower priority are evicted first;
+		// buckets with the same priority - in the order of their sizes, largest first
+		Priority int `json:"priority,omitempty"`
+
+		// RemoteOnly: evict only objects that have a copy in remote storage - that is,
+		// objects in remote buckets and ais:// buckets with remote backend
+		RemoteOnly bool `json:"remote_only,omitempty"`
+
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
 	}
 	LRUConfToSet struct {
 		DontEvictTime   *cos.Duration `json:"dont_evict_time,omitempty"`
 		CapacityUpdTime *cos.Duration `json:"capacity_upd_time,omitempty"`
+		Policy          *string       `json:"policy,omitempty"`
+		Priority        *int          `json:"priority,omitempty"`
+		RemoteOnly      *bool         `json:"remote_only,omitempty"`
 		Enabled         *bool         `json:"enabled,omitempty"`
 	}
 
@@ -426,11 +467,17 @@ type (
 		// - deleting in-cluster object if its remote ("cached") counterpart does not exist
 		// See also: apc.QparamSync, apc.CopyBckMsg
 		Sync bool `json:"synchronize"`
+
+		// ais buckets only: number of prior object versions to retain upon overwrite
+		// (zero - do not retain); prior versions can be listed, read, and deleted
+		// via S3 API (ListObjectVersions, versionId)
+		KeepPrior int `json:"keep_prior,omitempty"`
 	}
 	VersionConfToSet struct {
 		Enabled         *bool `json:"enabled,omitempty"`
 		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
 		Sync            *bool `json:"synchronize,omitempty"`
+		KeepPrior       *int  `json:"keep_prior,omitempty"`
 	}
 
 	NetConf struct {
@@ -502,12 +549,70 @@ type (
 	FSHCConfRC3 FSHCConf
 
 	AuthConf struct {
-		Secret  string `json:"secret"`
-		Enabled bool   `json:"enabled"`
+		Secret string `json:"secret"`
+		// AuthN public keys (JWK Set) to validate RS256 and ES256 signed tokens,
+		// e.g. "http://authn:52001/v1/tokens/jwks"; when specified,
+		// HS256 tokens are not accepted unless explicitly allowed (below)
+		JWKSURL string `json:"jwks_url,omitempty"`
+		// (with jwks_url) keep accepting HS256 tokens signed with the secret, e.g. during migration
+		AllowHS256 bool `json:"allow_hs256,omitempty"`
+		Enabled    bool `json:"enabled"`
 	}
 	AuthConfToSet struct {
-		Secret  *string `json:"secret,omitempty"`
-		Enabled *bool   `json:"enabled,omitempty"`
+		Secret     *string `json:"secret,omitempty"`
+		JWKSURL    *string `json:"jwks_url,omitempty"`
+		AllowHS256 *bool   `json:"allow_hs256,omitempty"`
+		Enabled    *bool   `json:"enabled,omitempty"`
+	}
+
+	// audit log of mutating requests (see also: BckAuditConf)
+	AuditConf struct {
+		// optional HTTP(S) endpoint to POST batches of audit records
+		// (newline-delimited JSON) to, in addition to the local audit log
+		HTTPSink string `json:"http_sink,omitempty"`
+		// also send audit records to the local syslog
+		Syslog  bool `json:"syslog"`
+		Enabled bool `json:"enabled"`
+	}
+	AuditConfToSet struct {
+		HTTPSink *string `json:"http_sink,omitempty"`
+		Syslog   *bool   `json:"syslog,omitempty"`
+		Enabled  *bool   `json:"enabled,omitempty"`
+	}
+
+	// distributed tracing: OpenTelemetry spans exported to OTLP/HTTP collector
+	TracingConf struct {
+		// collector's base URL, e.g. "http://localhost:4318" (spans are POSTed to <endpoint>/v1/traces)
+		ExporterEndpoint string `json:"exporter_endpoint"`
+		// optional value of the "Authorization" header, e.g. "Bearer <token>"
+		ExporterAuth string `json:"exporter_auth,omitempty"`
+		// fraction (0, 1] of the traces that start in the cluster to sample; zero means all;
+		// traces that start in the clients (and carry W3C traceparent) follow the client's decision
+		SamplerProbability float64 `json:"sampler_probability"`
+		SkipVerify         bool    `json:"skip_verify"`
+		Enabled            bool    `json:"enabled"`
+	}
+	TracingConfToSet struct {
+		ExporterEndpoint   *string  `json:"exporter_endpoint,omitempty"`
+		ExporterAuth       *string  `json:"exporter_auth,omitempty"`
+		SamplerProbability *float64 `json:"sampler_probability,omitempty"`
+		SkipVerify         *bool    `json:"skip_verify,omitempty"`
+		Enabled            *bool    `json:"enabled,omitempty"`
+	}
+
+	// per-bucket (and, optionally, per-user) GET, PUT, and DELETE metrics (see stats/bck.go)
+	BckMetricsConf struct {
+		// max number of distinct (bucket, user) label sets per target; beyond that,
+		// all new buckets and users are accounted as "other" (zero means default)
+		MaxLabels int `json:"max_labels"`
+		// label by AuthN user ID as well (requires AuthN)
+		PerUser bool `json:"per_user"`
+		Enabled bool `json:"enabled"`
+	}
+	BckMetricsConfToSet struct {
+		MaxLabels *int  `json:"max_labels,omitempty"`
+		PerUser   *bool `json:"per_user,omitempty"`
+		Enabled   *bool `json:"enabled,omitempty"`
 	}
 
 	// keepalive
@@ -622,7 +727,7 @@ type (
 )
 
 // assorted named fields that require (cluster | node) restart for changes to make an effect
-var ConfigRestartRequired = [...]string{"auth.secret", "memsys", "net"}
+var ConfigRestartRequired = [...]string{"auth.secret", "auth.jwks_url", "memsys", "net"}
 
 // dsort
 const (
@@ -663,6 +768,7 @@ var (
 	_ Validator = (*MirrorConf)(nil)
 	_ Validator = (*ECConf)(nil)
 	_ Validator = (*VersionConf)(nil)
+	_ Validator = (*AuthConf)(nil)
 	_ Validator = (*KeepaliveConf)(nil)
 	_ Validator = (*PeriodConf)(nil)
 	_ Validator = (*TimeoutConf)(nil)
@@ -981,9 +1087,18 @@ func (c *DiskConf) Validate() (err error) {
 // SpaceConf //
 ///////////////
 
+const MptAbandonTimeDflt = 7 * 24 * time.Hour
+
 func (c *SpaceConf) Validate() (err error) {
 	if c.CleanupWM <= 0 || c.LowWM < c.CleanupWM || c.HighWM < c.LowWM || c.OOS < c.HighWM || c.OOS > 100 {
-		err = fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+		return fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+	}
+	// [backward compatibility]
+	if c.MptAbandonTime == 0 {
+		c.MptAbandonTime = cos.Duration(MptAbandonTimeDflt)
+	}
+	if c.MptAbandonTime < cos.Duration(time.Hour) {
+		err = fmt.Errorf("invalid space.mpt_abandon_time %v (expecting >= %v)", c.MptAbandonTime, time.Hour)
 	}
 	return
 }
@@ -999,18 +1114,41 @@ func (c *SpaceConf) String() string {
 // LRUConf //
 /////////////
 
+// LRU eviction policies
+const (
+	LRUPolicyAtime = "lru"  // least recently used (oldest access time) first - the default
+	LRUPolicyLFU   = "lfu"  // least frequently used first (see core.LOM.AccessCount)
+	LRUPolicySize  = "size" // size-weighted LRU: the product (idle time * size), largest first
+)
+
 func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v", c.DontEvictTime, c.CapacityUpdTime)
+	return fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v, lru.policy=%s",
+		c.DontEvictTime, c.CapacityUpdTime, c.EvictPolicy())
 }
 
+func (c *LRUConf) EvictPolicy() string { return cos.Left(c.Policy, LRUPolicyAtime) }
+
 func (c *LRUConf) Validate() (err error) {
 	if c.CapacityUpdTime.D() < 10*time.Second {
-		err = fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
+		return fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
 	}
-	return
+	return c.ValidateAsProps()
+}
+
+func (c *LRUConf) ValidateAsProps(...any) error {
+	switch c.Policy {
+	case "", LRUPolicyAtime, LRUPolicyLFU, LRUPolicySize:
+	default:
+		return fmt.Errorf("invalid lru.policy %q (expecting one of: %q, %q, %q)",
+			c.Policy, LRUPolicyAtime, LRUPolicyLFU, LRUPolicySize)
+	}
+	if c.Priority < 0 {
+		return fmt.Errorf("invalid lru.priority %d (expecting non-negative integer)", c.Priority)
+	}
+	return nil
 }
 
 ///////////////
@@ -1053,10 +1191,18 @@ func (c *CksumConf) String() string {
 // VersionConf //
 /////////////////
 
+const MaxKeepPrior = 100 // max number of retained prior object versions
+
 func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.KeepPrior < 0 || c.KeepPrior > MaxKeepPrior {
+		return fmt.Errorf("invalid versioning.keep_prior %d (expecting 0 <= keep_prior <= %d)", c.KeepPrior, MaxKeepPrior)
+	}
+	if !c.Enabled && c.KeepPrior > 0 {
+		return errors.New("versioning.keep_prior requires versioning to be enabled")
+	}
 	return nil
 }
 
@@ -1113,6 +1259,8 @@ const (
 
 	MinSliceCount = 1  // minimum number of data or parity slices
 	MaxSliceCount = 32 // maximum --/--
+
+	ecMinScrubIval = time.Minute // (when enabled)
 )
 
 func (c *ECConf) Validate() error {
@@ -1134,9 +1282,47 @@ func (c *ECConf) Validate() error {
 	if !apc.IsValidCompression(c.Compression) {
 		return fmt.Errorf("invalid ec.compression: %q (expecting one of: %v)", c.Compression, apc.SupportedCompression)
 	}
+	if c.ScrubIval != 0 && c.ScrubIval.D() < ecMinScrubIval {
+		return fmt.Errorf("invalid ec.scrub_interval: %v (expecting zero (disabled) or greater than or equal %v)",
+			c.ScrubIval, ecMinScrubIval)
+	}
+	if c.DomainTolerance < 0 || c.DomainTolerance > c.ParitySlices {
+		return fmt.Errorf("invalid ec.domain_tolerance: %d (expected range [0, %d] - cannot exceed ec.parity_slices)",
+			c.DomainTolerance, c.ParitySlices)
+	}
 	return nil
 }
 
+// ValidateDomains checks whether, given the number of failure domains in the cluster,
+// the bucket can survive the loss of `DomainTolerance` domains. Assumes that slices and replicas
+// are spread evenly, so that any given domain has at most ceil(n / numDomains) of them, where:
+// - erasure coding: n = D + P + 1 (including main replica), any D of which suffice;
+// - replication:    n = P + 1, any one of which suffices.
+func (c *ECConf) ValidateDomains(numDomains int) error {
+	if !c.Enabled || c.DomainTolerance == 0 {
+		return nil
+	}
+	if numDomains <= c.DomainTolerance {
+		return fmt.Errorf("%v: ec.domain_tolerance %d requires at least %d failure domains (have %d)",
+			ErrNotEnoughTargets, c.DomainTolerance, c.DomainTolerance+1, numDomains)
+	}
+	check := func(n, spare int) error {
+		perDomain := (n + numDomains - 1) / numDomains
+		if c.DomainTolerance*perDomain <= spare {
+			return nil
+		}
+		return fmt.Errorf("EC configuration (D = %d, P = %d) cannot tolerate the loss of %d (out of %d) failure domains: "+
+			"up to %d slices (or replicas) per domain", c.DataSlices, c.ParitySlices, c.DomainTolerance, numDomains, perDomain)
+	}
+	if c.ObjSizeLimit != ObjSizeToAlwaysReplicate {
+		if err := check(c.DataSlices+c.ParitySlices+1, c.ParitySlices+1); err != nil {
+			return err
+		}
+	}
+	// (small objects get replicated)
+	return check(c.ParitySlices+1, c.ParitySlices)
+}
+
 func (c *ECConf) ValidateAsProps(arg ...any) (err error) {
 	if !c.Enabled {
 		return
@@ -1199,6 +1385,91 @@ func (c *WritePolicyConf) Validate() (err error) {
 
 func (c *WritePolicyConf) ValidateAsProps(...any) error { return c.Validate() }
 
+///////////////
+// AuditConf //
+///////////////
+
+func (c *AuditConf) Validate() error {
+	if c.HTTPSink == "" {
+		return nil
+	}
+	u, err := url.Parse(c.HTTPSink)
+	if err != nil {
+		return fmt.Errorf("invalid audit.http_sink %q: %v", c.HTTPSink, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid audit.http_sink %q: expecting http or https scheme", c.HTTPSink)
+	}
+	return nil
+}
+
+/////////////////
+// TracingConf //
+/////////////////
+
+func (c *TracingConf) Validate() error {
+	if c.SamplerProbability < 0 || c.SamplerProbability > 1 {
+		return fmt.Errorf("invalid tracing.sampler_probability %g (expecting a fraction in the (0, 1] range)",
+			c.SamplerProbability)
+	}
+	if c.ExporterEndpoint == "" {
+		if c.Enabled {
+			return errors.New("tracing.exporter_endpoint must be defined when tracing is enabled")
+		}
+		return nil
+	}
+	u, err := url.Parse(c.ExporterEndpoint)
+	if err != nil {
+		return fmt.Errorf("invalid tracing.exporter_endpoint %q: %v", c.ExporterEndpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid tracing.exporter_endpoint %q: expecting http or https scheme", c.ExporterEndpoint)
+	}
+	return nil
+}
+
+////////////////////
+// BckMetricsConf //
+////////////////////
+
+const (
+	BckMetricsDfltLabels = 256
+	bckMetricsMaxLabels  = 16 * 1024
+)
+
+func (c *BckMetricsConf) Validate() error {
+	if c.MaxLabels < 0 || c.MaxLabels > bckMetricsMaxLabels {
+		return fmt.Errorf("invalid bucket_metrics.max_labels %d (expecting 0 (default) to %d)",
+			c.MaxLabels, bckMetricsMaxLabels)
+	}
+	return nil
+}
+
+func (c *BckMetricsConf) Max() int {
+	if c.MaxLabels == 0 {
+		return BckMetricsDfltLabels
+	}
+	return c.MaxLabels
+}
+
+//////////////
+// AuthConf //
+//////////////
+
+func (c *AuthConf) Validate() error {
+	if c.JWKSURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.JWKSURL)
+	if err != nil {
+		return fmt.Errorf("invalid auth.jwks_url %q: %v", c.JWKSURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid auth.jwks_url %q: expecting http or https scheme", c.JWKSURL)
+	}
+	return nil
+}
+
 ///////////////////
 // KeepaliveConf //
 ///////////////////
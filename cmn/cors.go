@@ -0,0 +1,141 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket CORS (cross-origin resource sharing): a (bucket property) list of rules
+// that allow browser clients from the specified origins to access the bucket via S3 API.
+// The first rule that matches a given (origin, method, request headers) applies.
+// See also: ais/s3/cors.go and
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/cors.html
+
+const CORSMaxRules = 100 // as per S3
+
+type (
+	CORSConf struct {
+		Rules []CORSRule `json:"rules,omitempty"`
+	}
+	CORSConfToSet struct {
+		Rules *[]CORSRule `json:"rules,omitempty"`
+	}
+	CORSRule struct {
+		ID             string   `json:"id,omitempty"`
+		AllowedOrigins []string `json:"allowed_origins"`           // e.g. "https://*.example.com"; at most one '*' wildcard
+		AllowedMethods []string `json:"allowed_methods"`           // GET, PUT, POST, DELETE, HEAD
+		AllowedHeaders []string `json:"allowed_headers,omitempty"` // (preflight) "Access-Control-Request-Headers" to allow
+		ExposeHeaders  []string `json:"expose_headers,omitempty"`  // response headers accessible to the browser
+		MaxAge         int      `json:"max_age,omitempty"`         // seconds to cache preflight response
+	}
+)
+
+var corsMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead}
+
+//////////////
+// CORSConf //
+//////////////
+
+func (c *CORSConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > CORSMaxRules {
+		return fmt.Errorf("too many CORS rules: %d (max %d)", len(c.Rules), CORSMaxRules)
+	}
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("invalid CORS rule #%d %q: %v", i+1, rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// returns the first matching rule, or nil
+func (c *CORSConf) Match(origin, method string, reqHeaders []string) *CORSRule {
+	if origin == "" {
+		return nil
+	}
+	for i := range c.Rules {
+		if rule := &c.Rules[i]; rule.Match(origin, method, reqHeaders) {
+			return rule
+		}
+	}
+	return nil
+}
+
+//////////////
+// CORSRule //
+//////////////
+
+func (rule *CORSRule) validate() error {
+	if len(rule.AllowedOrigins) == 0 {
+		return errors.New("no allowed origins")
+	}
+	if len(rule.AllowedMethods) == 0 {
+		return errors.New("no allowed methods")
+	}
+	for _, o := range rule.AllowedOrigins {
+		if o == "" || strings.Count(o, "*") > 1 {
+			return fmt.Errorf("invalid allowed origin %q", o)
+		}
+	}
+	for _, m := range rule.AllowedMethods {
+		if !cos.StringInSlice(m, corsMethods) {
+			return fmt.Errorf("invalid allowed method %q (expecting one of %v)", m, corsMethods)
+		}
+	}
+	for _, h := range rule.AllowedHeaders {
+		if strings.Count(h, "*") > 1 {
+			return fmt.Errorf("invalid allowed header %q", h)
+		}
+	}
+	if rule.MaxAge < 0 {
+		return fmt.Errorf("negative max-age %d", rule.MaxAge)
+	}
+	return nil
+}
+
+func (rule *CORSRule) Match(origin, method string, reqHeaders []string) bool {
+	if !cos.StringInSlice(method, rule.AllowedMethods) {
+		return false
+	}
+	if !matchAny(rule.AllowedOrigins, origin, false) {
+		return false
+	}
+	for _, h := range reqHeaders {
+		if !matchAny(rule.AllowedHeaders, h, true) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(patterns []string, s string, ignoreCase bool) bool {
+	for _, p := range patterns {
+		if ignoreCase {
+			p, s = strings.ToLower(p), strings.ToLower(s)
+		}
+		if matchWildcard(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// (at most one '*')
+func matchWildcard(pattern, s string) bool {
+	i := strings.IndexByte(pattern, '*')
+	if i < 0 {
+		return pattern == s
+	}
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	return len(s) >= len(prefix)+len(suffix) && strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
@@ -36,6 +36,7 @@ const (
 	HdrContentType        = "Content-Type"
 	HdrContentTypeOptions = "X-Content-Type-Options"
 	HdrContentLength      = "Content-Length"
+	HdrContentEncoding    = "Content-Encoding"
 
 	// misc. gen
 	HdrUserAgent = "User-Agent"
@@ -45,6 +46,19 @@ const (
 	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
 
 	HdrHSTS = "Strict-Transport-Security"
+
+	// CORS
+	// Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
+	HdrOrigin             = "Origin"
+	HdrVary               = "Vary"
+	HdrACRequestMethod    = "Access-Control-Request-Method"
+	HdrACRequestHeaders   = "Access-Control-Request-Headers"
+	HdrACAllowOrigin      = "Access-Control-Allow-Origin"
+	HdrACAllowCredentials = "Access-Control-Allow-Credentials"
+	HdrACAllowMethods     = "Access-Control-Allow-Methods"
+	HdrACAllowHeaders     = "Access-Control-Allow-Headers"
+	HdrACExposeHeaders    = "Access-Control-Expose-Headers"
+	HdrACMaxAge           = "Access-Control-Max-Age"
 )
 
 //
@@ -67,9 +81,24 @@ const (
 	S3HdrObjSrc = "x-amz-copy-source"
 	S3HdrMptCnt = "x-amz-mp-parts-count"
 
+	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+	S3HdrTagging      = "x-amz-tagging"
+	S3HdrTaggingCount = "x-amz-tagging-count"
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+	S3HdrObjLockMode        = "x-amz-object-lock-mode"
+	S3HdrObjLockRetainUntil = "x-amz-object-lock-retain-until-date"
+	S3HdrObjLockLegalHold   = "x-amz-object-lock-legal-hold"
+
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
 	S3UnsignedPayload  = "UNSIGNED-PAYLOAD"
 	S3HdrContentSHA256 = "x-amz-content-sha256"
+	S3HdrDate          = "x-amz-date"
+
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
+	S3StreamingPayload          = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	S3HdrDecodedContentLength   = "x-amz-decoded-content-length"
+	S3ContentEncodingAWSChunked = "aws-chunked"
 
 	S3HdrBckRegion = "x-amz-bucket-region"
 
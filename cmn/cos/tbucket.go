@@ -0,0 +1,62 @@
+// Package cos provides common low-level types and utilities for all aistore projects.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// TokenBucket is a thread-safe token-bucket rate limiter that refills at `rate` tokens
+// per second up to the burst capacity of one second's worth of tokens.
+// The bucket can go into debt: TryTake succeeds as long as there's at least one token left,
+// even when the requested number is greater - which is how sizes (bytes) larger than the
+// burst get to pass, and how the cost that becomes known only after the fact (see Take)
+// gets to be accounted for.
+type TokenBucket struct {
+	rate   float64 // tokens per second
+	tokens float64
+	last   int64 // mono time of the last refill
+	mu     sync.Mutex
+}
+
+func NewTokenBucket(rate int64) *TokenBucket {
+	return &TokenBucket{rate: float64(rate), tokens: float64(rate), last: mono.NanoTime()}
+}
+
+// TryTake takes n tokens unless the bucket is empty (less than one token left, or in debt)
+func (tb *TokenBucket) TryTake(n int64) (ok bool) {
+	tb.mu.Lock()
+	tb.refill(mono.NanoTime())
+	if ok = tb.tokens >= 1; ok {
+		tb.tokens -= float64(n)
+	}
+	tb.mu.Unlock()
+	return ok
+}
+
+// Take takes n tokens unconditionally
+func (tb *TokenBucket) Take(n int64) {
+	tb.mu.Lock()
+	tb.refill(mono.NanoTime())
+	tb.tokens -= float64(n)
+	tb.mu.Unlock()
+}
+
+// Put returns n (previously taken) tokens, up to the burst capacity
+func (tb *TokenBucket) Put(n int64) {
+	tb.mu.Lock()
+	tb.refill(mono.NanoTime())
+	tb.tokens = min(tb.tokens+float64(n), tb.rate)
+	tb.mu.Unlock()
+}
+
+func (tb *TokenBucket) refill(now int64) {
+	elapsed := time.Duration(now - tb.last)
+	tb.last = now
+	tb.tokens = min(tb.tokens+tb.rate*elapsed.Seconds(), tb.rate)
+}
@@ -1224,8 +1224,10 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 		switch {
 		case isErrNotFoundExtended(err, status):
 			status = http.StatusNotFound
-		case IsErrCapExceeded(err):
+		case IsErrCapExceeded(err), IsErrQuotaExceeded(err):
 			status = http.StatusInsufficientStorage
+		case IsErrRateLimited(err):
+			status = http.StatusTooManyRequests
 		case IsErrRangeNotSatisfiable(err):
 			status = http.StatusRequestedRangeNotSatisfiable
 		case isErrUnsupp(err), isErrNotImpl(err):
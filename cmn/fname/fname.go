@@ -26,12 +26,13 @@ const (
 	ProxyID = ".ais.proxy_id"
 
 	// metadata
-	Smap        = ".ais.smap"   // Smap persistent file basename
-	Rmd         = ".ais.rmd"    // rmd persistent file basename
-	Bmd         = ".ais.bmd"    // bmd persistent file basename
-	BmdPrevious = Bmd + ".prev" // bmd previous version
-	Vmd         = ".ais.vmd"    // vmd persistent file basename
-	Emd         = ".ais.emd"    // emd persistent file basename
+	Smap        = ".ais.smap"    // Smap persistent file basename
+	Rmd         = ".ais.rmd"     // rmd persistent file basename
+	RebCkpt     = ".ais.rebckpt" // (target) rebalance checkpoint - see reb/checkpoint.go
+	Bmd         = ".ais.bmd"     // bmd persistent file basename
+	BmdPrevious = Bmd + ".prev"  // bmd previous version
+	Vmd         = ".ais.vmd"     // vmd persistent file basename
+	Emd         = ".ais.emd"     // emd persistent file basename
 
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
@@ -43,6 +44,9 @@ const (
 	// Token
 	Token = "auth.token"
 
+	// AuthN-managed S3 access keys (replicated)
+	S3Keys = ".ais.s3keys"
+
 	// Markers: per mountpath
 	MarkersDir          = ".ais.markers"
 	ResilverMarker      = "resilver"
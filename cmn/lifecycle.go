@@ -0,0 +1,131 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket lifecycle: a (bucket property) list of rules, each rule selecting objects
+// by name prefix and/or tags, to:
+// - expire (delete) objects that were not modified for a given time, and/or
+// - abort incomplete multipart uploads initiated more than a given time ago.
+// The rules are executed by the (target) lifecycle xaction - see xact/xs/lifecycle.go
+// and, for S3 compatibility, ais/s3/lifecycle.go.
+
+const LifecycleMaxRules = 1000 // as per S3
+
+type (
+	LifecycleConf struct {
+		Rules []LifecycleRule `json:"rules,omitempty"`
+	}
+	LifecycleConfToSet struct {
+		Rules *[]LifecycleRule `json:"rules,omitempty"`
+	}
+	LifecycleRule struct {
+		ID       string       `json:"id,omitempty"`
+		Prefix   string       `json:"prefix,omitempty"`
+		Tags     cos.StrKVs   `json:"tags,omitempty"`      // all must match (see TaggingObjMD)
+		Expire   cos.Duration `json:"expire,omitempty"`    // since object's last modification
+		AbortMpt cos.Duration `json:"abort_mpt,omitempty"` // since multipart upload initiation
+		Enabled  bool         `json:"enabled"`
+	}
+)
+
+///////////////////
+// LifecycleConf //
+///////////////////
+
+// (any rule) enabled
+func (c *LifecycleConf) IsEnabled() bool {
+	for i := range c.Rules {
+		if c.Rules[i].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LifecycleConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > LifecycleMaxRules {
+		return fmt.Errorf("too many lifecycle rules: %d (max %d)", len(c.Rules), LifecycleMaxRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("invalid lifecycle rule #%d %q: %v", i+1, rule.ID, err)
+		}
+		if rule.ID == "" {
+			continue
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("duplicate lifecycle rule ID %q", rule.ID)
+		}
+		ids.Add(rule.ID)
+	}
+	return nil
+}
+
+// returns the (shortest) expiration time applicable to a given object, or zero
+func (c *LifecycleConf) Expire(objName string, tags cos.StrKVs) (expire time.Duration) {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.Expire == 0 || !rule.Match(objName, tags) {
+			continue
+		}
+		if d := rule.Expire.D(); expire == 0 || d < expire {
+			expire = d
+		}
+	}
+	return
+}
+
+// ditto, for incomplete multipart uploads
+// (tags are not applicable - S3 does not allow tag filters with this action)
+func (c *LifecycleConf) AbortMpt(objName string) (abort time.Duration) {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.AbortMpt == 0 || len(rule.Tags) > 0 || !strings.HasPrefix(objName, rule.Prefix) {
+			continue
+		}
+		if d := rule.AbortMpt.D(); abort == 0 || d < abort {
+			abort = d
+		}
+	}
+	return
+}
+
+///////////////////
+// LifecycleRule //
+///////////////////
+
+func (rule *LifecycleRule) validate() error {
+	if rule.Expire < 0 || rule.AbortMpt < 0 {
+		return errors.New("negative expiration time")
+	}
+	if rule.Expire == 0 && rule.AbortMpt == 0 {
+		return errors.New("no action specified (expecting expiration and/or abort-incomplete-multipart)")
+	}
+	if rule.AbortMpt > 0 && len(rule.Tags) > 0 {
+		return errors.New("tag filter cannot be used to abort incomplete multipart uploads")
+	}
+	for k := range rule.Tags {
+		if k == "" {
+			return errors.New("empty tag key")
+		}
+	}
+	return nil
+}
+
+func (rule *LifecycleRule) Match(objName string, tags cos.StrKVs) bool {
+	return strings.HasPrefix(objName, rule.Prefix) && MatchObjTags(tags, rule.Tags)
+}
@@ -50,11 +50,11 @@ func ErrLogName() string  { return sname() + ".ERROR" }
 
 func Flush(action int) {
 	now := mono.NanoTime()
-	for _, sev := range []severity{sevInfo, sevErr} {
-		var (
-			nlog = nlogs[sev]
-			oob  bool
-		)
+	for _, nlog := range []*nlog{nlogs[sevInfo], nlogs[sevErr], alog.Load()} {
+		var oob bool
+		if nlog == nil {
+			continue // (audit log not in use)
+		}
 
 		nlog.mw.Lock()
 		if nlog.file == nil || (nlog.pw.length() == 0 && action != ActRotate) {
@@ -83,13 +83,16 @@ func Flush(action int) {
 
 func Since(now int64) time.Duration {
 	a, b := nlogs[sevInfo].since(now), nlogs[sevErr].since(now)
-	if a > b {
-		return a
+	if nlog := alog.Load(); nlog != nil {
+		b = max(b, nlog.since(now))
 	}
-	return b
+	return max(a, b)
 }
 
 func OOB() bool {
+	if nlog := alog.Load(); nlog != nil && nlog.oob.Load() {
+		return true
+	}
 	return nlogs[sevInfo].oob.Load() || nlogs[sevErr].oob.Load()
 }
 
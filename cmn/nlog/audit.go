@@ -0,0 +1,59 @@
+// Package nlog - aistore logger, provides buffering, timestamping, writing, and
+// flushing/syncing/rotating
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package nlog
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Audit log: a separate (".AUDIT.") log file that contains audit records, one per line,
+// as is - no headers and no timestamps other than those in the records themselves.
+// The file is created upon the first record and is then buffered, flushed, and
+// rotated exactly like the INFO and ERROR logs.
+
+var (
+	alog     atomic.Pointer[nlog]
+	alogOnce sync.Once
+)
+
+func AuditLogName() string { return sname() + "." + sevText[sevAudit] }
+
+// Audit writes a single record; the record must not contain newlines and
+// is truncated if it's longer than the maximum line size (2KiB)
+func Audit(rec []byte) {
+	if LogToStderr {
+		os.Stderr.Write(rec)
+		os.Stderr.WriteString("\n")
+		return
+	}
+	alogOnce.Do(initAudit)
+	nlog := alog.Load()
+	if nlog == nil {
+		return // (failed to create)
+	}
+	if len(rec) >= maxLineSize {
+		rec = rec[:maxLineSize-1]
+	}
+	nlog.mw.Lock()
+	nlog.line.reset()
+	nlog.line.Write(rec)
+	nlog.line.eol()
+	nlog.write(&nlog.line)
+	nlog.mw.Unlock()
+}
+
+func initAudit() {
+	onceInitFiles.Do(initFiles)
+	nlog := newNlog(sevAudit)
+	if err := nlog.rotate(time.Now()); err != nil {
+		Errorln("failed to create audit log:", err)
+		return
+	}
+	alog.Store(nlog)
+}
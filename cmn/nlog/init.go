@@ -24,7 +24,7 @@ var (
 		"common_stats": 0,
 		"err":          0,
 	}
-	sevText = []string{sevInfo: "INFO", sevErr: "ERROR"}
+	sevText = []string{sevInfo: "INFO", sevErr: "ERROR", sevAudit: "AUDIT"}
 )
 
 var (
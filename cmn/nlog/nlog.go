@@ -32,6 +32,7 @@ const (
 	sevInfo severity = iota
 	sevWarn
 	sevErr
+	sevAudit // (see audit.go)
 )
 
 type (
@@ -230,6 +231,9 @@ func (nlog *nlog) rotate(now time.Time) (err error) {
 
 	nlog.written.Store(0)
 	nlog.erred.Store(false)
+	if nlog.sev == sevAudit {
+		return // (records only)
+	}
 	if title == "" {
 		line1 = "Started up at " + snow + ", " + s
 		_, err = nlog.file.WriteString(line1)
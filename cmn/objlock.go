@@ -0,0 +1,163 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object lock (WORM): a bucket property that, once enabled, prevents objects from being
+// overwritten, deleted, or renamed for the duration of their retention period and/or while
+// under legal hold. Per-object retention and legal hold are stored in the object's
+// custom metadata; bucket-wide legal hold applies to all objects in the bucket.
+// In GOVERNANCE mode, per-object retention can be shortened (or removed) and the bucket's
+// object lock disabled; in COMPLIANCE mode, retention can be only extended and object lock
+// cannot be disabled.
+// A bucket with object lock enabled cannot be destroyed or renamed.
+// See also: ais/tgtobjlock.go and
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+
+const (
+	ObjLockGovernance = "GOVERNANCE"
+	ObjLockCompliance = "COMPLIANCE"
+
+	// custom metadata
+	RetainUntilObjMD = "retain-until" // RFC 3339 (UTC)
+	LegalHoldObjMD   = "legal-hold"   // LegalHoldOn (absent when off)
+
+	LegalHoldOn = "ON"
+)
+
+type (
+	ObjLockConf struct {
+		Mode      string       `json:"mode,omitempty"`       // ObjLockGovernance | ObjLockCompliance
+		Retention cos.Duration `json:"retention,omitempty"`  // default retention of new objects (zero - none)
+		Enabled   bool         `json:"enabled"`              // enables WORM semantics for the bucket
+		LegalHold bool         `json:"legal_hold,omitempty"` // bucket-wide legal hold
+	}
+	ObjLockConfToSet struct {
+		Mode      *string       `json:"mode,omitempty"`
+		Retention *cos.Duration `json:"retention,omitempty"`
+		Enabled   *bool         `json:"enabled,omitempty"`
+		LegalHold *bool         `json:"legal_hold,omitempty"`
+	}
+
+	ErrObjLocked struct {
+		what   string
+		reason string
+	}
+)
+
+/////////////////
+// ObjLockConf //
+/////////////////
+
+func (c *ObjLockConf) ValidateAsProps(...any) error {
+	if !c.Enabled {
+		if c.LegalHold {
+			return errors.New("object lock: legal hold requires object lock to be enabled")
+		}
+		return nil
+	}
+	if c.Mode != ObjLockGovernance && c.Mode != ObjLockCompliance {
+		return fmt.Errorf("object lock: invalid mode %q (expecting %q or %q)", c.Mode, ObjLockGovernance, ObjLockCompliance)
+	}
+	if c.Retention < 0 {
+		return fmt.Errorf("object lock: negative retention %v", c.Retention)
+	}
+	return nil
+}
+
+// validate changes of the existing configuration (see also: ValidateAsProps);
+// object lock is supported only for ais buckets
+func (c *ObjLockConf) ValidateUpdate(bck *Bck, nconf *ObjLockConf) error {
+	if nconf.Enabled && !bck.IsAIS() {
+		return fmt.Errorf("object lock: not supported for %s (only ais:// buckets)", bck.Cname(""))
+	}
+	if !c.Enabled || c.Mode != ObjLockCompliance {
+		return nil
+	}
+	if !nconf.Enabled {
+		return errors.New("object lock: cannot disable object lock in compliance mode")
+	}
+	if nconf.Mode != ObjLockCompliance {
+		return errors.New("object lock: cannot change compliance mode")
+	}
+	return nil
+}
+
+// Check returns ErrObjLocked if the object with the given custom metadata cannot be
+// modified or deleted; nil custom metadata - bucket-wide legal hold only
+func (c *ObjLockConf) Check(cname string, custom cos.StrKVs, now time.Time) error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.LegalHold {
+		return &ErrObjLocked{cname, "bucket is under legal hold"}
+	}
+	if custom[LegalHoldObjMD] == LegalHoldOn {
+		return &ErrObjLocked{cname, "object is under legal hold"}
+	}
+	if until, ok := RetainUntil(custom); ok && now.Before(until) {
+		return &ErrObjLocked{cname, "object is retained until " + FmtRetainUntil(until)}
+	}
+	return nil
+}
+
+// CheckRetention validates per-object retention update; zero `until` removes retention
+func (c *ObjLockConf) CheckRetention(cname string, custom cos.StrKVs, until, now time.Time) error {
+	if !c.Enabled {
+		return fmt.Errorf("%s: object lock is not enabled", cname)
+	}
+	if !until.IsZero() && !until.After(now) {
+		return fmt.Errorf("%s: retain-until date must be in the future (have %s)", cname, FmtRetainUntil(until))
+	}
+	if c.Mode != ObjLockCompliance {
+		return nil
+	}
+	if cur, ok := RetainUntil(custom); ok && now.Before(cur) && (until.IsZero() || until.Before(cur)) {
+		return &ErrObjLocked{cname, "cannot shorten retention in compliance mode (retained until " + FmtRetainUntil(cur) + ")"}
+	}
+	return nil
+}
+
+// DefaultRetainUntil returns retain-until time for a new object (zero - none)
+func (c *ObjLockConf) DefaultRetainUntil(now time.Time) time.Time {
+	if !c.Enabled || c.Retention <= 0 {
+		return time.Time{}
+	}
+	return now.Add(c.Retention.D())
+}
+
+func RetainUntil(custom cos.StrKVs) (time.Time, bool) {
+	s, ok := custom[RetainUntilObjMD]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, s)
+	return until, err == nil
+}
+
+func FmtRetainUntil(until time.Time) string { return until.UTC().Format(time.RFC3339) }
+
+//////////////////
+// ErrObjLocked //
+//////////////////
+
+func NewErrObjLocked(what, reason string) *ErrObjLocked { return &ErrObjLocked{what, reason} }
+
+func (e *ErrObjLocked) Error() string {
+	return fmt.Sprintf("%s is locked: %s", e.what, e.reason)
+}
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
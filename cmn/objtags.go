@@ -0,0 +1,83 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object tags: user-defined key/value pairs stored in the object's custom metadata
+// under TaggingObjMD as a single URL-encoded string (same format as S3 "x-amz-tagging").
+// Limits are as per S3:
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+
+const (
+	TaggingObjMD = "tagging" // custom metadata key (URL-encoded, as in "x-amz-tagging")
+
+	MaxObjTags        = 10
+	MaxObjTagKeyLen   = 128
+	MaxObjTagValueLen = 256
+)
+
+// parse URL-encoded object tags
+func ParseObjTags(s string) (cos.StrKVs, error) {
+	if s == "" {
+		return nil, nil
+	}
+	q, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object tags %q: %v", s, err)
+	}
+	tags := make(cos.StrKVs, len(q))
+	for k, vs := range q {
+		if len(vs) != 1 {
+			return nil, fmt.Errorf("invalid object tags %q: duplicate key %q", s, k)
+		}
+		tags[k] = vs[0]
+	}
+	return tags, nil
+}
+
+// (the inverse of the above; keys are sorted)
+func EncodeObjTags(tags cos.StrKVs) string {
+	q := make(url.Values, len(tags))
+	for k, v := range tags {
+		q.Set(k, v)
+	}
+	return q.Encode()
+}
+
+func ValidateObjTags(tags cos.StrKVs) error {
+	if len(tags) > MaxObjTags {
+		return fmt.Errorf("too many object tags: %d (max %d)", len(tags), MaxObjTags)
+	}
+	for k, v := range tags {
+		if k == "" {
+			return errors.New("empty tag key")
+		}
+		if len(k) > MaxObjTagKeyLen {
+			return fmt.Errorf("tag key %q is too long (max %d)", k, MaxObjTagKeyLen)
+		}
+		if len(v) > MaxObjTagValueLen {
+			return fmt.Errorf("tag %q: value is too long (max %d)", k, MaxObjTagValueLen)
+		}
+	}
+	return nil
+}
+
+// true if `tags` include all of the `want` key/value pairs (an empty `want` matches all)
+func MatchObjTags(tags, want cos.StrKVs) bool {
+	for k, v := range want {
+		if tv, ok := tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
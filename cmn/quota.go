@@ -0,0 +1,114 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket quotas: hard and soft limits on the bucket's total size and number of objects
+// (cluster-wide; zero - unlimited). Once a hard limit would be exceeded, targets fail
+// writes into the bucket (PUT, APPEND, copy, transform, promote) with
+// ErrQuotaExceeded; exceeding a soft limit is only logged and reported by bucket summary.
+// See also: core/quota.go (per-target usage) and ais/tgtquota.go (enforcement)
+
+type (
+	QuotaConf struct {
+		Size        cos.SizeIEC `json:"size,omitempty"`         // hard limit: total size of all objects
+		SoftSize    cos.SizeIEC `json:"soft_size,omitempty"`    // soft limit: ditto
+		Objects     int64       `json:"objects,omitempty"`      // hard limit: number of objects
+		SoftObjects int64       `json:"soft_objects,omitempty"` // soft limit: ditto
+	}
+	QuotaConfToSet struct {
+		Size        *cos.SizeIEC `json:"size,omitempty"`
+		SoftSize    *cos.SizeIEC `json:"soft_size,omitempty"`
+		Objects     *int64       `json:"objects,omitempty"`
+		SoftObjects *int64       `json:"soft_objects,omitempty"`
+	}
+
+	ErrQuotaExceeded struct {
+		bname string
+		what  string // "size" | "objects"
+		used  int64
+		limit int64
+	}
+)
+
+///////////////
+// QuotaConf //
+///////////////
+
+func (c *QuotaConf) IsSet() bool {
+	return c.Size > 0 || c.SoftSize > 0 || c.Objects > 0 || c.SoftObjects > 0
+}
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if c.Size < 0 || c.SoftSize < 0 || c.Objects < 0 || c.SoftObjects < 0 {
+		return errors.New("quota: limits cannot be negative")
+	}
+	if c.Size > 0 && c.SoftSize > c.Size {
+		return fmt.Errorf("quota: soft size limit %s exceeds hard limit %s", c.SoftSize, c.Size)
+	}
+	if c.Objects > 0 && c.SoftObjects > c.Objects {
+		return fmt.Errorf("quota: soft limit on the number of objects (%d) exceeds hard limit (%d)", c.SoftObjects, c.Objects)
+	}
+	return nil
+}
+
+// Check returns ErrQuotaExceeded if the given (resulting) usage exceeds any of the hard limits
+func (c *QuotaConf) Check(bname string, size, objs int64) error {
+	if c.Size > 0 && size > int64(c.Size) {
+		return &ErrQuotaExceeded{bname, "size", size, int64(c.Size)}
+	}
+	if c.Objects > 0 && objs > c.Objects {
+		return &ErrQuotaExceeded{bname, "objects", objs, c.Objects}
+	}
+	return nil
+}
+
+func (c *QuotaConf) SoftExceeded(size, objs int64) bool {
+	return (c.SoftSize > 0 && size > int64(c.SoftSize)) || (c.SoftObjects > 0 && objs > c.SoftObjects)
+}
+
+// usage of the most utilized limit (hard or, if there's no hard one, soft), in percent
+func (c *QuotaConf) Usage(size, objs int64) *apc.QuotaUsage {
+	qu := &apc.QuotaUsage{Size: int64(c.Size), Objects: c.Objects, SoftExceeded: c.SoftExceeded(size, objs)}
+	if l := _limit(int64(c.Size), int64(c.SoftSize)); l > 0 {
+		qu.Pct = size * 100 / l
+	}
+	if l := _limit(c.Objects, c.SoftObjects); l > 0 {
+		qu.Pct = max(qu.Pct, objs*100/l)
+	}
+	return qu
+}
+
+func _limit(hard, soft int64) int64 {
+	if hard > 0 {
+		return hard
+	}
+	return soft
+}
+
+//////////////////////
+// ErrQuotaExceeded //
+//////////////////////
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.what == "size" {
+		return fmt.Sprintf("bucket %s: quota exceeded (total size %s, limit %s)", e.bname,
+			cos.ToSizeIEC(e.used, 2), cos.ToSizeIEC(e.limit, 2))
+	}
+	return fmt.Sprintf("bucket %s: quota exceeded (number of objects %d, limit %d)", e.bname, e.used, e.limit)
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
@@ -0,0 +1,79 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Request rate limiting: token-bucket limits on the number of requests and the number of
+// bytes per second (zero - unlimited), configurable per bucket (bucket props) and per
+// AuthN user (carried by the user's token). The limits are enforced by each gateway
+// independently (before redirecting) and by each target - for the S3 requests that
+// target receives directly and, bytes only, for GETs. Once throttled, the request fails with ErrRateLimited
+// (HTTP 429, S3 "SlowDown").
+// See also: ais/ratelimit.go
+
+type (
+	RateLimitConf struct {
+		RPS int64       `json:"rps,omitempty"` // requests per second
+		BPS cos.SizeIEC `json:"bps,omitempty"` // bytes per second
+	}
+	RateLimitConfToSet struct {
+		RPS *int64       `json:"rps,omitempty"`
+		BPS *cos.SizeIEC `json:"bps,omitempty"`
+	}
+
+	ErrRateLimited struct {
+		what string // "bucket" | "user"
+		name string
+		bps  bool
+	}
+)
+
+///////////////////
+// RateLimitConf //
+///////////////////
+
+func (c *RateLimitConf) IsSet() bool { return c.RPS > 0 || c.BPS > 0 }
+
+func (c *RateLimitConf) ValidateAsProps(...any) error {
+	if c.RPS < 0 || c.BPS < 0 {
+		return errors.New("rate_limit: limits cannot be negative")
+	}
+	return nil
+}
+
+func (c *RateLimitConf) String() string {
+	if !c.IsSet() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("rps=%d, bps=%s", c.RPS, c.BPS)
+}
+
+////////////////////
+// ErrRateLimited //
+////////////////////
+
+func NewErrRateLimited(what, name string, bps bool) *ErrRateLimited {
+	return &ErrRateLimited{what, name, bps}
+}
+
+func (e *ErrRateLimited) Error() string {
+	s := "requests"
+	if e.bps {
+		s = "bytes"
+	}
+	return fmt.Sprintf("%s %s: too many %s per second, slow down", e.what, e.name, s)
+}
+
+func IsErrRateLimited(err error) bool {
+	_, ok := err.(*ErrRateLimited)
+	return ok
+}
@@ -24,6 +24,8 @@ type readMostly struct {
 	level, modules int
 	testingEnv     bool
 	authEnabled    bool
+	auditEnabled   bool
+	bckMetrics     BckMetricsConf
 }
 
 var Rom readMostly
@@ -42,6 +44,8 @@ func (rom *readMostly) Set(cfg *ClusterConfig) {
 	}
 	rom.features = cfg.Features
 	rom.authEnabled = cfg.Auth.Enabled
+	rom.auditEnabled = cfg.Audit.Enabled
+	rom.bckMetrics = cfg.BckMetrics
 
 	// pre-parse for FastV (below)
 	rom.level, rom.modules = cfg.Log.Level.Parse()
@@ -53,6 +57,8 @@ func (rom *readMostly) EcStreams() time.Duration       { return rom.timeout.ecst
 func (rom *readMostly) Features() feat.Flags           { return rom.features }
 func (rom *readMostly) TestingEnv() bool               { return rom.testingEnv }
 func (rom *readMostly) AuthEnabled() bool              { return rom.authEnabled }
+func (rom *readMostly) AuditEnabled() bool             { return rom.auditEnabled }
+func (rom *readMostly) BckMetrics() *BckMetricsConf    { return &rom.bckMetrics }
 
 func (rom *readMostly) FastV(verbosity, fl int) bool {
 	return rom.level >= verbosity || rom.modules&fl != 0
@@ -0,0 +1,28 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit", func() {
+	It("should validate", func() {
+		Expect((&cmn.BckAuditConf{}).ValidateAsProps()).NotTo(HaveOccurred())
+		Expect((&cmn.BckAuditConf{Mode: cmn.AuditDisabled}).ValidateAsProps()).NotTo(HaveOccurred())
+		Expect((&cmn.BckAuditConf{Mode: "on"}).ValidateAsProps()).To(HaveOccurred())
+		Expect((&cmn.AuditConf{HTTPSink: "https://audit.example.com/ingest"}).Validate()).NotTo(HaveOccurred())
+		Expect((&cmn.AuditConf{HTTPSink: "syslog://localhost"}).Validate()).To(HaveOccurred())
+	})
+
+	It("should override cluster-wide setting", func() {
+		Expect((&cmn.BckAuditConf{}).Enabled(true)).To(BeTrue())
+		Expect((&cmn.BckAuditConf{Mode: cmn.AuditInherit}).Enabled(false)).To(BeFalse())
+		Expect((&cmn.BckAuditConf{Mode: cmn.AuditEnabled}).Enabled(false)).To(BeTrue())
+		Expect((&cmn.BckAuditConf{Mode: cmn.AuditDisabled}).Enabled(true)).To(BeFalse())
+	})
+})
@@ -75,11 +75,26 @@ var _ = Describe("IterFields", func() {
 					"ec.objsize_limit":     int64(0),
 					"ec.compression":       "",
 					"ec.bundle_multiplier": 0,
+					"ec.scrub_interval":    cos.Duration(0),
+					"ec.domain_tolerance":  0,
 					"ec.disk_only":         false,
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
 					"versioning.synchronize":       false,
+					"versioning.keep_prior":        0,
+
+					"object_lock.mode":       "",
+					"object_lock.retention":  cos.Duration(0),
+					"object_lock.enabled":    false,
+					"object_lock.legal_hold": false,
+					"quota.size":             cos.SizeIEC(0),
+					"quota.soft_size":        cos.SizeIEC(0),
+					"quota.objects":          int64(0),
+					"quota.soft_objects":     int64(0),
+					"rate_limit.rps":         int64(0),
+					"rate_limit.bps":         cos.SizeIEC(0),
+					"audit.mode":             "",
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -90,6 +105,9 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           false,
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
+					"lru.policy":            "",
+					"lru.priority":          0,
+					"lru.remote_only":       false,
 
 					"extra.aws.cloud_region": "us-central",
 					"extra.aws.endpoint":     "",
@@ -134,11 +152,26 @@ var _ = Describe("IterFields", func() {
 					"ec.objsize_limit":     (*int64)(nil),
 					"ec.compression":       (*string)(nil),
 					"ec.bundle_multiplier": (*int)(nil),
+					"ec.scrub_interval":    (*cos.Duration)(nil),
+					"ec.domain_tolerance":  (*int)(nil),
 					"ec.disk_only":         (*bool)(nil),
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
 					"versioning.synchronize":       (*bool)(nil),
+					"versioning.keep_prior":        (*int)(nil),
+
+					"object_lock.mode":       (*string)(nil),
+					"object_lock.retention":  (*cos.Duration)(nil),
+					"object_lock.enabled":    (*bool)(nil),
+					"object_lock.legal_hold": (*bool)(nil),
+					"quota.size":             (*cos.SizeIEC)(nil),
+					"quota.soft_size":        (*cos.SizeIEC)(nil),
+					"quota.objects":          (*int64)(nil),
+					"quota.soft_objects":     (*int64)(nil),
+					"rate_limit.rps":         (*int64)(nil),
+					"rate_limit.bps":         (*cos.SizeIEC)(nil),
+					"audit.mode":             (*string)(nil),
 
 					"checksum.type":              apc.Ptr(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -149,6 +182,9 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           (*bool)(nil),
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
+					"lru.policy":            (*string)(nil),
+					"lru.priority":          (*int)(nil),
+					"lru.remote_only":       (*bool)(nil),
 
 					"access":   apc.Ptr[apc.AccessAttrs](1024),
 					"features": apc.Ptr[feat.Flags](1024),
@@ -0,0 +1,43 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quota", func() {
+	conf := cmn.QuotaConf{Size: 10 * cos.MiB, SoftSize: 8 * cos.MiB, Objects: 100}
+
+	It("should validate", func() {
+		Expect(conf.ValidateAsProps()).NotTo(HaveOccurred())
+		Expect((&cmn.QuotaConf{}).IsSet()).To(BeFalse())
+		Expect((&cmn.QuotaConf{Size: cos.MiB, SoftSize: 2 * cos.MiB}).ValidateAsProps()).To(HaveOccurred())
+		Expect((&cmn.QuotaConf{Objects: 10, SoftObjects: 11}).ValidateAsProps()).To(HaveOccurred())
+		Expect((&cmn.QuotaConf{Objects: -1}).ValidateAsProps()).To(HaveOccurred())
+		Expect((&cmn.QuotaConf{SoftObjects: 11}).ValidateAsProps()).NotTo(HaveOccurred())
+	})
+
+	It("should enforce hard limits", func() {
+		Expect(conf.Check("ais://b", 10*cos.MiB, 100)).NotTo(HaveOccurred())
+		err := conf.Check("ais://b", 10*cos.MiB+1, 1)
+		Expect(cmn.IsErrQuotaExceeded(err)).To(BeTrue())
+		err = conf.Check("ais://b", 1, 101)
+		Expect(cmn.IsErrQuotaExceeded(err)).To(BeTrue())
+	})
+
+	It("should report usage", func() {
+		Expect(conf.SoftExceeded(9*cos.MiB, 1)).To(BeTrue())
+		qu := conf.Usage(5*cos.MiB, 80)
+		Expect(qu.Pct).To(BeEquivalentTo(80))
+		Expect(qu.SoftExceeded).To(BeFalse())
+		qu = (&cmn.QuotaConf{SoftSize: 4 * cos.MiB}).Usage(5*cos.MiB, 80)
+		Expect(qu.Pct).To(BeEquivalentTo(125))
+		Expect(qu.SoftExceeded).To(BeTrue())
+	})
+})
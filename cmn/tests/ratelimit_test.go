@@ -0,0 +1,56 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimit", func() {
+	It("should validate", func() {
+		Expect((&cmn.RateLimitConf{}).IsSet()).To(BeFalse())
+		Expect((&cmn.RateLimitConf{RPS: 100, BPS: cos.MiB}).ValidateAsProps()).NotTo(HaveOccurred())
+		Expect((&cmn.RateLimitConf{RPS: -1}).ValidateAsProps()).To(HaveOccurred())
+		Expect(cmn.IsErrRateLimited(cmn.NewErrRateLimited("bucket", "ais://b", false))).To(BeTrue())
+	})
+
+	It("should throttle and refill", func() {
+		tb := cos.NewTokenBucket(10)
+		for range 10 {
+			Expect(tb.TryTake(1)).To(BeTrue())
+		}
+		Expect(tb.TryTake(1)).To(BeFalse())
+		time.Sleep(200 * time.Millisecond)
+		Expect(tb.TryTake(1)).To(BeTrue())
+	})
+
+	It("should go into debt", func() {
+		tb := cos.NewTokenBucket(cos.KiB)
+		Expect(tb.TryTake(4 * cos.KiB)).To(BeTrue()) // (larger than the burst)
+		Expect(tb.TryTake(1)).To(BeFalse())
+		tb = cos.NewTokenBucket(10)
+		tb.Take(100)
+		time.Sleep(200 * time.Millisecond)
+		Expect(tb.TryTake(1)).To(BeFalse())
+	})
+
+	It("should return tokens", func() {
+		tb := cos.NewTokenBucket(10)
+		Expect(tb.TryTake(10)).To(BeTrue())
+		Expect(tb.TryTake(1)).To(BeFalse())
+		tb.Put(5)
+		Expect(tb.TryTake(5)).To(BeTrue())
+		tb.Put(100) // (up to the burst)
+		for range 10 {
+			Expect(tb.TryTake(1)).To(BeTrue())
+		}
+		Expect(tb.TryTake(1)).To(BeFalse())
+	})
+})
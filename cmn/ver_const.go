@@ -33,11 +33,13 @@ const (
 )
 
 const (
-	MetaverSmap  = 2 // Smap (cluster map) formatting version a.k.a. meta-version (see core/meta/jsp.go)
-	MetaverBMD   = 2 // BMD (bucket metadata) --/--
-	MetaverRMD   = 1 // Rebalance MD (jsp)
-	MetaverVMD   = 2 // Volume MD (jsp)
-	MetaverEtlMD = 1 // ETL MD (jsp)
+	MetaverSmap    = 2 // Smap (cluster map) formatting version a.k.a. meta-version (see core/meta/jsp.go)
+	MetaverBMD     = 2 // BMD (bucket metadata) --/--
+	MetaverRMD     = 1 // Rebalance MD (jsp)
+	MetaverRebCkpt = 1 // (target) rebalance checkpoint (jsp)
+	MetaverTarIdx  = 1 // (target) .tar index (jsp)
+	MetaverVMD     = 2 // Volume MD (jsp)
+	MetaverEtlMD   = 1 // ETL MD (jsp)
 
 	MetaverLOM   = 1 // LOM
 	MetaverChunk = 2 // LOM chunk
@@ -45,6 +47,9 @@ const (
 	MetaverConfig      = 4 // Global Configuration (jsp)
 	MetaverAuthNConfig = 1 // Authn config (jsp) // ditto
 	MetaverAuthTokens  = 1 // Authn tokens (jsp) // ditto
+	MetaverS3Keys      = 1 // AuthN-managed S3 access keys (jsp)
+
+	MetaverMpt = 1 // S3 multipart upload manifest (jsp)
 
 	MetaverMetasync = 1 // metasync over network formatting version (jsp)
 
@@ -120,8 +120,20 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 		// NOTE: making "rlock" exception to be able to forcefully rm corrupted object in the GET path
 		return len(force) > 0 && force[0] && lom.isLockedRW()
 	})
+	var (
+		u     = lom.Usage()
+		osize = int64(-1)
+	)
+	if u != nil {
+		if finfo, errS := os.Stat(lom.FQN); errS == nil {
+			osize = finfo.Size()
+		}
+	}
 	lom.Uncache()
 	err = lom.RemoveMain()
+	if err == nil && osize >= 0 {
+		u.Add(-osize, -1)
+	}
 	for copyFQN := range lom.md.copies {
 		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) && err == nil {
 			err = erc
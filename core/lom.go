@@ -53,6 +53,7 @@ type (
 		cmn.ObjAttrs
 		atimefs uint64 // (high bit `lomDirtyMask` | int64: atime)
 		lid     lomBID
+		acnt    uint64 // access count (LFU eviction)
 	}
 	LOM struct {
 		mi      *fs.Mountpath
@@ -72,6 +73,7 @@ type (
 		maxLmeta atomic.Int64
 		locker   nameLocker
 		lchk     lchk
+		tcache   tidxCache
 	}
 )
 
@@ -176,6 +178,15 @@ func (lom *LOM) Atime() time.Time      { return time.Unix(0, lom.md.Atime) }
 func (lom *LOM) AtimeUnix() int64      { return lom.md.Atime }
 func (lom *LOM) SetAtimeUnix(tu int64) { lom.md.Atime = tu }
 
+// number of GETs (since the object was written), maintained only for buckets
+// with cmn.LRUPolicyLFU eviction policy; persisted lazily, along with atime
+func (lom *LOM) AccessCount() uint64 { return lom.md.acnt }
+
+func (lom *LOM) IncAccessCount() {
+	lom.md.acnt++
+	lom.md.makeDirty()
+}
+
 func (lom *LOM) bid() uint64             { return lom.md.lid.bid() }
 func (lom *LOM) setbid(bpropsBID uint64) { lom.md.lid = lom.md.lid.setbid(bpropsBID) }
 
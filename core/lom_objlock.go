@@ -0,0 +1,53 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// CheckObjLock returns cmn.ErrObjLocked if the existing object (that is about to be
+// overwritten, deleted, or renamed) is under retention or legal hold (see cmn/objlock.go).
+// Reads on-disk metadata regardless of whether the LOM is loaded; expecting the caller
+// to lock the object.
+func (lom *LOM) CheckObjLock() error {
+	conf := &lom.Bprops().ObjLock
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.LegalHold {
+		return conf.Check(lom.Cname(), nil, time.Time{})
+	}
+	if _, err := os.Stat(lom.FQN); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	md, err := lom.lmfs(false)
+	if err != nil {
+		if cmn.IsErrLmetaNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return conf.Check(lom.Cname(), md.GetCustomMD(), time.Now())
+}
+
+// SetRetention sets the default retention (if configured) of a new object,
+// unless already specified by the client
+func (lom *LOM) SetRetention(now time.Time) {
+	until := lom.Bprops().ObjLock.DefaultRetainUntil(now)
+	if until.IsZero() {
+		return
+	}
+	if cur, ok := cmn.RetainUntil(lom.GetCustomMD()); ok && cur.After(now) {
+		return
+	}
+	lom.SetCustomKey(cmn.RetainUntilObjMD, cmn.FmtRetainUntil(until))
+}
@@ -0,0 +1,174 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Prior object versions (ais buckets only, see cmn.VersionConf.KeepPrior):
+// upon overwrite, the current object is retained on its mountpath as fs.PriorVerType
+// content named <obj-name>.<version>, with its (unchanged) metadata.
+// Prior versions are not mirrored, erasure coded, or migrated by global rebalance.
+
+type PriorVer struct {
+	FQN     string
+	Version string
+	Mtime   time.Time
+	Size    int64
+	num     int64
+}
+
+func (lom *LOM) PriorFQN(version string) string {
+	return fs.CSM.Gen(lom, fs.PriorVerType, version)
+}
+
+// RetainPrior is called under exclusive lock prior to overwriting an existing object;
+// it renames the latter to its prior-version FQN and prunes the oldest prior versions.
+// Returns the resulting prior-version FQN (empty when there was nothing to retain),
+// to be reverted via RevertPrior if the overwrite fails.
+// Also (and always) makes sure that the version to follow is greater than all prior ones.
+func (lom *LOM) RetainPrior(keep int) (pfqn string, err error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	if err = cos.Stat(lom.FQN); err != nil {
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		// new object or a re-PUT of a deleted one
+		if priors, _ := lom.Priors(); len(priors) > 0 {
+			lom.SetVersion(priors[0].Version)
+		}
+		return "", nil
+	}
+	md, err := lom.lmfs(false)
+	if err != nil {
+		return "", err
+	}
+	ver := md.Version()
+	if ver == "" {
+		return "", nil
+	}
+	if lom.md.Version() == "" { // (not loaded)
+		lom.SetVersion(ver)
+	}
+	pfqn = lom.PriorFQN(ver)
+	if err = cos.Rename(lom.FQN, pfqn); err != nil {
+		return "", err
+	}
+	lom.prunePriors(keep)
+	return pfqn, nil
+}
+
+func (lom *LOM) RevertPrior(pfqn string) {
+	if err := cos.Rename(pfqn, lom.FQN); err != nil {
+		nlog.Errorln("failed to revert prior version", pfqn, "of", lom.Cname(), "err:", err)
+	}
+}
+
+// returns prior versions of this object, most recent first
+func (lom *LOM) Priors() ([]*PriorVer, error) {
+	var (
+		pfqn   = lom.PriorFQN("0")
+		dir    = filepath.Dir(pfqn)
+		prefix = filepath.Base(pfqn[:len(pfqn)-1]) // "<obj-basename>."
+	)
+	dentries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var priors []*PriorVer
+	for _, de := range dentries {
+		name := de.Name()
+		if de.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		ver := name[len(prefix):]
+		num, err := strconv.ParseInt(ver, 10, 64)
+		if err != nil {
+			continue
+		}
+		finfo, err := de.Info()
+		if err != nil {
+			continue
+		}
+		priors = append(priors, &PriorVer{
+			FQN:     filepath.Join(dir, name),
+			Version: ver,
+			Mtime:   finfo.ModTime(),
+			Size:    finfo.Size(),
+			num:     num,
+		})
+	}
+	sort.Slice(priors, func(i, j int) bool { return priors[i].num > priors[j].num })
+	return priors, nil
+}
+
+func (lom *LOM) prunePriors(keep int) {
+	priors, err := lom.Priors()
+	if err != nil {
+		nlog.Warningln("failed to list prior versions of", lom.Cname(), "err:", err)
+		return
+	}
+	conf := &lom.Bprops().ObjLock
+	for i := keep; i < len(priors); i++ {
+		if conf.Enabled {
+			// (locked prior versions are retained regardless)
+			if oa, err := LoadPriorAttrs(priors[i].FQN); err == nil && conf.Check("", oa.GetCustomMD(), time.Now()) != nil {
+				continue
+			}
+		}
+		if err := lom.RemovePrior(priors[i].FQN); err != nil && !os.IsNotExist(err) {
+			nlog.Warningln("failed to remove prior version", priors[i].FQN, "err:", err)
+		}
+	}
+}
+
+// RemovePrior removes a given prior version (under exclusive lock) and updates
+// the bucket's usage, if tracked (see core/quota.go)
+func (lom *LOM) RemovePrior(pfqn string) error {
+	var (
+		u     = lom.Usage()
+		psize = int64(-1)
+	)
+	if u != nil {
+		if finfo, err := os.Stat(pfqn); err == nil {
+			psize = finfo.Size()
+		}
+	}
+	if err := os.Remove(pfqn); err != nil {
+		return err
+	}
+	if psize >= 0 {
+		u.Add(-psize, 0)
+	}
+	return nil
+}
+
+// LoadPriorAttrs returns stored metadata of a given prior version
+func LoadPriorAttrs(pfqn string) (*cmn.ObjAttrs, error) {
+	b, err := fs.GetXattr(pfqn, XattrLOM)
+	if err != nil {
+		return nil, err
+	}
+	md := &lmeta{}
+	if err := md.unpack(b); err != nil {
+		return nil, cmn.NewErrLmetaCorrupted(err)
+	}
+	return &md.ObjAttrs, nil
+}
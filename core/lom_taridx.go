@@ -0,0 +1,151 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// TAR index (see archive.TarIndex):
+// - built upon the first archpath read (or listing) of a given .tar object
+//   and stored next to it, on the same mountpath, as fs.TarIdxType;
+// - valid only as long as the object's size and mtime remain unchanged,
+//   and explicitly removed upon APPEND (see archive.OpenTarForAppend);
+// - not replicated, not erasure coded, and not migrated by rebalance - rebuilt when needed;
+// - recently used indexes are also kept in memory (decoded), subject to the limits below.
+
+const (
+	tidxCacheMaxN       = 1024        // max number of cached indexes
+	tidxCacheMaxEntries = 1024 * 1024 // max total number of their entries
+)
+
+type (
+	tarIdx archive.TarIndex
+
+	tidxEnt struct {
+		idx   *archive.TarIndex
+		atime int64
+	}
+	tidxCache struct {
+		m    map[string]*tidxEnt // by uname
+		mu   sync.Mutex
+		size int // total number of cached entries
+	}
+)
+
+// interface guard
+var _ jsp.Opts = (*tarIdx)(nil)
+
+func (*tarIdx) JspOpts() jsp.Options { return jsp.CCSign(cmn.MetaverTarIdx) }
+
+func (lom *LOM) TarIdxFQN() string { return fs.CSM.Gen(lom, fs.TarIdxType, "") }
+
+// TarIndex loads the object's TAR index or, if there's none (or it's stale),
+// builds and stores a new one; `fh` is the open (and rlocked) object itself
+func (lom *LOM) TarIndex(fh *os.File) (*archive.TarIndex, error) {
+	var (
+		uname = lom.Uname()
+		fqn   = lom.TarIdxFQN()
+		idx   = &tarIdx{}
+	)
+	if aidx := g.tcache.get(uname); aidx != nil && aidx.Valid(fh) {
+		return aidx, nil
+	}
+	if _, err := jsp.LoadMeta(fqn, idx); err == nil {
+		if aidx := (*archive.TarIndex)(idx); aidx.Valid(fh) {
+			aidx.Init()
+			g.tcache.put(uname, aidx)
+			return aidx, nil
+		}
+	} else if !os.IsNotExist(err) {
+		nlog.Warningln("failed to load tar index of", lom.Cname(), "err:", err)
+	}
+	aidx, err := archive.BuildTarIndex(fh)
+	if err != nil {
+		return nil, err
+	}
+	// (a failure to persist is not fatal)
+	if err := jsp.SaveMeta(fqn, (*tarIdx)(aidx), nil /*wto*/); err != nil {
+		nlog.Warningln("failed to store tar index of", lom.Cname(), "err:", err)
+	}
+	aidx.Init()
+	g.tcache.put(uname, aidx)
+	return aidx, nil
+}
+
+func (lom *LOM) RemoveTarIdx() {
+	g.tcache.del(lom.Uname())
+	if err := cos.RemoveFile(lom.TarIdxFQN()); err != nil {
+		nlog.Warningln("failed to remove tar index of", lom.Cname(), "err:", err)
+	}
+}
+
+//
+// tidxCache: cached indexes are read-only (and initialized) - safe to share
+//
+
+func (c *tidxCache) get(uname string) (idx *archive.TarIndex) {
+	c.mu.Lock()
+	if e, ok := c.m[uname]; ok {
+		e.atime = mono.NanoTime()
+		idx = e.idx
+	}
+	c.mu.Unlock()
+	return idx
+}
+
+func (c *tidxCache) put(uname string, idx *archive.TarIndex) {
+	n := len(idx.Entries)
+	if n > tidxCacheMaxEntries {
+		return
+	}
+	c.mu.Lock()
+	if c.m == nil {
+		c.m = make(map[string]*tidxEnt, 64)
+	}
+	c._del(uname)
+	for len(c.m) > 0 && (len(c.m) >= tidxCacheMaxN || c.size+n > tidxCacheMaxEntries) {
+		c._evict()
+	}
+	c.m[uname] = &tidxEnt{idx: idx, atime: mono.NanoTime()}
+	c.size += n
+	c.mu.Unlock()
+}
+
+func (c *tidxCache) del(uname string) {
+	c.mu.Lock()
+	c._del(uname)
+	c.mu.Unlock()
+}
+
+func (c *tidxCache) _del(uname string) {
+	if e, ok := c.m[uname]; ok {
+		c.size -= len(e.idx.Entries)
+		delete(c.m, uname)
+	}
+}
+
+// least recently used (linear scan - bounded by tidxCacheMaxN)
+func (c *tidxCache) _evict() {
+	var (
+		oldest string
+		atime  int64
+	)
+	for uname, e := range c.m {
+		if oldest == "" || e.atime < atime {
+			oldest, atime = uname, e.atime
+		}
+	}
+	c._del(oldest)
+}
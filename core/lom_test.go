@@ -6,12 +6,14 @@
 package core_test
 
 import (
+	"archive/tar"
 	cryptorand "crypto/rand"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -71,6 +73,7 @@ var _ = Describe("LOM", func() {
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.TarIdxType, &fs.TarIdxContentResolver{}, true)
 
 	bmd := mock.NewBaseBownerMock(
 		meta.NewBck(
@@ -995,6 +998,109 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("TarIndex", func() {
+		var (
+			longName = strings.Repeat("long/", 30) + "name.txt" // (PAX header)
+			files    = map[string]string{
+				"a.txt":     "aaa",
+				"dir/b.txt": "bbbbbbbbbbbbbbbbbbbb",
+				longName:    "ccc",
+				"empty":     "",
+			}
+		)
+		writeTar := func(fqn string, names ...string) {
+			fh, err := cos.CreateFile(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			tw := tar.NewWriter(fh)
+			Expect(tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755})).To(Succeed())
+			for _, name := range names {
+				content := files[name]
+				hdr := &tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(content)), Mode: 0o644}
+				Expect(tw.WriteHeader(hdr)).To(Succeed())
+				_, err := tw.Write([]byte(content))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(tw.Close()).To(Succeed())
+			Expect(fh.Close()).To(Succeed())
+		}
+		readAll := func(csl cos.ReadCloseSizer) string {
+			b, err := io.ReadAll(csl)
+			Expect(err).NotTo(HaveOccurred())
+			csl.Close()
+			return string(b)
+		}
+
+		It("should build, store, and use tar index", func() {
+			lom := &core.LOM{ObjName: "shard.tar"}
+			Expect(lom.InitBck(&localBckA)).To(Succeed())
+			writeTar(lom.FQN, "a.txt", "dir/b.txt", longName, "empty")
+
+			fh, err := os.Open(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			defer fh.Close()
+			idx, err := lom.TarIndex(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.TarIdxFQN()).To(BeARegularFile())
+
+			for name, content := range files {
+				csl := idx.ReadOne(fh, name)
+				Expect(csl).NotTo(BeNil(), name)
+				Expect(readAll(csl)).To(Equal(content))
+			}
+			Expect(readAll(idx.ReadOne(fh, "/a.txt"))).To(Equal(files["a.txt"]))
+			Expect(idx.ReadOne(fh, "does-not-exist")).To(BeNil())
+
+			lst := idx.List()
+			Expect(lst).To(HaveLen(len(files)))
+			for i := 1; i < len(lst); i++ {
+				Expect(lst[i-1].Name < lst[i].Name).To(BeTrue())
+			}
+
+			// in-memory
+			again, err := lom.TarIndex(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(BeIdenticalTo(idx))
+
+			// load (upon removal from memory)
+			stored, err := os.ReadFile(lom.TarIdxFQN())
+			Expect(err).NotTo(HaveOccurred())
+			lom.RemoveTarIdx()
+			Expect(os.WriteFile(lom.TarIdxFQN(), stored, 0o644)).To(Succeed())
+			again, err = lom.TarIndex(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).NotTo(BeIdenticalTo(idx))
+			Expect(again.Entries).To(Equal(idx.Entries))
+			Expect(readAll(again.ReadOne(fh, longName))).To(Equal(files[longName]))
+
+			lom.RemoveTarIdx()
+			Expect(lom.TarIdxFQN()).NotTo(BeAnExistingFile())
+		})
+
+		It("should rebuild stale tar index", func() {
+			lom := &core.LOM{ObjName: "stale.tar"}
+			Expect(lom.InitBck(&localBckA)).To(Succeed())
+			writeTar(lom.FQN, "a.txt")
+
+			fh, err := os.Open(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			idx, err := lom.TarIndex(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idx.ReadOne(fh, "dir/b.txt")).To(BeNil())
+			fh.Close()
+
+			// overwrite
+			writeTar(lom.FQN, "a.txt", "dir/b.txt")
+			fh, err = os.Open(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			defer fh.Close()
+			Expect(idx.Valid(fh)).To(BeFalse())
+
+			idx, err = lom.TarIndex(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readAll(idx.ReadOne(fh, "dir/b.txt"))).To(Equal(files["dir/b.txt"]))
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
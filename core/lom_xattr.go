@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -76,6 +77,8 @@ const (
 	packedCustom
 	packedNum
 	packedChunk
+	packedAccess // NOTE: metadata written by older versions has no access count (zero); conversely,
+	// older versions fail to load metadata that has it (i.e., no downgrade once LFU counted any)
 )
 
 // packing format: separators
@@ -401,6 +404,12 @@ func (md *lmeta) unpack(buf []byte) error {
 				}
 				md.copies[copyFQN] = mpathInfo
 			}
+		case packedAccess:
+			n, err := strconv.ParseUint(string(record[cos.SizeofI16:]), 10, 64)
+			if err != nil {
+				return errors.New(badLmeta + " #6.1")
+			}
+			md.acnt = n
 		case packedCustom:
 			val := string(record[cos.SizeofI16:])
 			entries := strings.Split(val, customSepa)
@@ -449,6 +458,12 @@ func (md *lmeta) pack(mdSize int64) (buf []byte) {
 		buf = _packCopies(buf, md.copies)
 	}
 
+	// access count (decimal; written only when maintained - see LOM.IncAccessCount)
+	if md.acnt > 0 {
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedAccess, strconv.FormatUint(md.acnt, 10), false)
+	}
+
 	// custom md
 	if custom := md.GetCustomMD(); len(custom) > 0 {
 		buf = g.smm.Append(buf, recordSepa)
@@ -257,6 +257,37 @@ var _ = Describe("LOM Xattributes", func() {
 			})
 		})
 
+		Describe("AccessCount", func() {
+			It("should persist access count and load metadata without it", func() {
+				lom := filePut(localFQN, testFileSize)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+
+				// same as written by older versions (that do not count accesses)
+				b, err := fs.GetXattr(localFQN, core.XattrLOM)
+				Expect(err).NotTo(HaveOccurred())
+				newLom := NewBasicLom(localFQN)
+				Expect(newLom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(newLom.AccessCount()).To(BeZero())
+
+				for range 3 {
+					lom.IncAccessCount()
+				}
+				Expect(persist(lom)).NotTo(HaveOccurred())
+				newLom = NewBasicLom(localFQN)
+				Expect(newLom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(newLom.AccessCount()).To(BeEquivalentTo(3))
+				Expect(newLom.Version()).To(Equal(lom.Version()))
+				Expect(newLom.Lsize()).To(BeEquivalentTo(testFileSize))
+
+				Expect(fs.SetXattr(localFQN, core.XattrLOM, b)).NotTo(HaveOccurred())
+				newLom = NewBasicLom(localFQN)
+				Expect(newLom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(newLom.AccessCount()).To(BeZero())
+				Expect(newLom.Lsize()).To(BeEquivalentTo(testFileSize))
+			})
+		})
+
 		Describe("LoadMetaFromFS", func() {
 			It("should read fresh meta from fs", func() {
 				createTestFile(localFQN, testFileSize)
@@ -151,6 +151,10 @@ type hrwList struct {
 // returns resulting subset (aka slice) that has the requested length = count.
 // Returns error if the cluster does not have enough targets.
 // If count == length of Smap.Tmap, the function returns as many targets as possible.
+//
+// When the targets are labeled with (more than one) failure domains (see Snode.Domain),
+// the resulting list is spread across the domains - see spreadDomains below.
+// Either way, the first target in the list is always the HRW (i.e., main) one.
 
 func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error) {
 	const fmterr = "%v: required %d, available %d, %s"
@@ -161,7 +165,11 @@ func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error)
 	}
 	b := cos.UnsafeBptr(uname)
 	digest := xxhash.Checksum64S(*b, cos.MLCG32)
+	multi := smap.multiDomain()
 	hlist := newHrwList(count)
+	if multi {
+		hlist = newHrwList(cnt) // all of them
+	}
 
 	for _, tsi := range smap.Tmap {
 		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
@@ -175,9 +183,38 @@ func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error)
 		err = fmt.Errorf(fmterr, cmn.ErrNotEnoughTargets, count, len(sis), smap)
 		return nil, err
 	}
+	if multi {
+		sis = spreadDomains(sis, min(count, len(sis)))
+	}
 	return sis, nil
 }
 
+// Given all targets sorted by HRW, selects `count` of them in multiple passes,
+// with each pass taking (in HRW order) at most one more target per failure domain -
+// to minimize the maximum number of selected targets in any one domain
+// (e.g., with 3 domains, 6 EC slices get placed two per domain).
+func spreadDomains(all Nodes, count int) (sis Nodes) {
+	var (
+		taken   = make([]bool, len(all))
+		domains = make(map[string]int, 4)
+	)
+	sis = make(Nodes, 0, count)
+	for limit := 1; len(sis) < count; limit++ {
+		for i, tsi := range all {
+			if taken[i] || domains[tsi.Domain] >= limit {
+				continue
+			}
+			taken[i] = true
+			domains[tsi.Domain]++
+			sis = append(sis, tsi)
+			if len(sis) == count {
+				break
+			}
+		}
+	}
+	return sis
+}
+
 func newHrwList(count int) *hrwList {
 	return &hrwList{hs: make([]uint64, 0, count), sis: make(Nodes, 0, count), n: count}
 }
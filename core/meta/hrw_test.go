@@ -0,0 +1,94 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW", func() {
+	// numTargets targets, round-robin across numDomains (no labels when zero)
+	newSmap := func(numTargets, numDomains int) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, numTargets), Pmap: make(meta.NodeMap)}
+		for i := range numTargets {
+			tsi := &meta.Snode{}
+			if numDomains > 0 {
+				tsi.Domain = fmt.Sprintf("rack%d", i%numDomains)
+			}
+			tsi.Init(fmt.Sprintf("t%02d", i), apc.Target)
+			smap.Tmap[tsi.ID()] = tsi
+		}
+		return smap
+	}
+	perDomain := func(sis meta.Nodes) map[string]int {
+		domains := make(map[string]int)
+		for _, tsi := range sis {
+			domains[tsi.Domain]++
+		}
+		return domains
+	}
+
+	Describe("CountDomains", func() {
+		It("should count targets without labels as one domain", func() {
+			Expect(newSmap(6, 0).CountDomains()).To(Equal(1))
+			Expect(newSmap(6, 3).CountDomains()).To(Equal(3))
+		})
+
+		It("should skip targets in maintenance", func() {
+			smap := newSmap(3, 3)
+			smap.Tmap["t02"].Flags = meta.SnodeMaint
+			Expect(smap.CountDomains()).To(Equal(2))
+		})
+	})
+
+	Describe("HrwTargetList", func() {
+		It("should return plain HRW order without failure domains", func() {
+			smap := newSmap(10, 0)
+			for i := range 100 {
+				uname := fmt.Sprintf("bucket/obj-%d", i)
+				sis, err := smap.HrwTargetList(&uname, 4)
+				Expect(err).NotTo(HaveOccurred())
+				all, err := smap.HrwTargetList(&uname, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sis).To(Equal(all[:4]))
+			}
+		})
+
+		DescribeTable("should spread targets across failure domains",
+			func(numTargets, numDomains, count, maxPerDomain int) {
+				smap := newSmap(numTargets, numDomains)
+				for i := range 100 {
+					uname := fmt.Sprintf("bucket/obj-%d", i)
+					sis, err := smap.HrwTargetList(&uname, count)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(sis).To(HaveLen(count))
+
+					// the main target is always the HRW one
+					tsi, err := smap.HrwName2T([]byte(uname))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(sis[0].ID()).To(Equal(tsi.ID()))
+
+					ids := make(map[string]struct{}, count)
+					for _, tsi := range sis {
+						ids[tsi.ID()] = struct{}{}
+					}
+					Expect(ids).To(HaveLen(count))
+					for _, n := range perDomain(sis) {
+						Expect(n).To(BeNumerically("<=", maxPerDomain))
+					}
+				}
+			},
+			Entry("one per domain", 9, 3, 3, 1),
+			Entry("EC 2:1 + main, 4 domains", 12, 4, 4, 1),
+			Entry("EC 4:2 + main, 3 domains", 12, 3, 7, 3),
+			Entry("more domains than needed", 10, 10, 3, 1),
+		)
+	})
+})
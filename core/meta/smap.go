@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -75,6 +76,7 @@ type (
 		ControlNet NetInfo      `json:"intra_control_net"` // cmn.NetIntraControl
 		DaeType    string       `json:"daemon_type"`       // "target" or "proxy"
 		DaeID      string       `json:"daemon_id"`
+		Domain     string       `json:"domain,omitempty"` // failure domain (e.g., rack or zone); see HrwTargetList
 		name       string       // cached
 		Flags      cos.BitFlags `json:"flags"` // enum { SnodeNonElectable, SnodeIC, ... }
 		idDigest   uint64       // cached
@@ -185,6 +187,9 @@ func (d *Snode) Eq(o *Snode) (eq bool) {
 		if err := d.NetEq(o); err != nil {
 			nlog.Warningln(err)
 			eq = false
+		} else if d.Domain != o.Domain {
+			nlog.Warningf("%s: failure domain changed from %q to %q", d.StringEx(), d.Domain, o.Domain)
+			eq = false
 		}
 	}
 	return eq
@@ -451,6 +456,36 @@ func (m *Smap) CountActiveTs() (count int) {
 }
 
 // whether this target has active peers
+// number of distinct failure domains across active targets
+// (targets without domain label all count as one)
+func (m *Smap) CountDomains() int {
+	var domains []string
+	for _, t := range m.Tmap {
+		if !t.InMaintOrDecomm() && !slices.Contains(domains, t.Domain) {
+			domains = append(domains, t.Domain)
+		}
+	}
+	return len(domains)
+}
+
+// whether active targets span more than one failure domain
+func (m *Smap) multiDomain() bool {
+	var (
+		first  string
+		active bool
+	)
+	for _, t := range m.Tmap {
+		switch {
+		case t.InMaintOrDecomm():
+		case !active:
+			first, active = t.Domain, true
+		case t.Domain != first:
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Smap) HasActiveTs(except string) bool {
 	for tid, t := range m.Tmap {
 		if tid == except || t.InMaintOrDecomm() {
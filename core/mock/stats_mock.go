@@ -5,6 +5,7 @@
 package mock
 
 import (
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/stats"
@@ -36,3 +37,4 @@ func (*StatsTracker) GetStats() *stats.Node
 func (*StatsTracker) GetStatsV322() *stats.NodeV322                             { return nil }
 func (*StatsTracker) ResetStats(bool)                                           {}
 func (*StatsTracker) IsPrometheus() bool                                        { return false }
+func (*StatsTracker) AddBck(stats.BckOp, *cmn.Bck, string, int64, int64)        {}
@@ -0,0 +1,161 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Bucket quotas (see cmn/quota.go): this target's usage of the buckets that have quotas.
+// The usage is computed by walking the bucket when first needed and is then maintained
+// incrementally - by the writers (that first reserve and then commit or roll back - see
+// ais/tgtquota.go), by LOM.RemoveObj, and by LOM.RemovePrior.
+// Retained prior versions (see core/lom_prior.go) count toward the size but not the number of objects.
+// Given that objects also come and go in other ways (e.g., resilvering, EC recovery) and
+// that concurrent updates may get lost during the walk, the usage is periodically recomputed.
+
+const usageRefresh = 30 * time.Minute
+
+type BckUsage struct {
+	size    atomic.Int64
+	objs    atomic.Int64
+	walked  atomic.Int64 // mono time of the last completed walk
+	walking atomic.Bool
+}
+
+var usages sync.Map // bucket ID => *BckUsage
+
+// returns nil if the bucket has no quota
+func (lom *LOM) Usage() *BckUsage {
+	if lom.bck.Props == nil || !lom.bck.Props.Quota.IsSet() {
+		return nil
+	}
+	return GetUsage(&lom.bck)
+}
+
+// get or create (and start computing)
+func GetUsage(bck *meta.Bck) *BckUsage {
+	v, ok := usages.Load(bck.Props.BID)
+	if !ok {
+		v, _ = usages.LoadOrStore(bck.Props.BID, &BckUsage{})
+	}
+	u := v.(*BckUsage)
+	u.refresh(bck)
+	return u
+}
+
+// usage of all buckets with quotas (bucket ID => [size, number of objects])
+func AllUsages() map[uint64][2]int64 {
+	all := make(map[uint64][2]int64, 4)
+	usages.Range(func(k, v any) bool {
+		u := v.(*BckUsage)
+		if u.Ready() {
+			all[k.(uint64)] = [2]int64{u.size.Load(), u.objs.Load()}
+		}
+		return true
+	})
+	return all
+}
+
+// remove usage of the buckets that no longer exist or have no quota;
+// return true if there's at least one bucket with quota
+func PruneUsages(bmd *meta.BMD) bool {
+	keep := make(map[uint64]struct{}, 4)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if bck.Props.Quota.IsSet() {
+			keep[bck.Props.BID] = struct{}{}
+			GetUsage(bck) // (refresh if stale)
+		}
+		return false
+	})
+	usages.Range(func(k, _ any) bool {
+		if _, ok := keep[k.(uint64)]; !ok {
+			usages.Delete(k)
+		}
+		return true
+	})
+	return len(keep) > 0
+}
+
+//////////////
+// BckUsage //
+//////////////
+
+func (u *BckUsage) Ready() bool { return u.walked.Load() != 0 }
+
+func (u *BckUsage) Get() (size, objs int64) { return u.size.Load(), u.objs.Load() }
+
+// returns the resulting usage
+func (u *BckUsage) Add(size, objs int64) (int64, int64) {
+	return u.size.Add(size), u.objs.Add(objs)
+}
+
+func (u *BckUsage) refresh(bck *meta.Bck) {
+	if w := u.walked.Load(); w != 0 && mono.Since(w) < usageRefresh {
+		return
+	}
+	if u.walking.CAS(false, true) {
+		go u.walk(*bck)
+	}
+}
+
+func (u *BckUsage) walk(bck meta.Bck) {
+	var (
+		size, objs int64
+		started    = mono.NanoTime()
+		cb         = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			lom := AllocLOM("")
+			if err := lom.InitFQN(fqn, bck.Bucket()); err == nil && lom.IsHRW() { // (skip copies)
+				if finfo, err := os.Stat(fqn); err == nil {
+					size += finfo.Size()
+					objs++
+				}
+			}
+			FreeLOM(lom)
+			return nil
+		}
+		cbp = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			if finfo, err := os.Stat(fqn); err == nil {
+				size += finfo.Size()
+			}
+			return nil
+		}
+	)
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, Bck: *bck.Bucket(), CTs: []string{fs.ObjectType}, Callback: cb}
+		if err := fs.Walk(opts); err != nil {
+			nlog.Errorln("failed to compute", bck.Cname(""), "usage:", err)
+		}
+		// prior versions
+		opts = &fs.WalkOpts{Mi: mi, Bck: *bck.Bucket(), CTs: []string{fs.PriorVerType}, Callback: cbp}
+		if err := fs.Walk(opts); err != nil {
+			nlog.Errorln("failed to compute", bck.Cname(""), "prior versions usage:", err)
+		}
+	}
+
+	u.size.Store(size)
+	u.objs.Store(objs)
+	u.walked.Store(mono.NanoTime())
+	u.walking.Store(false)
+	if cmn.Rom.FastV(4, cos.SmoduleCore) {
+		nlog.Infoln(bck.Cname(""), "usage:", size, objs, mono.Since(started))
+	}
+}
@@ -67,6 +67,12 @@ type (
 		InBytes() int64
 		OutBytes() int64
 	}
+
+	// optional: xaction-specific stats (see Snap.Ext) that come from a component
+	// the xaction does not own - e.g., ETL pipeline (via its data provider)
+	SnapExt interface {
+		SnapExt() any
+	}
 )
 
 type (
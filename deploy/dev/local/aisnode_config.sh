@@ -54,7 +54,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"cleanupwm":         65,
 		"lowwm":             ${AIS_SPACE_LOWWM:-75},
 		"highwm":            ${AIS_SPACE_HIGHWM:-90},
-		"out_of_space":      ${AIS_SPACE_OOS:-95}
+		"out_of_space":      ${AIS_SPACE_OOS:-95},
+		"mpt_abandon_time":  "168h"
 	},
 	"lru": {
 		"dont_evict_time":   "120m",
@@ -133,6 +134,11 @@ cat > $AIS_CONF_FILE <<EOL
 		"secret":      "$AIS_AUTHN_SECRET_KEY",
 		"enabled":     ${AIS_AUTHN_ENABLED:-false}
 	},
+	"tracing": {
+		"exporter_endpoint":   "${AIS_TRACING_ENDPOINT}",
+		"sampler_probability": 1,
+		"enabled":             ${AIS_TRACING_ENABLED:-false}
+	},
 	"keepalivetracker": {
 		"proxy": {
 			"interval": "10s",
@@ -5,6 +5,7 @@
 package ec
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sync"
@@ -97,8 +98,9 @@ func (r *XactBckEncode) Run(wg *sync.WaitGroup) {
 	ECM.incActive(r)
 
 	opts := &mpather.JgroupOpts{
-		CTs:      []string{fs.ObjectType},
+		CTs:      []string{fs.ObjectType, fs.ECMetaType},
 		VisitObj: r.bckEncode,
+		VisitCT:  r.bckRestore,
 		DoLoad:   mpather.LoadUnsafe,
 	}
 	opts.Bck.Copy(r.bck.Bucket())
@@ -133,7 +135,9 @@ func (r *XactBckEncode) afterECObj(lom *core.LOM, err error) {
 
 // Walks through all files in 'obj' directory, and calls EC.Encode for every
 // file whose HRW points to this file and the file does not have corresponding
-// metadata file in 'meta' directory
+// metadata file in 'meta' directory.
+// Objects that were erasure-coded with a different (D, P) - i.e., prior to
+// changing the bucket's EC configuration - get re-encoded in place.
 func (r *XactBckEncode) bckEncode(lom *core.LOM, _ []byte) error {
 	_, local, err := lom.HrwTarget(r.smap)
 	if err != nil {
@@ -149,13 +153,17 @@ func (r *XactBckEncode) bckEncode(lom *core.LOM, _ []byte) error {
 		nlog.Warningf("metadata FQN generation failed %q: %v", lom, err)
 		return nil
 	}
-	err = cos.Stat(mdFQN)
-	// Metadata file exists - the object was already EC'ed before.
-	if err == nil {
-		return nil
-	}
-	if !os.IsNotExist(err) {
-		nlog.Warningf("failed to stat %q: %v", mdFQN, err)
+	md, err := LoadMetadata(mdFQN)
+	switch {
+	case err == nil:
+		// Metadata file exists - the object was already EC'ed before.
+		if !r.reencode(md) {
+			return nil
+		}
+	case os.IsNotExist(err):
+		md = nil
+	default:
+		nlog.Warningf("failed to load %q: %v", mdFQN, err)
 		return nil
 	}
 
@@ -163,7 +171,12 @@ func (r *XactBckEncode) bckEncode(lom *core.LOM, _ []byte) error {
 	// After Walk finishes, the xaction waits until counter drops to zero.
 	// That means all objects have been processed and xaction can finalize.
 	r.beforeECObj()
-	if err = ECM.EncodeObject(lom, r.afterECObj); err != nil {
+	if md == nil {
+		err = ECM.EncodeObject(lom, r.afterECObj)
+	} else {
+		err = ECM.ReencodeObject(lom, md, r.afterECObj)
+	}
+	if err != nil {
 		// something went wrong: abort xaction
 		r.afterECObj(lom, err)
 		if err != errSkipped {
@@ -173,6 +186,56 @@ func (r *XactBckEncode) bckEncode(lom *core.LOM, _ []byte) error {
 	return nil
 }
 
+// Visits metafiles to find objects that must be re-encoded but are missing their
+// main replica (e.g., lost along with a disk): restores the object from its existing
+// slices (or replicas) and then re-encodes it (see above).
+func (r *XactBckEncode) bckRestore(ct *core.CT, _ []byte) error {
+	tsi, err := r.smap.HrwHash2T(ct.Digest())
+	if err != nil {
+		nlog.Errorf("%s: %s", ct.Cname(), err)
+		return nil
+	}
+	if tsi.ID() != core.T.SID() {
+		return nil
+	}
+	// the object is here - nothing to do (bckEncode takes care of it)
+	if err := cos.Stat(ct.Make(fs.ObjectType)); err == nil || !os.IsNotExist(err) {
+		return nil
+	}
+	md, err := LoadMetadata(ct.FQN())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Warningf("failed to load %q: %v", ct.FQN(), err)
+		}
+		return nil
+	}
+	if !r.reencode(md) {
+		return nil
+	}
+
+	lom := core.AllocLOM(ct.ObjectName())
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		nlog.Warningln(err)
+		return nil
+	}
+	if err := ECM.RestoreObject(context.Background(), lom); err != nil {
+		nlog.Warningln("failed to restore", lom.Cname(), "prior to re-encoding:", err)
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		nlog.Warningln(err)
+		return nil
+	}
+	return r.bckEncode(lom, nil)
+}
+
+// whether already EC'ed object must be re-encoded with the current bucket's (D, P)
+func (r *XactBckEncode) reencode(md *Metadata) bool {
+	ecConf := &r.bck.Props.EC
+	return md.Data != ecConf.DataSlices || md.Parity != ecConf.ParitySlices
+}
+
 func (r *XactBckEncode) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
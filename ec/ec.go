@@ -6,6 +6,7 @@ package ec
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -129,11 +130,13 @@ type (
 		Action   string     // what to do with the object (see Act* consts)
 		ErrCh    chan error // for final EC result (used only in restore)
 		Callback core.OnFinishObj
+		tctx     context.Context // restore only: parent span, if traced
 
 		putTime time.Time // time when the object is put into main queue
 		tm      time.Time // to measure different steps
 		IsCopy  bool      // replicate or use erasure coding
 		rebuild bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		prev    *Metadata // re-encode only: previous (D, P) encoding, to clean up its leftovers
 	}
 
 	RequestsControlMsg struct {
@@ -204,6 +207,7 @@ func Init() {
 	xreg.RegBckXact(&putFactory{})
 	xreg.RegBckXact(&rspFactory{})
 	xreg.RegBckXact(&encFactory{})
+	xreg.RegBckXact(&scrubFactory{})
 
 	if err := initManager(); err != nil {
 		cos.ExitLog("Failed to initialize EC manager:", err)
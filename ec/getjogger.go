@@ -6,6 +6,7 @@ package ec
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -44,6 +45,7 @@ type (
 		nodes    map[string]*Metadata // EC metafiles downloaded from other targets
 		slices   []*slice             // slices downloaded from other targets
 		idToNode map[int]string       // existing sliceID <-> target
+		tctx     context.Context      // (tracing) parent span, if any
 		toDisk   bool                 // use memory or disk for temporary files
 	}
 )
@@ -75,6 +77,7 @@ func (c *getJogger) newCtx(req *request) (*restoreCtx, error) {
 	ctx := allocRestoreCtx()
 	ctx.toDisk = useDisk(0 /*size of the original object is unknown*/, c.parent.config)
 	ctx.lom = lom
+	ctx.tctx = req.tctx
 	err = lom.Load(true /*cache it*/, false /*locked*/)
 	if os.IsNotExist(err) {
 		err = nil
@@ -412,6 +415,7 @@ func (c *getJogger) requestSlices(ctx *restoreCtx) error {
 
 	o := transport.AllocSend()
 	o.Hdr = hdr
+	o.Ctx = ctx.tctx
 
 	// Broadcast slice request and wait for targets to respond
 	if cmn.Rom.FastV(4, cos.SmoduleEC) {
@@ -5,6 +5,7 @@
 package ec
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -255,6 +256,20 @@ func (mgr *Manager) recvResponse(hdr *transport.ObjHdr, objReader io.Reader, err
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *core.LOM, cb core.OnFinishObj) error {
+	return mgr.encodeObject(lom, nil, cb)
+}
+
+// ReencodeObject re-encodes already erasure-coded object with the bucket's current
+// (D, P) configuration; `prev` is the object's existing metadata.
+// The main replica stays in place and is readable throughout; once the new slices
+// (or replicas) are distributed, the targets that are no longer in use get the
+// request to remove their (outdated) slices and metafiles.
+func (mgr *Manager) ReencodeObject(lom *core.LOM, prev *Metadata, cb core.OnFinishObj) error {
+	debug.Assert(prev != nil)
+	return mgr.encodeObject(lom, prev, cb)
+}
+
+func (mgr *Manager) encodeObject(lom *core.LOM, prev *Metadata, cb core.OnFinishObj) error {
 	if !lom.ECEnabled() {
 		return ErrorECDisabled
 	}
@@ -269,6 +284,7 @@ func (mgr *Manager) EncodeObject(lom *core.LOM, cb core.OnFinishObj) error {
 
 	req := allocateReq(ActSplit, lom.LIF())
 	req.IsCopy = IsECCopy(lom.Lsize(), &lom.Bprops().EC)
+	req.prev = prev
 	if cb != nil {
 		req.rebuild = true
 		req.Callback = cb
@@ -288,7 +304,7 @@ func (mgr *Manager) CleanupObject(lom *core.LOM) {
 	mgr.RestoreBckPutXact(lom.Bck()).cleanup(req, lom)
 }
 
-func (mgr *Manager) RestoreObject(lom *core.LOM) error {
+func (mgr *Manager) RestoreObject(ctx context.Context, lom *core.LOM) error {
 	if !lom.ECEnabled() {
 		return ErrorECDisabled
 	}
@@ -301,6 +317,7 @@ func (mgr *Manager) RestoreObject(lom *core.LOM) error {
 	req := allocateReq(ActRestore, lom.LIF())
 	errCh := make(chan error) // unbuffered
 	req.ErrCh = errCh
+	req.tctx = ctx
 	mgr.RestoreBckGetXact(lom.Bck()).decode(req, lom)
 
 	// wait for EC completes restoring the object
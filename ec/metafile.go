@@ -92,6 +92,35 @@ func (md *Metadata) RemoteTargets() []*meta.Snode {
 	return nodes
 }
 
+// Misplaced returns true if the object's slices (or replicas) are not spread across
+// failure domains as evenly as the current cluster map allows (see meta.HrwTargetList),
+// e.g. after targets have joined or changed their respective domains.
+func (md *Metadata) Misplaced(smap *meta.Smap, uname *string) bool {
+	if smap.CountDomains() <= 1 {
+		return false
+	}
+	nodes := make(meta.Nodes, 0, len(md.Daemons))
+	for tid := range md.Daemons {
+		if tsi := smap.GetTarget(tid); tsi != nil {
+			nodes = append(nodes, tsi)
+		}
+	}
+	sis, err := smap.HrwTargetList(uname, len(md.Daemons))
+	if err != nil {
+		return false
+	}
+	return maxPerDomain(nodes) > maxPerDomain(sis)
+}
+
+func maxPerDomain(nodes meta.Nodes) (n int) {
+	domains := make(map[string]int, len(nodes))
+	for _, tsi := range nodes {
+		domains[tsi.Domain]++
+		n = max(n, domains[tsi.Domain])
+	}
+	return n
+}
+
 // TODO: use 'buf, slab = smm.Alloc()'
 func (md *Metadata) NewPack() []byte {
 	var (
@@ -264,6 +264,12 @@ func (c *putJogger) encode(req *request, lom *core.LOM) error {
 		}
 		return fmt.Errorf("%s metafile saved while bucket %s was being destroyed", ctMeta.ObjectName(), ctMeta.Bucket())
 	}
+	if req.prev != nil {
+		// (the object is already re-encoded - not failing it)
+		if err := c.cleanupPrev(lom, req.prev, meta); err != nil {
+			nlog.Warningln("failed to cleanup previous encoding of", lom.Cname(), "err:", err)
+		}
+	}
 	return nil
 }
 
@@ -313,7 +319,25 @@ func (c *putJogger) cleanup(lom *core.LOM) error {
 	if err := cos.RemoveFile(ctMeta.FQN()); err != nil {
 		return err
 	}
+	return c.sendDel(lom, nodes)
+}
+
+// Re-encode: remove slices and replicas of the previous encoding from the targets
+// that do not have a role in the new one (the rest have been overwritten)
+func (c *putJogger) cleanupPrev(lom *core.LOM, prev, md *Metadata) error {
+	nodes := make([]*meta.Snode, 0, len(prev.Daemons))
+	for _, tsi := range prev.RemoteTargets() {
+		if _, ok := md.Daemons[tsi.ID()]; !ok {
+			nodes = append(nodes, tsi)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	return c.sendDel(lom, nodes)
+}
 
+func (c *putJogger) sendDel(lom *core.LOM, nodes []*meta.Snode) error {
 	request := newIntraReq(reqDel, nil, lom.Bck()).NewPack(g.smm)
 	o := transport.AllocSend()
 	o.Hdr = transport.ObjHdr{ObjName: lom.ObjName, Opaque: request, Opcode: reqDel}
@@ -0,0 +1,334 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// EC scrubber: background verification and repair of erasure-coded content.
+// Runs on every target (each target taking care of its own content) periodically,
+// as per bucket's `ec.scrub_interval`, or on demand via xaction start API.
+// Walks the bucket's metafiles and slices:
+// - slice: validates its checksum against the slice's metafile; removes corrupted slices
+//   (along with their metafiles) so that they get rebuilt by the object's main target;
+// - metafile of the object this target is the main one for: validates the main replica
+//   (restoring it from slices, if missing or corrupted) and cross-checks the metafiles
+//   of all the other targets that must have the object's slices (or replicas);
+//   missing and outdated ones are rebuilt by re-encoding the object.
+// Upon completion, logs the bucket's redundancy summary (see ExtECScrubStats).
+
+type (
+	scrubFactory struct {
+		xreg.RenewBase
+		xctn *XactScrub
+	}
+	XactScrub struct {
+		smap   *meta.Smap
+		client *http.Client
+		wg     sync.WaitGroup // pending repairs
+		stats  scrubStats
+		xact.BckJog
+	}
+	scrubStats struct {
+		objs          atomic.Int64
+		healthy       atomic.Int64
+		degraded      atomic.Int64
+		repaired      atomic.Int64
+		unrecoverable atomic.Int64
+		missing       atomic.Int64
+		stale         atomic.Int64
+		corrupt       atomic.Int64
+		orphan        atomic.Int64
+	}
+	// x-ec-scrub statistics: bucket's redundancy health summary
+	ExtECScrubStats struct {
+		Objs          int64 `json:"ec.scrub.obj.n,string"`           // objects checked (by their main targets)
+		Healthy       int64 `json:"ec.scrub.healthy.n,string"`       // objects with all slices (replicas) in place
+		Degraded      int64 `json:"ec.scrub.degraded.n,string"`      // objects missing main replica, slices, or replicas (or having outdated ones)
+		Repaired      int64 `json:"ec.scrub.repaired.n,string"`      // degraded objects rebuilt
+		Unrecoverable int64 `json:"ec.scrub.unrecoverable.n,string"` // objects that could not be restored
+		MissingCTs    int64 `json:"ec.scrub.missing.n,string"`       // missing slices (replicas)
+		StaleCTs      int64 `json:"ec.scrub.stale.n,string"`         // slices (replicas) of another generation
+		CorruptCTs    int64 `json:"ec.scrub.corrupt.n,string"`       // local slices that failed checksum validation (and were removed)
+		OrphanCTs     int64 `json:"ec.scrub.orphan.n,string"`        // local slices without metafiles
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactScrub)(nil)
+	_ xreg.Renewable = (*scrubFactory)(nil)
+)
+
+//////////////////
+// scrubFactory //
+//////////////////
+
+func (*scrubFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &scrubFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *scrubFactory) Start() error {
+	if !p.Bck.Props.EC.Enabled {
+		return fmt.Errorf("%s does not have EC enabled", p.Bck.Cname(""))
+	}
+	p.xctn = newXactScrub(p.UUID(), p.Bck)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*scrubFactory) Kind() string     { return apc.ActECScrub }
+func (p *scrubFactory) Get() core.Xact { return p.xctn }
+
+func (*scrubFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////
+// XactScrub //
+///////////////
+
+func newXactScrub(uuid string, bck *meta.Bck) (r *XactScrub) {
+	var (
+		config = cmn.GCO.Get()
+		cargs  = cmn.TransportArgs{Timeout: config.Client.Timeout.D()}
+	)
+	r = &XactScrub{smap: core.T.Sowner().Get()}
+	if config.Net.HTTP.UseHTTPS {
+		r.client = cmn.NewIntraClientTLS(cargs, config)
+	} else {
+		r.client = cmn.NewClient(cargs)
+	}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ECMetaType, fs.ECSliceType},
+		VisitCT:  r.visitCT,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActECScrub, bck, mpopts, config)
+	return
+}
+
+func (r *XactScrub) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+
+	ECM.incActive(r)
+
+	r.BckJog.Run()
+	if err := r.BckJog.Wait(); err != nil {
+		r.AddErr(err)
+	}
+	r.wg.Wait() // for the repairs in progress
+
+	nlog.Infoln(r.Name(), "redundancy summary:", r.summary())
+	r.Finish()
+}
+
+func (r *XactScrub) visitCT(ct *core.CT, _ []byte) error {
+	switch ct.ContentType() {
+	case fs.ECSliceType:
+		r.checkSlice(ct)
+	case fs.ECMetaType:
+		tsi, err := r.smap.HrwHash2T(ct.Digest())
+		if err != nil {
+			nlog.Errorf("%s: %s", ct.Cname(), err)
+			return nil
+		}
+		// slices' (and replicas') metafiles are checked by the respective main targets
+		if tsi.ID() == core.T.SID() {
+			r.checkObj(ct)
+		}
+	}
+	return nil
+}
+
+// validate slice's checksum; remove corrupted slice along with its metafile
+func (r *XactScrub) checkSlice(ct *core.CT) {
+	md, err := LoadMetadata(ct.Make(fs.ECMetaType))
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.stats.orphan.Inc()
+		} else {
+			nlog.Warningln(r.Name(), err)
+		}
+		return
+	}
+	if md.CksumType == "" || md.CksumType == cos.ChecksumNone {
+		return
+	}
+	ct.Lock(false)
+	err = cksumFile(ct.FQN(), cos.NewCksum(md.CksumType, md.CksumValue), ct.Cname())
+	ct.Unlock(false)
+	if err == nil || !cos.IsErrBadCksum(err) {
+		if err != nil && !os.IsNotExist(err) {
+			nlog.Warningln(r.Name(), err)
+		}
+		return
+	}
+
+	r.stats.corrupt.Inc()
+	nlog.Errorln(r.Name(), "removing corrupted slice:", err)
+	ct.Lock(true)
+	for _, fqn := range []string{ct.Make(fs.ECMetaType), ct.FQN()} {
+		if errRm := cos.RemoveFile(fqn); errRm != nil {
+			nlog.Errorln(r.Name(), "failed to remove", fqn, "err:", errRm)
+		}
+	}
+	ct.Unlock(true)
+}
+
+// check and repair, if need be, the object this target is the main one for
+func (r *XactScrub) checkObj(ct *core.CT) {
+	md, err := LoadMetadata(ct.FQN())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Warningln(r.Name(), err)
+		}
+		return
+	}
+	lom := core.AllocLOM(ct.ObjectName())
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		nlog.Warningln(r.Name(), err)
+		return
+	}
+	r.stats.objs.Inc()
+
+	// 1. main replica
+	if err := r.checkMain(lom); err != nil {
+		r.stats.degraded.Inc()
+		nlog.Warningln(r.Name(), "restoring", lom.Cname(), "err:", err)
+		if errR := ECM.RestoreObject(context.Background(), lom); errR != nil {
+			r.stats.unrecoverable.Inc()
+			r.AddErr(cmn.NewErrFailedTo(core.T, "restore", lom.Cname(), errR), 0)
+		} else {
+			// (restoring the main replica also rebuilds missing slices)
+			r.stats.repaired.Inc()
+			r.ObjsAdd(1, md.Size)
+		}
+		return
+	}
+
+	// 2. all the other targets that must have the object's slices (or replicas)
+	var (
+		nodes    = md.RemoteTargets()
+		expected = md.Parity
+		missing  = len(md.Daemons) - 1 - len(nodes) // (targets that are no longer in the cluster map)
+		stale    int
+	)
+	if !md.IsCopy {
+		expected += md.Data
+	}
+	if n := len(md.Daemons) - 1; n < expected {
+		missing += expected - n
+	}
+	for _, tsi := range nodes {
+		rmd, err := RequestECMeta(lom.Bucket(), lom.ObjName, tsi, r.client)
+		switch {
+		case err == nil:
+			if rmd.Generation != md.Generation {
+				stale++
+			}
+		case cos.IsErrNotFound(err):
+			missing++
+		default:
+			// (not treating intermittent errors as missing slices)
+			nlog.Warningln(r.Name(), "failed to check", lom.Cname(), "at", tsi.StringEx(), "err:", err)
+			return
+		}
+	}
+	if missing == 0 && stale == 0 {
+		r.stats.healthy.Inc()
+		return
+	}
+	r.stats.degraded.Inc()
+	r.stats.missing.Add(int64(missing))
+	r.stats.stale.Add(int64(stale))
+
+	// 3. repair: re-encode from the main replica
+	r.wg.Add(1)
+	if err := ECM.ReencodeObject(lom, md, r.afterRepair); err != nil {
+		r.afterRepair(lom, err)
+	}
+}
+
+// whether the main replica is present and intact
+func (*XactScrub) checkMain(lom *core.LOM) (err error) {
+	lom.Lock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		err = lom.ValidateContentChecksum()
+	}
+	lom.Unlock(false)
+	return err
+}
+
+func (r *XactScrub) afterRepair(lom *core.LOM, err error) {
+	if err == nil {
+		r.stats.repaired.Inc()
+		r.LomAdd(lom)
+	} else if err != errSkipped {
+		r.AddErr(cmn.NewErrFailedTo(core.T, "repair", lom.Cname(), err), 0)
+	}
+	r.wg.Done()
+}
+
+func (r *XactScrub) ext() *ExtECScrubStats {
+	st := &r.stats
+	return &ExtECScrubStats{
+		Objs:          st.objs.Load(),
+		Healthy:       st.healthy.Load(),
+		Degraded:      st.degraded.Load(),
+		Repaired:      st.repaired.Load(),
+		Unrecoverable: st.unrecoverable.Load(),
+		MissingCTs:    st.missing.Load(),
+		StaleCTs:      st.stale.Load(),
+		CorruptCTs:    st.corrupt.Load(),
+		OrphanCTs:     st.orphan.Load(),
+	}
+}
+
+func (r *XactScrub) summary() string {
+	ext := r.ext()
+	return fmt.Sprintf("objects: %d (healthy %d, degraded %d, repaired %d, unrecoverable %d); "+
+		"slices: missing %d, stale %d, corrupted %d, orphaned %d",
+		ext.Objs, ext.Healthy, ext.Degraded, ext.Repaired, ext.Unrecoverable,
+		ext.MissingCTs, ext.StaleCTs, ext.CorruptCTs, ext.OrphanCTs)
+}
+
+func (r *XactScrub) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	snap.Ext = r.ext()
+	return
+}
+
+// checksum file's content
+func cksumFile(fqn string, cksum *cos.Cksum, cname string) error {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	err = cksumSlice(fh, cksum, cname)
+	cos.Close(fh)
+	return err
+}
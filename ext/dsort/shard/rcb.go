@@ -42,7 +42,7 @@ func (c *rcbCtx) Call(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop
 	return c.xzip("", reader, hdr)
 }
 
-// handles .tar, .targz, and .tarlz4 - anything and everything that has tar headers
+// handles .tar, .targz, .tarlz4, and .tarzst - anything and everything that has tar headers
 func (c *rcbCtx) xtar(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop*/, error) {
 	header, ok := hdr.(*tar.Header)
 	debug.Assert(ok)
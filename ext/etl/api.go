@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -23,8 +24,9 @@ import (
 const PrefixXactID = "etl-"
 
 const (
-	Spec = "spec"
-	Code = "code"
+	Spec     = "spec"
+	Code     = "code"
+	Pipeline = "pipeline"
 )
 
 // consistent with rfc2396.txt "Uniform Resource Identifiers (URI): Generic Syntax"
@@ -48,6 +50,15 @@ const (
 	HpushStdin = "io://"
 )
 
+// enum platforms (`platforms`): where and how transformers run
+const (
+	// (default) K8s pod per target, see etlBootstrapper
+	PlatformK8s = "k8s"
+	// Local process per target: either a long-running server (hpush, hpull, hrev)
+	// or, in case of io://, one process per object, see local.go
+	PlatformLocal = "local"
+)
+
 // enum arg types (`argTypes`)
 const (
 	ArgTypeDefault = ""
@@ -58,18 +69,20 @@ const (
 type (
 	InitMsg interface {
 		Name() string
-		MsgType() string // Code or Spec
+		MsgType() string // Code, Spec, or Pipeline
 		CommType() string
 		ArgType() string
+		Platform() string
 		Validate() error
 		String() string
 	}
 
 	// and implementations
 	InitMsgBase struct {
-		IDX       string       `json:"id"`            // etlName (not to be confused)
-		CommTypeX string       `json:"communication"` // enum commTypes
-		ArgTypeX  string       `json:"argument"`      // enum argTypes
+		IDX       string       `json:"id"`                 // etlName (not to be confused)
+		CommTypeX string       `json:"communication"`      // enum commTypes
+		ArgTypeX  string       `json:"argument"`           // enum argTypes
+		PlatformX string       `json:"platform,omitempty"` // enum platforms (default: PlatformK8s)
 		Timeout   cos.Duration `json:"timeout"`
 	}
 	InitSpecMsg struct {
@@ -96,6 +109,13 @@ type (
 		// bitwise flags: (streaming | debug | strict | ...) future enhancements
 		Flags int64 `json:"flags"`
 	}
+
+	// named pipeline: ordered list of (existing) ETLs - see pipeline.go
+	// (comm-type, arg-type, and platform do not apply)
+	InitPipelineMsg struct {
+		InitMsgBase
+		Stages []string `json:"pipeline"`
+	}
 )
 
 type (
@@ -131,6 +151,7 @@ type (
 var (
 	commTypes = []string{Hpush, Hpull, Hrev, HpushStdin}         // NOTE: must contain all
 	argTypes  = []string{ArgTypeDefault, ArgTypeURL, ArgTypeFQN} // ditto
+	platforms = []string{"", PlatformK8s, PlatformLocal}         // ditto
 )
 
 ////////////////
@@ -141,13 +162,24 @@ var (
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*InitPipelineMsg)(nil)
 )
 
-func (m InitMsgBase) CommType() string { return m.CommTypeX }
-func (m InitMsgBase) ArgType() string  { return m.ArgTypeX }
-func (m InitMsgBase) Name() string     { return m.IDX }
-func (*InitCodeMsg) MsgType() string   { return Code }
-func (*InitSpecMsg) MsgType() string   { return Spec }
+func (m InitMsgBase) CommType() string   { return m.CommTypeX }
+func (m InitMsgBase) ArgType() string    { return m.ArgTypeX }
+func (m InitMsgBase) Name() string       { return m.IDX }
+func (*InitCodeMsg) MsgType() string     { return Code }
+func (*InitSpecMsg) MsgType() string     { return Spec }
+func (*InitPipelineMsg) MsgType() string { return Pipeline }
+
+func (m InitMsgBase) Platform() string {
+	if m.PlatformX == "" {
+		return PlatformK8s
+	}
+	return m.PlatformX
+}
+
+func (*InitPipelineMsg) Platform() string { return "" } // n/a (each stage has its own)
 
 func (m *InitCodeMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s-%s]", Code, m.IDX, m.CommTypeX, m.ArgTypeX, m.Runtime)
@@ -157,6 +189,10 @@ func (m *InitSpecMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s]", Spec, m.IDX, m.CommTypeX, m.ArgTypeX)
 }
 
+func (m *InitPipelineMsg) String() string {
+	return fmt.Sprintf("init-%s[%s: %s]", Pipeline, m.IDX, strings.Join(m.Stages, pipelineArrow))
+}
+
 // TODO: double-take, unmarshaling-wise. To avoid, include (`Spec`, `Code`) in API calls
 func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 	var msgInf map[string]json.RawMessage
@@ -173,6 +209,11 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf[Pipeline]; ok {
+		msg = &InitPipelineMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid etl.InitMsg: %+v", msgInf)
 	return
 }
@@ -190,6 +231,11 @@ func (m *InitMsgBase) validate(detail string) error {
 		return cmn.NewErrETLf(errCtx, ferr, err, detail)
 	}
 
+	if !cos.StringInSlice(m.PlatformX, platforms) {
+		err := fmt.Errorf("unknown platform %q (expecting one of: %v)", m.PlatformX, platforms[1:])
+		return cmn.NewErrETLf(errCtx, ferr, err, detail)
+	}
+
 	if !cos.StringInSlice(m.ArgTypeX, argTypes) {
 		err := fmt.Errorf("unsupported arg-type %q", m.ArgTypeX)
 		return cmn.NewErrETLf(errCtx, ferr, err, detail)
@@ -251,6 +297,10 @@ func (m *InitCodeMsg) Validate() error {
 		return fmt.Errorf("chunk-size %d is invalid, expecting 0 <= chunk-size <= MiB (%q, comm-type %q)",
 			m.ChunkSize, m.CommTypeX, m.Runtime)
 	}
+	// no runtime container to run the server: the code is executed by local interpreter, once per object
+	if m.PlatformX == PlatformLocal && m.CommTypeX != HpushStdin {
+		return fmt.Errorf("platform %q: init-code requires comm-type %q (got %q)", m.PlatformX, HpushStdin, m.CommTypeX)
+	}
 	return nil
 }
 
@@ -271,6 +321,10 @@ func (m *InitSpecMsg) Validate() (err error) {
 		return
 	}
 	container := pod.Spec.Containers[0]
+	// the container's command (and args) is what gets executed locally (the image is ignored)
+	if m.PlatformX == PlatformLocal && len(container.Command) == 0 {
+		return cmn.NewErrETLf(errCtx, "platform %q requires container command", m.PlatformX)
+	}
 	if len(container.Ports) != 1 {
 		return cmn.NewErrETLf(errCtx, "unsupported number of container ports (%d), expected: 1", len(container.Ports))
 	}
@@ -299,6 +353,59 @@ func (m *InitSpecMsg) Validate() (err error) {
 	return nil
 }
 
+func (m *InitPipelineMsg) Validate() error {
+	if err := k8s.ValidateEtlName(m.IDX); err != nil {
+		return fmt.Errorf("%v [%s]", err, m)
+	}
+	if len(m.Stages) < 2 {
+		return fmt.Errorf("%s: expecting at least two stages, got %d", m, len(m.Stages))
+	}
+	for _, name := range m.Stages {
+		if name == m.IDX {
+			return fmt.Errorf("%s: pipeline cannot include itself", m)
+		}
+		if err := k8s.ValidateEtlName(name); err != nil {
+			return fmt.Errorf("%v [%s]", err, m)
+		}
+	}
+	return nil
+}
+
+// given existing ETLs (EtlMD): stages must exist and cannot be pipelines themselves;
+// all stages but the first must accept pushed data (see StreamTransform)
+func (m *InitPipelineMsg) ValidateStages(etls ETLs) error {
+	for i, name := range m.Stages {
+		msg, ok := etls[name]
+		if !ok {
+			return fmt.Errorf("%s: stage #%d etl[%s] does not exist", m, i, name)
+		}
+		if msg.MsgType() == Pipeline {
+			return fmt.Errorf("%s: stage #%d etl[%s] is a pipeline (nested pipelines are not supported)", m, i, name)
+		}
+		if i > 0 && !chainable(msg.CommType(), msg.ArgType()) {
+			return fmt.Errorf("%s: stage #%d etl[%s] (%s) cannot receive the output of the previous stage", m, i, name, msg)
+		}
+	}
+	return nil
+}
+
+// PlatformLocal runs user-provided commands and code directly on storage nodes
+// and, therefore, requires explicit opt-in (checked by both proxy and target)
+func CheckPlatform(msg InitMsg) error {
+	if msg.Platform() != PlatformLocal {
+		return nil
+	}
+	if !cmn.Rom.Features().IsSet(feat.AllowLocalETL) {
+		return fmt.Errorf("%s: platform %q is not permitted - requires %v feature flag", msg, PlatformLocal,
+			feat.AllowLocalETL.Names())
+	}
+	// (local transformers listen on the loopback interface and are not authenticated)
+	if msg.CommType() == Hpull {
+		return fmt.Errorf("%s: communication type %q is not supported with platform %q", msg, Hpull, PlatformLocal)
+	}
+	return nil
+}
+
 func ParsePodSpec(errCtx *cmn.ETLErrCtx, spec []byte) (*corev1.Pod, error) {
 	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(spec, nil, nil)
 	if err != nil {
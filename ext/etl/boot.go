@@ -39,6 +39,7 @@ type etlBootstrapper struct {
 	uri             string
 	originalPodName string
 	originalCommand []string
+	rt              localRT // non-K8s platforms only (see local.go)
 }
 
 func (b *etlBootstrapper) createPodSpec() (err error) {
@@ -7,16 +7,19 @@ package etl
 import (
 	cryptorand "crypto/rand"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
@@ -139,6 +142,154 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	It("should perform transformation "+HpushStdin+" (platform "+PlatformLocal+")", func() {
+		xctn := mock.NewXact(apc.ActETLInline)
+		boot := &etlBootstrapper{
+			msg: InitSpecMsg{
+				InitMsgBase: InitMsgBase{
+					CommTypeX: HpushStdin,
+					PlatformX: PlatformLocal,
+				},
+			},
+			rt:   &localProc{command: []string{"cat"}, dir: tmpDir},
+			xctn: xctn,
+		}
+		comm = newCommunicator(nil, boot)
+		Expect(comm.PodName()).To(BeEmpty())
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		b, err := cos.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(b)).To(Equal(int(dataSize)))
+
+		// `cat` echoes the object
+		lom := &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		orig, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(orig))
+	})
+
+	It("should require feature flag and not pass aisnode environment (platform "+PlatformLocal+")", func() {
+		msg := &InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "local", CommTypeX: HpushStdin, PlatformX: PlatformLocal}}
+		rom := cmn.Rom
+		defer func() { cmn.Rom = rom }()
+		cmn.Rom.Set(&cmn.ClusterConfig{})
+		Expect(CheckPlatform(msg)).To(HaveOccurred())
+		Expect(initSpecLocal(msg, "", StartOpts{})).To(HaveOccurred())
+
+		cmn.Rom.Set(&cmn.ClusterConfig{Features: feat.AllowLocalETL})
+		Expect(CheckPlatform(msg)).NotTo(HaveOccurred())
+		Expect(CheckPlatform(&InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "k8s"}})).NotTo(HaveOccurred())
+		msg.CommTypeX = Hpull
+		Expect(CheckPlatform(msg)).To(HaveOccurred())
+		msg.CommTypeX = HpushStdin
+
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+		env := localEnv()
+		Expect(env).To(HaveLen(2))
+		for _, kv := range env {
+			Expect(kv).NotTo(ContainSubstring("AWS_"))
+		}
+	})
+
+	It("should perform transformation via ad-hoc pipeline ("+Hpush+", "+HpushStdin+")", func() {
+		first := newCommunicator(nil, &etlBootstrapper{
+			msg:  InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "first", CommTypeX: Hpush}},
+			uri:  transformerServer.URL,
+			xctn: mock.NewXact(apc.ActETLInline),
+		})
+		second := newCommunicator(nil, &etlBootstrapper{
+			msg:  InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "second", CommTypeX: HpushStdin, PlatformX: PlatformLocal}},
+			rt:   &localProc{command: []string{"cat"}, dir: tmpDir},
+			xctn: mock.NewXact(apc.ActETLInline),
+		})
+		Expect(reg.add("first", first)).NotTo(HaveOccurred())
+		defer reg.del("first")
+		Expect(reg.add("second", second)).NotTo(HaveOccurred())
+		defer reg.del("second")
+
+		var err error
+		comm, err = GetCommunicator("first" + PipelineSepa + "second")
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		// `cat` echoes the output of the first stage
+		b, err := cos.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(transformData))
+
+		stats := comm.(*pipelineComm).SnapExt().(PipelineStats)
+		Expect(stats).To(HaveLen(2))
+		for _, s := range stats {
+			Expect(s.Objs).To(Equal(int64(1)))
+			Expect(s.InBytes).To(Equal(dataSize))
+			Expect(s.OutBytes).To(Equal(dataSize))
+			Expect(s.Errs).To(BeZero())
+		}
+
+		// hpull (or hrev) transformer can only be the first stage
+		third := newCommunicator(nil, &etlBootstrapper{
+			msg:  InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "third", CommTypeX: Hpull}},
+			uri:  transformerServer.URL,
+			xctn: mock.NewXact(apc.ActETLInline),
+		})
+		Expect(reg.add("third", third)).NotTo(HaveOccurred())
+		defer reg.del("third")
+		_, err = GetCommunicator("first" + PipelineSepa + "third")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should pass bucket and object names to all pipeline stages ("+Hpush+", "+Hpush+")", func() {
+		var (
+			paths []string
+			mu    sync.Mutex
+		)
+		echoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			paths = append(paths, r.URL.Path)
+			mu.Unlock()
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write(b)
+		}))
+		defer echoServer.Close()
+		for _, name := range []string{"first", "second"} {
+			c := newCommunicator(nil, &etlBootstrapper{
+				msg:  InitSpecMsg{InitMsgBase: InitMsgBase{IDX: name, CommTypeX: Hpush}},
+				uri:  echoServer.URL,
+				xctn: mock.NewXact(apc.ActETLInline),
+			})
+			Expect(reg.add(name, c)).NotTo(HaveOccurred())
+			defer reg.del(name)
+		}
+
+		var err error
+		comm, err = GetCommunicator("first" + PipelineSepa + "second")
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := http.Get(proxyServer.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		b, err := cos.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(int64(len(b))).To(Equal(dataSize))
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(paths).To(Equal([]string{"/" + bck.Name + "/" + objName, "/" + bck.Name + "/" + objName}))
+	})
 })
 
 // Creates a file with random content.
@@ -40,6 +40,7 @@ type (
 		Xact() core.Xact
 		PodName() string
 		SvcName() string
+		Platform() string
 
 		String() string
 
@@ -56,9 +57,20 @@ type (
 		// See also, and separately: on-the-fly transformation as part of a user (e.g. training model) GET request handling
 		OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error)
 
+		// StreamTransform transforms arbitrary content (e.g., the output of the previous
+		// pipeline stage) without writing it first - see pipeline.go
+		// - supported by (Hpush | HpushStdin) with the default arg-type
+		// - `objName`: "<bucket>/<object>" the content originates from (same as the path that
+		//   Hpush transformers receive with OfflineTransform), or empty
+		// - closes `in` when done or upon error
+		StreamTransform(in io.ReadCloser, size int64, objName string, timeout time.Duration) (cos.ReadCloseSizer, error)
+
 		Stop()
 
 		CommStats
+
+		lrt() localRT     // nil for PlatformK8s
+		streamable() bool // whether StreamTransform is supported
 	}
 
 	baseComm struct {
@@ -98,6 +110,11 @@ var (
 //////////////
 
 func newCommunicator(listener meta.Slistener, boot *etlBootstrapper) Communicator {
+	if boot.rt != nil && boot.msg.CommTypeX == HpushStdin {
+		sc := &stdioComm{}
+		sc.listener, sc.boot = listener, boot
+		return sc
+	}
 	switch boot.msg.CommTypeX {
 	case Hpush, HpushStdin:
 		pc := &pushComm{}
@@ -136,9 +153,26 @@ func newCommunicator(listener meta.Slistener, boot *etlBootstrapper) Communicato
 	return nil
 }
 
-func (c *baseComm) Name() string    { return c.boot.originalPodName }
-func (c *baseComm) PodName() string { return c.boot.pod.Name }
-func (c *baseComm) SvcName() string { return c.boot.pod.Name /*same as pod name*/ }
+func (c *baseComm) Name() string     { return c.boot.originalPodName }
+func (c *baseComm) Platform() string { return c.boot.msg.Platform() }
+func (c *baseComm) lrt() localRT     { return c.boot.rt }
+
+func (c *baseComm) PodName() string {
+	if c.boot.pod == nil {
+		return "" // (non-K8s)
+	}
+	return c.boot.pod.Name
+}
+
+func (c *baseComm) SvcName() string { return c.PodName() /*same as pod name*/ }
+
+func (c *baseComm) streamable() bool { return chainable(c.boot.msg.CommTypeX, c.boot.msg.ArgTypeX) }
+
+// default (Hpull, Hrev, and non-default arg-types): not supported
+func (c *baseComm) StreamTransform(in io.ReadCloser, _ int64, _ string, _ time.Duration) (cos.ReadCloseSizer, error) {
+	cos.Close(in)
+	return nil, cmn.NewErrUnsupp("stream-transform", c.String())
+}
 
 func (c *baseComm) ListenSmapChanged() { c.listener.ListenSmapChanged() }
 
@@ -151,7 +185,12 @@ func (c *baseComm) ObjCount() int64 { return c.boot.xctn.Objs() }
 func (c *baseComm) InBytes() int64  { return c.boot.xctn.InBytes() }
 func (c *baseComm) OutBytes() int64 { return c.boot.xctn.OutBytes() }
 
-func (c *baseComm) Stop() { c.boot.xctn.Finish() }
+func (c *baseComm) Stop() {
+	c.boot.xctn.Finish()
+	if c.boot.rt != nil {
+		c.boot.rt.stop()
+	}
+}
 
 func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
 	if err := c.boot.xctn.AbortErr(); err != nil {
@@ -222,11 +261,8 @@ func (pc *pushComm) doRequest(lom *core.LOM, timeout time.Duration) (r cos.ReadC
 
 func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSizer, ecode int, err error) {
 	var (
-		body   io.ReadCloser
-		cancel func()
-		req    *http.Request
-		resp   *http.Response
-		u      string
+		body io.ReadCloser
+		u    string
 	)
 	if err := pc.boot.xctn.AbortErr(); err != nil {
 		return nil, 0, err
@@ -242,7 +278,7 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 		// - container must be ready to receive complete bucket name including namespace
 		// - see `bck.AddToQuery` and api/bucket.go for numerous examples
 		debug.Assert(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname(""), " - bucket with namespace")
-		u = pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
+		u = pc.boot.uri + "/" + streamObjName(lom)
 
 		fh, err := cos.NewFileHandle(lom.FQN)
 		if err != nil {
@@ -255,7 +291,16 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 	default:
 		debug.Assert(false, "unexpected msg type:", pc.boot.msg.ArgTypeX) // is validated at construction time
 	}
+	return pc.put(u, body, size, timeout)
+}
 
+// PUT the body to the transformer; the body gets closed in all cases
+func (pc *pushComm) put(u string, body io.ReadCloser, size int64, timeout time.Duration) (_ cos.ReadCloseSizer, ecode int, err error) {
+	var (
+		cancel func()
+		req    *http.Request
+		resp   *http.Response
+	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
@@ -301,12 +346,30 @@ finish:
 				cancel()
 			}
 			pc.boot.xctn.InObjsAdd(1, 0)
-			pc.boot.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
+			pc.boot.xctn.OutObjsAdd(1, max(size, 0)) // see also: `coi.objsAdd`
 		},
 	}
 	return cos.NewReaderWithArgs(args), 0, nil
 }
 
+func (pc *pushComm) StreamTransform(in io.ReadCloser, size int64, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if !pc.streamable() {
+		return pc.baseComm.StreamTransform(in, size, objName, timeout)
+	}
+	if err := pc.boot.xctn.AbortErr(); err != nil {
+		cos.Close(in)
+		return nil, err
+	}
+	r, _, err := pc.put(pc.boot.uri+"/"+objName, in, size, timeout)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(Hpush, "stream", objName, size, err)
+	}
+	return r, err
+}
+
+// "<bucket>/<object>" as passed to Hpush transformers (see also StreamTransform)
+func streamObjName(lom *core.LOM) string { return lom.Bck().Name + "/" + lom.ObjName }
+
 func (pc *pushComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
 	r, err := pc.doRequest(lom, 0 /*timeout*/)
 	if err != nil {
@@ -27,13 +27,19 @@ type (
 )
 
 // interface guard
-var _ core.DP = (*OfflineDP)(nil)
+var (
+	_ core.DP      = (*OfflineDP)(nil)
+	_ core.SnapExt = (*OfflineDP)(nil)
+)
 
 func NewOfflineDP(msg *apc.TCBMsg, config *cmn.Config) (*OfflineDP, error) {
 	comm, err := GetCommunicator(msg.Transform.Name)
 	if err != nil {
 		return nil, err
 	}
+	if pc, ok := comm.(*pipelineComm); ok && pc.name != "" {
+		comm = pc.job() // named pipeline: per-job stats
+	}
 	pr := &OfflineDP{comm: comm, tcbmsg: msg, config: config}
 	pr.requestTimeout = time.Duration(msg.Transform.Timeout)
 	return pr, nil
@@ -77,3 +83,11 @@ func (dp *OfflineDP) Reader(lom *core.LOM, latestVer, sync bool) (cos.ReadOpenCl
 	}
 	return cos.NopOpener(r), oah, nil
 }
+
+// per-stage stats when transforming via pipeline (nil otherwise)
+func (dp *OfflineDP) SnapExt() any {
+	if pc, ok := dp.comm.(*pipelineComm); ok {
+		return pc.SnapExt()
+	}
+	return nil
+}
@@ -0,0 +1,514 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// PlatformLocal:
+// - no pods and no services - each target runs its own transformer;
+// - hpush or hrev: a long-running local server that must listen on the loopback port
+//   given by AIS_ETL_PORT (env); once it is ready the regular communicators take over;
+// - hpull is not supported: clients would have to be redirected to the transformer
+//   (that is, to an unauthenticated server on the target's public network - see CheckPlatform);
+// - io://: the transformer runs once per object, with the object's content
+//   piped to its stdin and the transformed result read from its stdout (see stdioComm).
+
+const (
+	localEnvPort = "AIS_ETL_PORT"
+	localHost    = "127.0.0.1"
+	localPython  = "python3" // (init-code) local interpreter found in the PATH
+
+	localStartRetries = 3 // (see freePort)
+
+	localStopTimeout = 10 * time.Second
+	maxLocalLogSize  = 256 * cos.KiB
+)
+
+type (
+	// local transformer runtime (see localProc)
+	localRT interface {
+		run(ctx context.Context, in io.Reader, out io.Writer) error // io:// only
+		logs() []byte
+		health() string
+		metrics() (cpu float64, mem int64, err error)
+		stop()
+	}
+
+	localProc struct {
+		cmd     *exec.Cmd // long-running server (hpush, hrev)
+		exited  chan struct{}
+		exitErr error
+		command []string // command and args
+		env     []string
+		dir     string // working directory
+		tmpDir  string // removed upon stop
+		lbuf    logBuf
+		cpu     struct {
+			total uint64 // ms
+			at    int64  // mono time
+		}
+		mu sync.Mutex
+	}
+
+	// implements io:// for local platforms
+	stdioComm struct {
+		baseComm
+	}
+
+	// keeps the last maxLocalLogSize bytes of transformer's stdout/stderr
+	logBuf struct {
+		b  []byte
+		mu sync.Mutex
+	}
+)
+
+// interface guard
+var (
+	_ localRT      = (*localProc)(nil)
+	_ Communicator = (*stdioComm)(nil)
+	_ io.Writer    = (*logBuf)(nil)
+)
+
+// InitSpecMsg with PlatformLocal: execute the (single) container's command and args,
+// with the container's env (values only), in its working directory
+func initSpecLocal(msg *InitSpecMsg, xid string, opts StartOpts) error {
+	if err := CheckPlatform(msg); err != nil {
+		return err
+	}
+	errCtx := &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}
+	pod, err := ParsePodSpec(errCtx, msg.Spec)
+	if err != nil {
+		return err
+	}
+	var (
+		container = &pod.Spec.Containers[0]
+		env       = make(map[string]string, len(container.Env)+len(opts.Env))
+		lp        = &localProc{dir: container.WorkingDir}
+	)
+	lp.command = make([]string, 0, len(container.Command)+len(container.Args))
+	lp.command = append(lp.command, container.Command...)
+	lp.command = append(lp.command, container.Args...)
+	for i := range container.Env {
+		env[container.Env[i].Name] = container.Env[i].Value
+	}
+	for k, v := range opts.Env {
+		env[k] = v
+	}
+	if lp.dir == "" {
+		if lp.tmpDir, err = os.MkdirTemp("", "ais-etl-"+msg.IDX+"-"); err != nil {
+			return cmn.NewErrETL(errCtx, err.Error())
+		}
+		lp.dir = lp.tmpDir
+	}
+	lp.setEnv(env)
+
+	boot := &etlBootstrapper{errCtx: errCtx, config: cmn.GCO.Get(), msg: *msg, env: opts.Env}
+	boot.originalPodName = msg.IDX
+	if err := lp.start(boot, container.ReadinessProbe.HTTPGet.Path); err != nil {
+		lp.stop()
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	return startLocal(boot, lp, xid)
+}
+
+// InitCodeMsg with PlatformLocal (io:// only): store the code (and install its dependencies, if any)
+// in a temp directory, to further run it with the local interpreter
+func initCodeLocal(msg *InitCodeMsg, xid string) (err error) {
+	var (
+		errCtx = &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}
+		lp     = &localProc{command: []string{localPython, "code.py"}}
+		env    = make(map[string]string, 2)
+	)
+	if err = CheckPlatform(msg); err != nil {
+		return err
+	}
+	if lp.tmpDir, err = os.MkdirTemp("", "ais-etl-"+msg.IDX+"-"); err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	lp.dir = lp.tmpDir
+	if err = lp.prepCode(msg, env); err != nil {
+		lp.stop()
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	lp.setEnv(env)
+
+	boot := &etlBootstrapper{errCtx: errCtx, config: cmn.GCO.Get(), msg: InitSpecMsg{InitMsgBase: msg.InitMsgBase}}
+	boot.originalPodName = msg.IDX
+	return startLocal(boot, lp, xid)
+}
+
+// (where all local platforms converge; compare with `start`)
+func startLocal(boot *etlBootstrapper, rt localRT, xid string) error {
+	boot.rt = rt
+	boot.setupXaction(xid)
+
+	comm := newCommunicator(newAborter(boot.msg.IDX), boot)
+	if err := reg.add(boot.msg.IDX, comm); err != nil {
+		comm.Stop()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(comm)
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infoln("started", comm.String(), "platform", boot.msg.Platform())
+	}
+	return nil
+}
+
+///////////////
+// localProc //
+///////////////
+
+// user processes do not inherit aisnode environment (credentials, secrets, etc.) -
+// only PATH and HOME (see also setEnv and start for the rest)
+func localEnv() []string {
+	return []string{"PATH=" + os.Getenv("PATH"), "HOME=" + os.Getenv("HOME")}
+}
+
+// plus AIS_TARGET_URL and the ETL's own (explicitly specified) env
+func (lp *localProc) setEnv(env map[string]string) {
+	lp.env = localEnv()
+	lp.env = append(lp.env, "AIS_TARGET_URL="+core.T.Snode().URL(cmn.NetPublic)+apc.URLPathETLObject.Join(reqSecret))
+	for k, v := range env {
+		lp.env = append(lp.env, k+"="+v)
+	}
+}
+
+// write code.py and pip-install requirements (compare with etl/runtime/podspec.yaml)
+func (lp *localProc) prepCode(msg *InitCodeMsg, env map[string]string) error {
+	if err := os.WriteFile(filepath.Join(lp.dir, "code.py"), msg.Code, cos.PermRWR); err != nil {
+		return err
+	}
+	if len(msg.Deps) == 0 {
+		return nil
+	}
+	var (
+		reqs = filepath.Join(lp.dir, "requirements.txt")
+		dst  = filepath.Join(lp.dir, "runtime")
+	)
+	if err := os.WriteFile(reqs, msg.Deps, cos.PermRWR); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), msg.Timeout.D())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, localPython, "-m", "pip", "install", "--target="+dst, "-r", reqs)
+	cmd.Dir, cmd.Env = lp.dir, localEnv()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to install dependencies: %v\n%s", err, out)
+	}
+	env["PYTHONPATH"] = dst
+	return nil
+}
+
+// start the server and wait for it to become ready;
+// retry with another port when the server exits early (e.g., failing to bind - see freePort)
+func (lp *localProc) start(boot *etlBootstrapper, readyPath string) error {
+	if boot.msg.CommTypeX == HpushStdin {
+		return nil // nothing to start
+	}
+	for i := 1; ; i++ {
+		port, err := freePort()
+		if err != nil {
+			return err
+		}
+		if err := lp.exec(port); err != nil {
+			return err
+		}
+		boot.uri = "http://" + net.JoinHostPort(localHost, strconv.Itoa(port))
+		err = lp.waitReady(boot.uri+readyPath, boot.msg.Timeout.D())
+		if err == nil || i == localStartRetries {
+			return err
+		}
+		select {
+		case <-lp.exited:
+			nlog.Warningln(err, "- retrying with another port")
+		default:
+			return err // timed out
+		}
+	}
+}
+
+func (lp *localProc) exec(port int) error {
+	cmd := exec.Command(lp.command[0], lp.command[1:]...)
+	cmd.Dir = lp.dir
+	cmd.Env = append(lp.env[:len(lp.env):len(lp.env)], localEnvPort+"="+strconv.Itoa(port))
+	cmd.Stdout, cmd.Stderr = &lp.lbuf, &lp.lbuf
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // to terminate the entire group
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	lp.cmd, lp.exited = cmd, make(chan struct{})
+	go lp.wait()
+	return nil
+}
+
+func (lp *localProc) wait() {
+	lp.exitErr = lp.cmd.Wait()
+	close(lp.exited)
+}
+
+func (lp *localProc) waitReady(u string, timeout time.Duration) error {
+	var (
+		ival     = cos.ProbingFrequency(timeout)
+		deadline = mono.NanoTime() + timeout.Nanoseconds()
+	)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), ival)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+		debug.AssertNoErr(err)
+		resp, err := core.T.DataClient().Do(req)
+		if err == nil {
+			cos.DrainReader(resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return nil
+		}
+		select {
+		case <-lp.exited:
+			return fmt.Errorf("%q exited before becoming ready: %v\n%s", lp.command, lp.exitErr, lp.logs())
+		case <-time.After(ival):
+		}
+		if mono.NanoTime() > deadline {
+			return fmt.Errorf("timed out waiting for %q to become ready (%s, timeout %v)", lp.command, u, timeout)
+		}
+	}
+}
+
+func (lp *localProc) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	debug.Assert(lp.cmd == nil)
+	cmd := exec.CommandContext(ctx, lp.command[0], lp.command[1:]...)
+	cmd.Dir, cmd.Env = lp.dir, lp.env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = in, out, &lp.lbuf
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%q: %v", lp.command, err)
+	}
+	return nil
+}
+
+func (lp *localProc) logs() []byte { return lp.lbuf.get() }
+
+func (lp *localProc) health() string {
+	if lp.cmd == nil {
+		return "Running" // (io://)
+	}
+	select {
+	case <-lp.exited:
+		return fmt.Sprintf("Failed (%v)", lp.exitErr)
+	default:
+		return "Running"
+	}
+}
+
+// cpu: number of cores used since the previous call
+func (lp *localProc) metrics() (cpu float64, mem int64, err error) {
+	if lp.cmd == nil {
+		return 0, 0, cmn.NewErrUnsupp("get metrics of", "io:// transformer (one process per object)")
+	}
+	ps, err := sys.ProcessStats(lp.cmd.Process.Pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	now := mono.NanoTime()
+	lp.mu.Lock()
+	if lp.cpu.at != 0 && ps.CPU.Total >= lp.cpu.total {
+		elapsed := time.Duration(now - lp.cpu.at).Milliseconds()
+		if elapsed > 0 {
+			cpu = float64(ps.CPU.Total-lp.cpu.total) / float64(elapsed)
+		}
+	}
+	lp.cpu.total, lp.cpu.at = ps.CPU.Total, now
+	lp.mu.Unlock()
+	return cpu, int64(ps.Mem.Resident), nil
+}
+
+func (lp *localProc) stop() {
+	if lp.cmd != nil {
+		pgid := -lp.cmd.Process.Pid
+		if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+			nlog.Warningln("failed to terminate", lp.command, "err:", err)
+		}
+		select {
+		case <-lp.exited:
+		case <-time.After(localStopTimeout):
+			_ = syscall.Kill(pgid, syscall.SIGKILL)
+			<-lp.exited
+		}
+	}
+	if lp.tmpDir != "" {
+		if err := os.RemoveAll(lp.tmpDir); err != nil {
+			nlog.Warningln(err)
+		}
+	}
+}
+
+// NOTE: the port is not reserved - between closing the listener and the transformer
+// binding the port, another local process may take it
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(localHost, "0"))
+	if err != nil {
+		return 0, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	return port, l.Close()
+}
+
+///////////////
+// stdioComm //
+///////////////
+
+func (sc *stdioComm) doRequest(lom *core.LOM, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+	if err := lom.InitBck(lom.Bucket()); err != nil {
+		return nil, err
+	}
+	lom.Lock(false)
+	r, err = sc.do(lom, timeout)
+	if err != nil && cos.IsNotExist(err, 0) && lom.Bucket().IsRemote() {
+		lom.Unlock(false)
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return nil, err
+		}
+		lom.Lock(false)
+		r, err = sc.do(lom, timeout)
+	}
+	if err != nil {
+		lom.Unlock(false)
+	}
+	return r, err
+}
+
+// on success, the returned reader owns the (rlocked) lom until closed
+func (sc *stdioComm) do(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := sc.boot.xctn.AbortErr(); err != nil {
+		return nil, err
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return nil, err
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	if err != nil {
+		return nil, err
+	}
+	return sc.pipe(fh, lom.Lsize(), timeout, func() { lom.Unlock(false) }), nil
+}
+
+// run the transformer in a goroutine, with `in` => stdin, and stdout => returned reader;
+// `in` gets closed (and `cb`, if any, called) when the transformer exits
+func (sc *stdioComm) pipe(in io.ReadCloser, size int64, timeout time.Duration, cb func()) cos.ReadCloseSizer {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		pr, pw = io.Pipe()
+		done   = make(chan struct{})
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	go func() {
+		err := sc.boot.rt.run(ctx, in, pw)
+		cos.Close(in)
+		if cb != nil {
+			cb()
+		}
+		pw.CloseWithError(err) // (nil => EOF)
+		close(done)
+	}()
+	args := cos.ReaderArgs{
+		R:      pr,
+		Size:   -1, // unknown
+		ReadCb: func(n int, _ error) { sc.boot.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			cancel() // (when closed early)
+			<-done
+			sc.boot.xctn.InObjsAdd(1, 0)
+			sc.boot.xctn.OutObjsAdd(1, max(size, 0)) // see also: `coi.objsAdd`
+		},
+	}
+	return cos.NewReaderWithArgs(args)
+}
+
+func (sc *stdioComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	r, err := sc.doRequest(lom, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(HpushStdin, sc.boot.msg.Platform(), lom.Cname())
+	}
+	buf, slab := core.T.PageMM().AllocSize(memsys.DefaultBufSize)
+	_, err = io.CopyBuffer(w, r, buf)
+
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+func (sc *stdioComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+	clone := *lom
+	r, err = sc.doRequest(&clone, timeout)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(HpushStdin, sc.boot.msg.Platform(), clone.Cname(), err)
+	}
+	return
+}
+
+func (sc *stdioComm) StreamTransform(in io.ReadCloser, size int64, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := sc.boot.xctn.AbortErr(); err != nil {
+		cos.Close(in)
+		return nil, err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(HpushStdin, sc.boot.msg.Platform(), "stream", objName, size)
+	}
+	return sc.pipe(in, size, timeout, nil), nil
+}
+
+////////////
+// logBuf //
+////////////
+
+func (lb *logBuf) Write(p []byte) (int, error) {
+	lb.mu.Lock()
+	lb.b = append(lb.b, p...)
+	if over := len(lb.b) - maxLocalLogSize; over > 0 {
+		n := copy(lb.b, lb.b[over:])
+		lb.b = lb.b[:n]
+	}
+	lb.mu.Unlock()
+	return len(p), nil
+}
+
+func (lb *logBuf) get() []byte {
+	lb.mu.Lock()
+	b := make([]byte, len(lb.b))
+	copy(b, lb.b)
+	lb.mu.Unlock()
+	return b
+}
@@ -0,0 +1,263 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// ETL pipeline: ordered list of ETLs (stages), each transforming the output of the previous one
+// - named (see InitPipelineMsg) or ad-hoc, e.g.: `?etl_name=decode,resize,normalize`
+//   (inline GET) and `TCBMsg.Transform.Name` (ETLBucket and ETLMultiObj);
+// - the first stage reads the object (any comm-type); subsequent stages must support
+//   StreamTransform, so that data is streamed between stages without writing it first;
+// - stages are resolved upon every use - stopping any one of them breaks the pipeline;
+// - per-stage stats are reported as part of the xaction snapshot (core.Snap.Ext):
+//   named pipeline's own (inline) xaction and offline (TCB, TCO) xactions.
+
+const (
+	PipelineSepa  = "," // ad-hoc pipeline, e.g. "a,b,c"
+	pipelineArrow = "->"
+)
+
+type (
+	// (see core.Snap.Ext)
+	StageStats struct {
+		Name     string `json:"name"`
+		Objs     int64  `json:"objs,string"`
+		InBytes  int64  `json:"in-bytes,string"`
+		OutBytes int64  `json:"out-bytes,string"`
+		Errs     int64  `json:"errs,string"`
+	}
+	PipelineStats []StageStats
+
+	stageCnt struct {
+		objs, in, out, errs atomic.Int64
+	}
+
+	pipelineComm struct {
+		listener meta.Slistener // nil when ad-hoc
+		xctn     core.Xact      // ditto
+		name     string         // ditto
+		stages   []string
+		cnts     []stageCnt
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*pipelineComm)(nil)
+	_ core.SnapExt = (*pipelineComm)(nil)
+)
+
+// chaining requires transforming content (rather than the object, by name)
+func chainable(commType, argType string) bool {
+	return (commType == Hpush || commType == HpushStdin) && argType == ArgTypeDefault
+}
+
+func isPipeline(c Communicator) bool {
+	_, ok := c.(*pipelineComm)
+	return ok
+}
+
+func newPipeline(name string, stages []string) (*pipelineComm, error) {
+	pc := &pipelineComm{name: name, stages: stages, cnts: make([]stageCnt, len(stages))}
+	if _, err := pc.resolve(); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// named pipeline (see also: `startLocal`)
+func InitPipeline(msg *InitPipelineMsg, xid string) error {
+	pc, err := newPipeline(msg.IDX, msg.Stages)
+	if err != nil {
+		return err
+	}
+	rns := xreg.RenewETL(pc, xid)
+	if rns.Err != nil {
+		return rns.Err
+	}
+	pc.xctn = rns.Entry.Get()
+	pc.listener = newAborter(msg.IDX)
+	if err := reg.add(msg.IDX, pc); err != nil {
+		pc.Stop()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(pc)
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infoln("started", pc.String())
+	}
+	return nil
+}
+
+// new offline job: same stages, its own stats
+func (pc *pipelineComm) job() *pipelineComm {
+	return &pipelineComm{name: pc.name, stages: pc.stages, cnts: make([]stageCnt, len(pc.stages))}
+}
+
+func (pc *pipelineComm) resolve() ([]Communicator, error) {
+	comms := make([]Communicator, len(pc.stages))
+	for i, name := range pc.stages {
+		c, exists := reg.get(name)
+		if !exists {
+			return nil, cos.NewErrNotFound(core.T, "etl job "+name+" ("+pc.String()+")")
+		}
+		if isPipeline(c) {
+			return nil, fmt.Errorf("%s: stage #%d %s is a pipeline (nested pipelines are not supported)", pc, i, name)
+		}
+		if i > 0 && !c.streamable() {
+			return nil, fmt.Errorf("%s: stage #%d %s cannot receive the output of the previous stage", pc, i, c)
+		}
+		comms[i] = c
+	}
+	return comms, nil
+}
+
+func (pc *pipelineComm) transform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	comms, err := pc.resolve()
+	if err != nil {
+		return nil, err
+	}
+	clone := *lom
+	size, err := lomLoad(&clone)
+	if err != nil {
+		return nil, err
+	}
+	r, err := comms[0].OfflineTransform(lom, timeout)
+	if err != nil {
+		pc.cnts[0].errs.Inc()
+		return nil, err
+	}
+	pc.cnts[0].in.Add(size)
+	for i := 1; i < len(comms); i++ {
+		// (upon error, StreamTransform closes its input and, therefore, all previous stages)
+		r, err = comms[i].StreamTransform(pc.wrap(r, i-1), r.Size(), streamObjName(lom), timeout)
+		if err != nil {
+			pc.cnts[i].errs.Inc()
+			return nil, err
+		}
+	}
+	return pc.wrap(r, len(comms)-1), nil
+}
+
+// count output of the stage `i` (and input of the next one)
+func (pc *pipelineComm) wrap(r cos.ReadCloseSizer, i int) cos.ReadCloseSizer {
+	last := i == len(pc.cnts)-1
+	args := cos.ReaderArgs{
+		R:    r,
+		Size: r.Size(),
+		ReadCb: func(n int, err error) {
+			pc.cnts[i].out.Add(int64(n))
+			if !last {
+				pc.cnts[i+1].in.Add(int64(n))
+			}
+			switch {
+			case err == nil:
+			case err == io.EOF:
+				pc.cnts[i].objs.Inc()
+				if last && pc.xctn != nil {
+					pc.xctn.ObjsAdd(1, 0)
+				}
+			default:
+				pc.cnts[i].errs.Inc()
+			}
+			if last && pc.xctn != nil && n > 0 {
+				pc.xctn.ObjsAdd(0, int64(n))
+			}
+		},
+	}
+	return cos.NewReaderWithArgs(args)
+}
+
+func (pc *pipelineComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	r, err := pc.transform(lom, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(pc.String(), lom.Cname())
+	}
+	buf, slab := core.T.PageMM().AllocSize(memsys.DefaultBufSize)
+	_, err = io.CopyBuffer(w, r, buf)
+
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+func (pc *pipelineComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	r, err := pc.transform(lom, timeout)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(pc.String(), lom.Cname(), err)
+	}
+	return r, err
+}
+
+func (pc *pipelineComm) StreamTransform(in io.ReadCloser, _ int64, _ string, _ time.Duration) (cos.ReadCloseSizer, error) {
+	cos.Close(in)
+	return nil, cmn.NewErrUnsupp("stream-transform", pc.String())
+}
+
+func (pc *pipelineComm) Name() string    { return pc.name }
+func (pc *pipelineComm) Xact() core.Xact { return pc.xctn }
+func (*pipelineComm) PodName() string    { return "" }
+func (*pipelineComm) SvcName() string    { return "" }
+func (*pipelineComm) Platform() string   { return "" } // n/a
+func (*pipelineComm) lrt() localRT       { return nil }
+func (*pipelineComm) streamable() bool   { return false }
+
+func (pc *pipelineComm) String() string {
+	s := strings.Join(pc.stages, pipelineArrow)
+	if pc.name == "" {
+		return "pipeline[" + s + "]"
+	}
+	return "pipeline-" + pc.name + "[" + s + "]"
+}
+
+func (pc *pipelineComm) ObjCount() int64 { return pc.cnts[len(pc.cnts)-1].objs.Load() }
+func (pc *pipelineComm) InBytes() int64  { return pc.cnts[0].in.Load() }
+func (pc *pipelineComm) OutBytes() int64 { return pc.cnts[len(pc.cnts)-1].out.Load() }
+
+func (pc *pipelineComm) ListenSmapChanged() {
+	if pc.listener != nil {
+		pc.listener.ListenSmapChanged()
+	}
+}
+
+func (pc *pipelineComm) Stop() {
+	if pc.xctn != nil {
+		pc.xctn.Finish()
+	}
+}
+
+// implements core.SnapExt
+func (pc *pipelineComm) SnapExt() any {
+	stats := make(PipelineStats, len(pc.stages))
+	for i, name := range pc.stages {
+		cnt := &pc.cnts[i]
+		stats[i] = StageStats{
+			Name:     name,
+			Objs:     cnt.objs.Load(),
+			InBytes:  cnt.in.Load(),
+			OutBytes: cnt.out.Load(),
+			Errs:     cnt.errs.Load(),
+		}
+	}
+	return stats
+}
@@ -146,6 +146,9 @@ func (e *Aborter) ListenSmapChanged() {
 
 // (common for both `InitCode` and `InitSpec` flows)
 func InitSpec(msg *InitSpecMsg, etlName string, opts StartOpts) error {
+	if msg.Platform() == PlatformLocal {
+		return initSpecLocal(msg, etlName, opts)
+	}
 	config := cmn.GCO.Get()
 	errCtx, podName, svcName, err := start(msg, etlName, opts, config)
 	if err == nil {
@@ -168,6 +171,9 @@ func InitSpec(msg *InitSpecMsg, etlName string, opts StartOpts) error {
 // - execute `InitSpec` with the modified podspec
 // See also: etl/runtime/podspec.yaml
 func InitCode(msg *InitCodeMsg, xid string) error {
+	if msg.Platform() == PlatformLocal {
+		return initCodeLocal(msg, xid)
+	}
 	var (
 		ftp      = fromToPairs(msg)
 		replacer = strings.NewReplacer(ftp...)
@@ -295,7 +301,8 @@ func start(msg *InitSpecMsg, xid string, opts StartOpts, config *cmn.Config) (er
 	return
 }
 
-// Stop deletes all occupied by the ETL resources, including Pods and Services.
+// Stop deletes all occupied by the ETL resources, including Pods and Services
+// (or local processes).
 // It unregisters ETL smap listener.
 func Stop(id string, errCause error) error {
 	errCtx := &cmn.ETLErrCtx{
@@ -310,11 +317,12 @@ func Stop(id string, errCause error) error {
 	if err != nil {
 		return cmn.NewErrETL(errCtx, err.Error())
 	}
-	errCtx.PodName = c.PodName()
-	errCtx.SvcName = c.SvcName()
-
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
-		return err
+	if c.Platform() == PlatformK8s {
+		errCtx.PodName = c.PodName()
+		errCtx.SvcName = c.SvcName()
+		if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+			return err
+		}
 	}
 
 	if c := reg.del(id); c != nil {
@@ -328,9 +336,6 @@ func Stop(id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll() {
-	if !k8s.IsK8s() {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(e.Name, nil); err != nil {
 			nlog.Errorln(err)
@@ -338,7 +343,11 @@ func StopAll() {
 	}
 }
 
+// (comma-separated names: ad-hoc pipeline - see pipeline.go)
 func GetCommunicator(etlName string) (Communicator, error) {
+	if strings.Contains(etlName, PipelineSepa) {
+		return newPipeline("", strings.Split(etlName, PipelineSepa))
+	}
 	c, exists := reg.get(etlName)
 	if !exists {
 		return nil, cos.NewErrNotFound(core.T, "etl job "+etlName)
@@ -353,6 +362,12 @@ func PodLogs(transformID string) (logs Logs, err error) {
 	if err != nil {
 		return logs, err
 	}
+	if isPipeline(c) {
+		return logs, cmn.NewErrUnsupp("get logs of", c.String())
+	}
+	if rt := c.lrt(); rt != nil {
+		return Logs{TargetID: core.T.SID(), Logs: rt.logs()}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -372,6 +387,12 @@ func PodHealth(etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if isPipeline(c) {
+		return "", cmn.NewErrUnsupp("check health of", c.String())
+	}
+	if rt := c.lrt(); rt != nil {
+		return rt.health(), nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -384,6 +405,16 @@ func PodMetrics(etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if isPipeline(c) {
+		return nil, cmn.NewErrUnsupp("get metrics of", c.String())
+	}
+	if rt := c.lrt(); rt != nil {
+		cpuUsed, memUsed, err := rt.metrics()
+		if err != nil {
+			return nil, err
+		}
+		return &CPUMemUsed{TargetID: core.T.SID(), CPU: cpuUsed, Mem: memUsed}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
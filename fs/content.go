@@ -38,6 +38,9 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	MptType      = "mp" // S3 multipart upload: manifests and parts
+	PriorVerType = "pv" // retained prior versions of ais objects (see cmn.VersionConf.KeepPrior)
+	TarIdxType   = "ti" // .tar index: offsets of archived files (see archive.TarIndex)
 )
 
 type (
@@ -178,6 +181,9 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	MptContentResolver      struct{}
+	PriorVerContentResolver struct{}
+	TarIdxContentResolver   struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -252,3 +258,62 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// S3 multipart upload content is named as follows:
+// - manifest: <obj-name>.<upload-digest>.<MptManifest>
+// - part:     <obj-name>.<upload-digest>.<part-number>
+// where `prefix` (see GenUniqueFQN) is the "<upload-digest>.<suffix>" part of the above
+
+const MptManifest = "mpt"
+
+func (*MptContentResolver) PermToMove() bool    { return false }
+func (*MptContentResolver) PermToEvict() bool   { return false }
+func (*MptContentResolver) PermToProcess() bool { return false }
+
+func (*MptContentResolver) GenUniqueFQN(base, prefix string) string { return base + "." + prefix }
+
+func (*MptContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	const (
+		contentSepa = '.'
+	)
+	sfxIndex := strings.LastIndexByte(base, contentSepa) // manifest or part number
+	if sfxIndex <= 0 {
+		return "", false, false
+	}
+	digIndex := strings.LastIndexByte(base[:sfxIndex], contentSepa) // upload digest
+	if digIndex <= 0 {
+		return "", false, false
+	}
+	return base[:digIndex], false, true
+}
+
+// Prior object version: <obj-name>.<version>
+// where `prefix` (see GenUniqueFQN) is the (numeric) ais version
+
+func (*PriorVerContentResolver) PermToMove() bool    { return false }
+func (*PriorVerContentResolver) PermToEvict() bool   { return false }
+func (*PriorVerContentResolver) PermToProcess() bool { return false }
+
+func (*PriorVerContentResolver) GenUniqueFQN(base, prefix string) string { return base + "." + prefix }
+
+func (*PriorVerContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	verIndex := strings.LastIndexByte(base, '.')
+	if verIndex <= 0 || verIndex == len(base)-1 {
+		return "", false, false
+	}
+	if _, err := strconv.ParseUint(base[verIndex+1:], 10, 64); err != nil {
+		return "", false, false
+	}
+	return base[:verIndex], false, true
+}
+
+// TAR index: same name as the (.tar) object it indexes
+
+func (*TarIdxContentResolver) PermToMove() bool                   { return false }
+func (*TarIdxContentResolver) PermToEvict() bool                  { return true }
+func (*TarIdxContentResolver) PermToProcess() bool                { return false }
+func (*TarIdxContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*TarIdxContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -0,0 +1,215 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xs"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Rebalance checkpoints (non-EC buckets only):
+// - each target keeps track of the (mountpath, bucket) pairs it has completely walked,
+//   with all the objects sent during the walk acknowledged by their respective destinations;
+// - the pairs are persisted in the target's config directory (next to RMD), periodically
+//   and when rebalance gets aborted;
+// - the next rebalance (upon restart, or after having been aborted or preempted)
+//   skips the checkpointed pairs _iff_ it runs with the same set of active targets and
+//   local mountpaths - otherwise, the checkpoint is discarded and removed at the start
+//   of the rebalance (EC or non-EC) - before anything gets sent or received;
+// - successfully completed rebalance removes the checkpoint.
+
+const ckptIval = 10 * time.Second // persist (at most) every so often
+
+type checkpoint struct {
+	Done    map[string]map[string]int64 `json:"done"`          // mountpath => bucket => number of walked objects
+	Digest  uint64                      `json:"digest,string"` // active targets and local mountpaths (see ckptDigest)
+	RebID   int64                       `json:"reb_id,string"` // rebalance that has last updated the checkpoint
+	pending map[string]map[string]int64 // walked but (possibly) not yet acknowledged
+	fpath   string
+	last    int64 // last time persisted
+	mu      sync.Mutex
+}
+
+// interface guard
+var _ jsp.Opts = (*checkpoint)(nil)
+
+func (*checkpoint) JspOpts() jsp.Options { return jsp.CCSign(cmn.MetaverRebCkpt) }
+
+// load the previous checkpoint, if any and if still valid
+func newCkpt(rargs *rebArgs) (ckpt *checkpoint) {
+	ckpt = &checkpoint{
+		Done:    make(map[string]map[string]int64, len(rargs.apaths)),
+		Digest:  ckptDigest(rargs),
+		RebID:   rargs.id,
+		pending: make(map[string]map[string]int64, len(rargs.apaths)),
+		fpath:   filepath.Join(rargs.config.ConfigDir, fname.RebCkpt),
+		last:    mono.NanoTime(),
+	}
+	prev := &checkpoint{}
+	if _, err := jsp.LoadMeta(ckpt.fpath, prev); err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Warningln("failed to load rebalance checkpoint:", err)
+			ckpt.remove()
+		}
+		return ckpt
+	}
+	if prev.Digest != ckpt.Digest {
+		nlog.Infof("g%d: discarding g%d checkpoint (cluster map or mountpaths have changed)", rargs.id, prev.RebID)
+		ckpt.remove()
+		return ckpt
+	}
+	if prev.Done != nil {
+		ckpt.Done = prev.Done
+	}
+	return ckpt
+}
+
+// active targets and (this target's) available mountpaths
+func ckptDigest(rargs *rebArgs) (digest uint64) {
+	for _, tsi := range rargs.smap.Tmap {
+		if !tsi.InMaintOrDecomm() {
+			digest ^= tsi.Digest()
+		}
+	}
+	for mpath := range rargs.apaths {
+		digest ^= xxhash.Checksum64S(cos.UnsafeB(mpath), cos.MLCG32)
+	}
+	return digest
+}
+
+// objects walked prior to this rebalance
+func (ckpt *checkpoint) resumed() (n int64, pairs int) {
+	for _, bcks := range ckpt.Done {
+		for _, cnt := range bcks {
+			n += cnt
+			pairs++
+		}
+	}
+	return n, pairs
+}
+
+func (ckpt *checkpoint) isDone(mpath string, bck *meta.Bck) bool {
+	ckpt.mu.Lock()
+	_, ok := ckpt.Done[mpath][bck.Cname("")]
+	ckpt.mu.Unlock()
+	return ok
+}
+
+func (ckpt *checkpoint) walked(reb *Reb, mpath string, bck *meta.Bck, cnt int64) {
+	ckpt.mu.Lock()
+	_add(ckpt.pending, mpath, bck.Cname(""), cnt)
+	if time.Duration(mono.NanoTime()-ckpt.last) >= ckptIval {
+		ckpt._persist(reb)
+	}
+	ckpt.mu.Unlock()
+}
+
+func (ckpt *checkpoint) persist(reb *Reb) {
+	ckpt.mu.Lock()
+	ckpt._persist(reb)
+	ckpt.mu.Unlock()
+}
+
+// under lock; checkpoints walked buckets that have no objects awaiting ACKs
+func (ckpt *checkpoint) _persist(reb *Reb) {
+	ckpt.last = mono.NanoTime()
+	if len(ckpt.pending) == 0 {
+		return
+	}
+	awaiting := make(cos.StrSet, 4)
+	for _, lomAck := range reb.lomAcks() {
+		lomAck.mu.Lock()
+		for _, lom := range lomAck.q {
+			awaiting.Set(lom.Bck().Cname(""))
+		}
+		lomAck.mu.Unlock()
+	}
+	for mpath, bcks := range ckpt.pending {
+		for bname, cnt := range bcks {
+			if awaiting.Contains(bname) {
+				continue
+			}
+			_add(ckpt.Done, mpath, bname, cnt)
+			delete(bcks, bname)
+		}
+		if len(bcks) == 0 {
+			delete(ckpt.pending, mpath)
+		}
+	}
+	if err := jsp.SaveMeta(ckpt.fpath, ckpt, nil /*wto*/); err != nil {
+		nlog.Errorln(core.T.String(), "failed to persist rebalance checkpoint:", err)
+	}
+}
+
+func (ckpt *checkpoint) remove() {
+	if err := cos.RemoveFile(ckpt.fpath); err != nil {
+		nlog.Errorln(core.T.String(), "failed to remove rebalance checkpoint:", err)
+	}
+}
+
+func _add(m map[string]map[string]int64, mpath, bname string, cnt int64) {
+	bcks, ok := m[mpath]
+	if !ok {
+		bcks = make(map[string]int64, 4)
+		m[mpath] = bcks
+	}
+	bcks[bname] = cnt
+}
+
+//
+// progress: count the objects to walk (in parallel with the walk itself)
+//
+
+func countObjs(rargs *rebArgs, xreb *xs.Rebalance) {
+	var (
+		total int64
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		ckpt  = rargs.ckpt
+		bmd   = core.T.Bowner().Get()
+	)
+	for _, mi := range rargs.apaths {
+		wg.Add(1)
+		go func(mi *fs.Mountpath) {
+			var n int64
+			opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Sorted: false}
+			opts.Callback = func(_ string, de fs.DirEntry) error {
+				if !de.IsDir() {
+					n++
+				}
+				return xreb.AbortErr()
+			}
+			bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+				if bck.Props.EC.Enabled || ckpt.isDone(mi.Path, bck) {
+					return false
+				}
+				opts.Bck.Copy(bck.Bucket())
+				return fs.Walk(opts) != nil
+			})
+			mu.Lock()
+			total += n
+			mu.Unlock()
+			wg.Done()
+		}(mi)
+	}
+	wg.Wait()
+	if !xreb.IsAborted() {
+		xreb.SetTotal(total)
+	}
+}
@@ -0,0 +1,102 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestCheckpoint(t *testing.T) {
+	var (
+		config = &cmn.Config{}
+		reb    = &Reb{}
+		bck    = meta.NewBck("ckpt", apc.AIS, cmn.NsGlobal)
+		other  = meta.NewBck("other", apc.AIS, cmn.NsGlobal)
+	)
+	config.ConfigDir = t.TempDir()
+	for i := range reb.lomacks {
+		reb.lomacks[i] = &lomAcks{mu: &sync.Mutex{}, q: make(map[string]*core.LOM)}
+	}
+	newArgs := func(id int64, tids []string, mpaths ...string) *rebArgs {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tids))}
+		for _, tid := range tids {
+			tsi := &meta.Snode{}
+			tsi.Init(tid, apc.Target)
+			smap.Tmap[tid] = tsi
+		}
+		apaths := make(fs.MPI, len(mpaths))
+		for _, mpath := range mpaths {
+			apaths[mpath] = nil
+		}
+		return &rebArgs{id: id, smap: smap, config: config, apaths: apaths}
+	}
+	var (
+		tids   = []string{"t1", "t2", "t3"}
+		fpath  = filepath.Join(config.ConfigDir, fname.RebCkpt)
+		rargs1 = newArgs(1, tids, "/mp1", "/mp2")
+	)
+
+	// walk and persist
+	ckpt := newCkpt(rargs1)
+	if _, pairs := ckpt.resumed(); pairs != 0 {
+		t.Fatalf("expected empty checkpoint, got %d pairs", pairs)
+	}
+	ckpt.walked(reb, "/mp1", bck, 10)
+	ckpt.walked(reb, "/mp2", bck, 20)
+	ckpt.persist(reb)
+	if _, err := os.Stat(fpath); err != nil {
+		t.Fatal(err)
+	}
+
+	// load and resume (same targets and mountpaths)
+	ckpt = newCkpt(newArgs(2, tids, "/mp2", "/mp1"))
+	n, pairs := ckpt.resumed()
+	if n != 30 || pairs != 2 {
+		t.Fatalf("expected (30, 2), got (%d, %d)", n, pairs)
+	}
+	if !ckpt.isDone("/mp1", bck) || !ckpt.isDone("/mp2", bck) || ckpt.isDone("/mp1", other) {
+		t.Fatalf("unexpected checkpoint %v", ckpt.Done)
+	}
+
+	// target in maintenance does not count
+	rargs3 := newArgs(3, append(tids, "t4"), "/mp1", "/mp2")
+	rargs3.smap.Tmap["t4"].Flags = meta.SnodeMaint
+	ckpt = newCkpt(rargs3)
+	if _, pairs := ckpt.resumed(); pairs != 2 {
+		t.Fatalf("expected 2 pairs, got %d", pairs)
+	}
+
+	// changed cluster map: discarded and removed
+	ckpt = newCkpt(newArgs(4, tids[:2], "/mp1", "/mp2"))
+	if _, pairs := ckpt.resumed(); pairs != 0 {
+		t.Fatalf("expected discarded checkpoint, got %d pairs", pairs)
+	}
+	if _, err := os.Stat(fpath); !os.IsNotExist(err) {
+		t.Fatalf("expected removed checkpoint, got %v", err)
+	}
+	// ... and cannot be resumed when the cluster map changes back
+	ckpt = newCkpt(rargs1)
+	if _, pairs := ckpt.resumed(); pairs != 0 {
+		t.Fatalf("expected no checkpoint, got %d pairs", pairs)
+	}
+
+	// changed mountpaths
+	ckpt.walked(reb, "/mp1", bck, 10)
+	ckpt.persist(reb)
+	ckpt = newCkpt(newArgs(6, tids, "/mp1"))
+	if _, pairs := ckpt.resumed(); pairs != 0 {
+		t.Fatalf("expected discarded checkpoint, got %d pairs", pairs)
+	}
+}
@@ -93,6 +93,27 @@ func (reb *Reb) jogEC(mi *fs.Mountpath, bck *cmn.Bck, wg *sync.WaitGroup) {
 	}
 }
 
+// The main target stays put while the object's slices (replicas) are not spread across
+// failure domains as per the current Smap - re-encode the object (in the background),
+// to place them anew and remove the ones that are no longer needed.
+func (reb *Reb) respreadEC(ct *core.CT, md *ec.Metadata) {
+	lom := core.AllocLOM(ct.ObjectName())
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bck().Bucket()); err != nil {
+		nlog.Warningln(reb.xctn().Name(), err)
+		return
+	}
+	lom.Lock(false)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	lom.Unlock(false)
+	if err == nil {
+		err = ec.ECM.ReencodeObject(lom, md, nil)
+	}
+	if err != nil {
+		nlog.Warningln(reb.xctn().Name(), "failed to re-spread", lom.Cname(), "across failure domains:", err)
+	}
+}
+
 // Sends local CT along with EC metadata to default target.
 // The CT is on a local drive and not loaded into SGL. Just read and send.
 func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode, workFQN ...string) (err error) {
@@ -318,9 +339,15 @@ func (reb *Reb) walkEC(fqn string, de fs.DirEntry) error {
 
 	smap := reb.smap.Load()
 	hrwTarget, err := smap.HrwHash2T(ct.Digest())
-	if err != nil || hrwTarget.ID() == core.T.SID() {
+	if err != nil {
 		return err
 	}
+	if hrwTarget.ID() == core.T.SID() {
+		if md.Misplaced(smap, ct.UnamePtr()) {
+			reb.respreadEC(ct, md)
+		}
+		return nil
+	}
 
 	// check if both slice/replica and metafile exist
 	isReplica := md.SliceID == 0
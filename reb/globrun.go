@@ -104,13 +104,16 @@ type (
 	rebJogger struct {
 		joggerBase
 		smap *meta.Smap
+		ckpt *checkpoint
 		opts fs.WalkOpts
 		ver  int64
+		objs int64 // walked in the current bucket
 	}
 	rebArgs struct {
 		smap   *meta.Smap
 		config *cmn.Config
 		apaths fs.MPI
+		ckpt   *checkpoint // non-EC walk (see checkpoint.go)
 		id     int64
 		ecUsed bool
 	}
@@ -193,6 +196,10 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 	if !reb.serialize(rargs, logHdr) {
 		return
 	}
+	// load or discard (and remove) the previous checkpoint prior to receiving anything -
+	// any rebalance that gets past this point invalidates checkpoints made with different
+	// cluster map or mountpaths (see checkpoint.go)
+	rargs.ckpt = newCkpt(rargs)
 
 	reb.regRecv()
 
@@ -213,6 +220,7 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 		reb.semaCh.Release()
 		fs.RemoveMarker(fname.RebalanceMarker)
 		fs.RemoveMarker(fname.NodeRestartedPrev)
+		rargs.ckpt.remove()
 		reb.xctn().Finish()
 		return
 	}
@@ -523,8 +531,14 @@ func (reb *Reb) runEC(rargs *rebArgs) error {
 
 // when not a single bucket has EC enabled
 func (reb *Reb) runNoEC(rargs *rebArgs) error {
-	errCnt := bcast(rargs, reb.rxReady) // ignore timeout
 	xreb := reb.xctn()
+	if n, pairs := rargs.ckpt.resumed(); pairs > 0 {
+		nlog.Infoln(reb.logHdr(rargs.id, rargs.smap), "resuming: skipping", pairs,
+			"(mountpath, bucket) pair(s) walked by previous rebalance (num objects:", n, ")")
+		xreb.SetResumed(n)
+	}
+
+	errCnt := bcast(rargs, reb.rxReady) // ignore timeout
 	if err := xreb.AbortErr(); err != nil {
 		logHdr := reb.logHdr(rargs.id, rargs.smap)
 		nlog.Infoln(logHdr, "abort rx-ready", err, "num-fail", errCnt)
@@ -535,12 +549,14 @@ func (reb *Reb) runNoEC(rargs *rebArgs) error {
 		nlog.Errorln(logHdr, "rx-ready num-fail", errCnt) // unlikely
 	}
 
+	go countObjs(rargs, xreb) // (progress)
+
 	wg := &sync.WaitGroup{}
 	ver := rargs.smap.Version
 	for _, mi := range rargs.apaths {
 		rl := &rebJogger{
 			joggerBase: joggerBase{m: reb, xreb: reb.xctn(), wg: wg},
-			smap:       rargs.smap, ver: ver,
+			smap:       rargs.smap, ckpt: rargs.ckpt, ver: ver,
 		}
 		wg.Add(1)
 		go rl.jog(mi)
@@ -716,21 +732,27 @@ func (reb *Reb) _aborted(rargs *rebArgs) (yes bool) {
 
 func (reb *Reb) fini(rargs *rebArgs, logHdr string, err error) {
 	nlog.Infoln(logHdr, "fini")
+	xreb := reb.xctn()
 
 	// prior to closing the streams
-	if q := reb.quiesce(rargs, rargs.config.Transport.QuiesceTime.D(), reb.nodesQuiescent); q != core.QuiAborted {
+	q := reb.quiesce(rargs, rargs.config.Transport.QuiesceTime.D(), reb.nodesQuiescent)
+	if q != core.QuiAborted {
 		if errM := fs.RemoveMarker(fname.RebalanceMarker); errM == nil {
-			nlog.Infof("%s: %s removed marker ok", core.T, reb.xctn())
+			nlog.Infof("%s: %s removed marker ok", core.T, xreb)
 		}
 		_ = fs.RemoveMarker(fname.NodeRestartedPrev)
 	}
+	if ckpt := rargs.ckpt; ckpt != nil {
+		if err == nil && q != core.QuiAborted && !xreb.IsAborted() {
+			ckpt.remove()
+		} else {
+			ckpt.persist(reb) // to resume
+		}
+	}
 	reb.endStreams(err)
 	reb.filterGFN.Reset()
 
-	var (
-		stats core.Stats
-		xreb  = reb.xctn()
-	)
+	var stats core.Stats
 	xreb.ToStats(&stats)
 	if stats.Objs > 0 || stats.OutObjs > 0 || stats.InObjs > 0 {
 		s, e := jsoniter.MarshalIndent(&stats, "", " ")
@@ -767,9 +789,18 @@ func (rj *rebJogger) jog(mi *fs.Mountpath) {
 }
 
 func (rj *rebJogger) walkBck(bck *meta.Bck) bool {
+	// (EC rebalance takes care of erasure-coded buckets)
+	ecEnabled := bck.Props.EC.Enabled
+	if !ecEnabled && rj.ckpt.isDone(rj.opts.Mi.Path, bck) {
+		return false // already walked (see checkpoint.go)
+	}
+	rj.objs = 0
 	rj.opts.Bck.Copy(bck.Bucket())
 	err := fs.Walk(&rj.opts)
 	if err == nil {
+		if !ecEnabled {
+			rj.ckpt.walked(rj.m, rj.opts.Mi.Path, bck, rj.objs)
+		}
 		return rj.xreb.IsAborted()
 	}
 	if rj.xreb.IsAborted() {
@@ -829,6 +860,8 @@ func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
 	if lom.ECEnabled() {
 		return filepath.SkipDir
 	}
+	rj.objs++
+	rj.xreb.IncWalked()
 	tsi, err := rj.smap.HrwHash2T(lom.Digest())
 	if err != nil {
 		return err
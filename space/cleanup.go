@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -347,7 +348,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.MptType, fs.TarIdxType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -410,11 +411,39 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.MptType:
+		j.visitMpt(fqn)
+	case fs.TarIdxType:
+		// tar indexes: remove those that outlived their respective objects
+		// (stale ones get rebuilt upon access - see core.LOM.TarIndex)
+		ct, err := core.NewCTFromFQN(fqn, core.T.Bowner())
+		if err != nil || cos.Stat(ct.Make(fs.ObjectType)) != nil {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
 }
 
+// S3 multipart uploads: remove abandoned manifests and parts.
+// The manifest gets rewritten upon every added part, and so parts are aged by their
+// respective manifest, if exists (see fs.MptContentResolver for naming).
+func (j *clnJ) visitMpt(fqn string) {
+	mfqn := fqn
+	if !strings.HasSuffix(fqn, "."+fs.MptManifest) {
+		if i := strings.LastIndexByte(fqn, '.'); i > 0 && cos.Stat(fqn[:i+1]+fs.MptManifest) == nil {
+			mfqn = fqn[:i+1] + fs.MptManifest
+		}
+	}
+	finfo, err := os.Stat(mfqn)
+	if err != nil {
+		return
+	}
+	if finfo.ModTime().UnixNano()+int64(j.config.Space.MptAbandonTime) < j.now {
+		j.oldWork = append(j.oldWork, fqn)
+	}
+}
+
 // TODO: add stats error counters (stats.ErrLmetaCorruptedCount, ...)
 // TODO: revisit rm-ed byte counting
 func (j *clnJ) visitObj(fqn string, lom *core.LOM) {
@@ -32,7 +32,13 @@ import (
 // config.Space.HighWM (section "space" in the cluster config).
 //
 // When and if exceeded, AIS target will start gradually evicting objects from its
-// stable storage: oldest first access-time wise.
+// stable storage in the order determined by the eviction policy (config.LRU.Policy,
+// can be overridden by bucket props):
+//   - "lru" (default): oldest first access-time wise;
+//   - "lfu": least frequently accessed first (see core.LOM.AccessCount);
+//   - "size": size-weighted LRU - larger and colder objects first.
+// In addition, buckets with lower LRU priority (bucket props) are evicted first, and
+// "remote_only" protects objects that do not have a remote copy.
 //
 // LRU is implemented as eXtended Action (xaction, see xact/README.md) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.Space.HighWM). LRU then
@@ -67,8 +73,13 @@ type (
 
 // private
 type (
-	// minHeap keeps fileInfo sorted by access time with oldest on top of the heap.
-	minHeap []*core.LOM
+	// eviction candidate
+	lruItem struct {
+		lom   *core.LOM
+		score float64 // lower score - sooner eviction (see lruJ.score)
+	}
+	// minHeap keeps eviction candidates sorted by score, with the lowest on top of the heap.
+	minHeap []lruItem
 
 	// parent (contains mpath joggers)
 	lruP struct {
@@ -83,10 +94,11 @@ type (
 		// runtime
 		curSize   int64
 		totalSize int64 // difference between lowWM size and used size
-		newest    int64
+		maxScore  float64
 		heap      *minHeap
 		bck       cmn.Bck
 		now       int64
+		policy    string // eviction policy of the current bucket
 		// init-time
 		p       *lruP
 		ini     *IniLRU
@@ -97,6 +109,7 @@ type (
 		// runtime
 		throttle    bool
 		allowDelObj bool
+		remoteOnly  bool
 	}
 	lruFactory struct {
 		xreg.RenewBase
@@ -254,17 +267,27 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		return
 	}
 	if len(bcks) > 1 {
-		j.sortBsize(bcks)
+		j.sortBcks(bcks)
 	}
 	for _, bck := range bcks { // for each bucket under a given provider
-		var size int64
+		var (
+			b    *meta.Bck
+			size int64
+		)
 		j.bck = bck
-		if j.allowDelObj, err = j.allow(); err != nil {
+		if b, j.allowDelObj, err = j.allow(); err != nil {
 			nlog.Errorf("%s: %v - skipping %s (Hint: run 'ais storage cleanup' to cleanup)", j, err, bck)
 			err = nil
 			continue
 		}
 		j.allowDelObj = j.allowDelObj || force
+		// (with BMD-initialized props - to tell ais:// buckets that have remote backend)
+		if j.allowDelObj && j.remoteOnly && !b.IsRemote() {
+			if cmn.Rom.FastV(4, cos.SmoduleSpace) {
+				nlog.Infoln(j.String()+":", "remote-only eviction policy - skipping", bck.String())
+			}
+			continue
+		}
 		if size, err = j.jogBck(); err != nil {
 			return
 		}
@@ -287,6 +310,7 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.curSize, j.maxScore = 0, 0
 
 	// 2. collect
 	opts := &fs.WalkOpts{
@@ -329,18 +353,34 @@ func (j *lruJ) _visit(lom *core.LOM) (pushed bool) {
 		return
 	}
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the object is less of a candidate than the heap's worst
+	score := j.score(lom)
+	if j.curSize >= j.totalSize && score > j.maxScore {
 		return
 	}
-	heap.Push(j.heap, lom)
+	heap.Push(j.heap, lruItem{lom: lom, score: score})
 	j.curSize += lom.Lsize()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if score > j.maxScore {
+		j.maxScore = score
 	}
 	return true
 }
 
+// eviction policy: the lower the score, the sooner the object gets evicted
+func (j *lruJ) score(lom *core.LOM) float64 {
+	switch j.policy {
+	case cmn.LRUPolicyLFU:
+		// access count first; same count - oldest first
+		// (the fraction is in (0, 1) for any valid atime)
+		return float64(lom.AccessCount()) + float64(lom.AtimeUnix())/float64(j.now+1)
+	case cmn.LRUPolicySize:
+		idle := float64(max(j.now-lom.AtimeUnix(), 1))
+		return -idle * float64(max(lom.Lsize(), 1))
+	default:
+		return float64(lom.AtimeUnix())
+	}
+}
+
 func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	var parsed fs.ParsedFQN
 	if de.IsDir() {
@@ -369,7 +409,7 @@ func (j *lruJ) evict() (size int64, err error) {
 
 	// evict(sic!) and house-keep
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*core.LOM)
+		lom := heap.Pop(h).(lruItem).lom
 		if !j.evictObj(lom) {
 			core.FreeLOM(lom)
 			continue
@@ -401,7 +441,7 @@ func (j *lruJ) postRemove(prev, size int64) (capCheck int64, err error) {
 	// init, recompute, and throttle - once per capCheckThresh
 	capCheck = 0
 	j.throttle = false
-	j.allowDelObj, _ = j.allow()
+	_, j.allowDelObj, _ = j.allow()
 	j.config = cmn.GCO.Get()
 	j.now = time.Now().UnixNano()
 	usedPct, ok := j.ini.GetFSUsedPercentage(j.mi.Path)
@@ -479,18 +519,28 @@ func (j *lruJ) yieldTerm() error {
 	return nil
 }
 
-// sort buckets by size
-func (j *lruJ) sortBsize(bcks []cmn.Bck) {
-	sized := make([]struct {
-		b cmn.Bck
-		v uint64
-	}, len(bcks))
+// sort buckets by LRU priority (lowest first) and then by size (largest first)
+func (j *lruJ) sortBcks(bcks []cmn.Bck) {
+	var (
+		bmd   = core.T.Bowner().Get()
+		sized = make([]struct {
+			b cmn.Bck
+			v uint64
+			p int
+		}, len(bcks))
+	)
 	for i := range bcks {
 		path := j.mi.MakePathCT(&bcks[i], fs.ObjectType)
 		sized[i].b = bcks[i]
 		sized[i].v, _ = ios.DirSizeOnDisk(path, false /*withNonDirPrefix*/)
+		if bprops, present := bmd.Get(meta.CloneBck(&bcks[i])); present {
+			sized[i].p = bprops.LRU.Priority
+		}
 	}
 	sort.Slice(sized, func(i, j int) bool {
+		if sized[i].p != sized[j].p {
+			return sized[i].p < sized[j].p
+		}
 		return sized[i].v > sized[j].v
 	})
 	for i := range bcks {
@@ -498,15 +548,17 @@ func (j *lruJ) sortBsize(bcks []cmn.Bck) {
 	}
 }
 
-func (j *lruJ) allow() (ok bool, err error) {
-	var (
-		bowner = core.T.Bowner()
-		b      = meta.CloneBck(&j.bck)
-	)
+func (j *lruJ) allow() (b *meta.Bck, ok bool, err error) {
+	bowner := core.T.Bowner()
+	b = meta.CloneBck(&j.bck)
 	if err = b.Init(bowner); err != nil {
 		return
 	}
 	ok = b.Props.LRU.Enabled && b.Allow(apc.AceObjDELETE) == nil
+
+	// bucket props override cluster config
+	j.policy = cos.Left(b.Props.LRU.Policy, j.config.LRU.Policy)
+	j.remoteOnly = b.Props.LRU.RemoteOnly || j.config.LRU.RemoteOnly
 	return
 }
 
@@ -515,9 +567,9 @@ func (j *lruJ) allow() (ok bool, err error) {
 //////////////
 
 func (h minHeap) Len() int           { return len(h) }
-func (h minHeap) Less(i, j int) bool { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Less(i, j int) bool { return h[i].score < h[j].score }
 func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x any)        { *h = append(*h, x.(*core.LOM)) }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(lruItem)) }
 func (h *minHeap) Pop() any {
 	old := *h
 	n := len(old)
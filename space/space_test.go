@@ -37,6 +37,8 @@ const (
 	basePath             = "/tmp/space-tests"
 	bucketName           = "space-bck"
 	bucketNameAnother    = bucketName + "-another"
+	bucketNameLow        = bucketName + "-low"     // lower LRU priority
+	bucketNameBackend    = bucketName + "-backend" // ais:// with remote backend
 )
 
 type fileMetadata struct {
@@ -61,6 +63,8 @@ var _ = Describe("space evict/cleanup tests", func() {
 		var (
 			filesPath  string
 			fpAnother  string
+			fpLow      string
+			fpBackend  string
 			bckAnother cmn.Bck
 		)
 
@@ -71,10 +75,16 @@ var _ = Describe("space evict/cleanup tests", func() {
 			avail := fs.GetAvail()
 			bck := cmn.Bck{Name: bucketName, Provider: apc.AIS, Ns: cmn.NsGlobal}
 			bckAnother = cmn.Bck{Name: bucketNameAnother, Provider: apc.AIS, Ns: cmn.NsGlobal}
+			bckLow := cmn.Bck{Name: bucketNameLow, Provider: apc.AIS, Ns: cmn.NsGlobal}
+			bckBackend := cmn.Bck{Name: bucketNameBackend, Provider: apc.AIS, Ns: cmn.NsGlobal}
 			filesPath = avail[basePath].MakePathCT(&bck, fs.ObjectType)
 			fpAnother = avail[basePath].MakePathCT(&bckAnother, fs.ObjectType)
+			fpLow = avail[basePath].MakePathCT(&bckLow, fs.ObjectType)
+			fpBackend = avail[basePath].MakePathCT(&bckBackend, fs.ObjectType)
 			cos.CreateDir(filesPath)
 			cos.CreateDir(fpAnother)
+			cos.CreateDir(fpLow)
+			cos.CreateDir(fpBackend)
 		})
 
 		AfterEach(func() {
@@ -171,6 +181,114 @@ var _ = Describe("space evict/cleanup tests", func() {
 				}
 			})
 
+			It("should evict the largest files first [size policy]", func() {
+				const totalSize = 32 * cos.MiB
+				if testing.Short() {
+					Skip("skipping in short mode")
+				}
+				config := cmn.GCO.BeginUpdate()
+				config.LRU.Policy = cmn.LRUPolicySize
+				cmn.GCO.CommitUpdate(config)
+
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(totalSize / blockSize)
+					blocks = uint64(float64(btaken) / initialDiskUsagePct)
+					bavail = blocks - btaken
+					return
+				}
+				files := []fileMetadata{
+					{getRandomFileName(0), int64(4 * cos.MiB)},
+					{getRandomFileName(1), int64(16 * cos.MiB)},
+					{getRandomFileName(2), int64(4 * cos.MiB)},
+					{getRandomFileName(3), int64(8 * cos.MiB)},
+				}
+				saveRandomFilesWithMetadata(filesPath, files)
+
+				// unlike "oldest first" (above), evicting the single 16MB file suffices
+				space.RunLRU(ini)
+
+				filesLeft, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(3))
+				for _, name := range filesLeft {
+					Expect(name.Name()).NotTo(Equal(files[1].name))
+				}
+			})
+
+			It("should evict the least frequently accessed files first [LFU policy]", func() {
+				const numberOfFiles = 6
+				config := cmn.GCO.BeginUpdate()
+				config.LRU.Policy = cmn.LRUPolicyLFU
+				cmn.GCO.CommitUpdate(config)
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				// older but frequently accessed vs. newer accessed just once
+				hotFiles := []fileMetadata{
+					{getRandomFileName(0), fileSize},
+					{getRandomFileName(1), fileSize},
+					{getRandomFileName(2), fileSize},
+				}
+				for _, file := range hotFiles {
+					saveRandomFileAcnt(path.Join(filesPath, file.name), file.size, 10)
+				}
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(filesPath, 3)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+				hotNames := namesFromFilesMetadatas(hotFiles)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), hotNames)).To(BeTrue())
+				}
+			})
+
+			It("should evict from the bucket with lower priority first", func() {
+				const numberOfFiles = 6
+				ini.GetFSStats = getDirsFSStats(numberOfFiles, filesPath, fpLow)
+
+				// (the lower-priority bucket's files are the newest)
+				saveRandomFiles(filesPath, numberOfFiles/2)
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(fpLow, numberOfFiles/2)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfFiles / 2))
+				files, err = os.ReadDir(fpLow)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(BeZero())
+			})
+
+			It("should evict from ais bucket with remote backend [remote-only policy]", func() {
+				const numberOfFiles = 6
+				config := cmn.GCO.BeginUpdate()
+				config.LRU.RemoteOnly = true
+				cmn.GCO.CommitUpdate(config)
+
+				ini.GetFSStats = getDirsFSStats(numberOfFiles, filesPath, fpBackend)
+
+				// (the ais bucket's files are the oldest)
+				saveRandomFiles(filesPath, numberOfFiles/2)
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(fpBackend, numberOfFiles/2)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfFiles / 2))
+				files, err = os.ReadDir(fpBackend)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(BeZero())
+			})
+
 			It("should evict only files from requested bucket [ignores LRU prop]", func() {
 				if testing.Short() {
 					Skip("skipping in short mode")
@@ -252,6 +370,23 @@ var _ = Describe("space evict/cleanup tests", func() {
 				numFilesLeft := len(filesAnother)
 				Expect(numFilesLeft).To(BeNumerically("==", numberOfCreatedFiles))
 			})
+
+			It("should not evict from ais buckets if remote-only policy", func() {
+				const numberOfFiles = 6
+				config := cmn.GCO.BeginUpdate()
+				config.LRU.RemoteOnly = true
+				cmn.GCO.CommitUpdate(config)
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+				ini.Force = true
+				saveRandomFiles(filesPath, numberOfFiles)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfFiles))
+			})
 		})
 
 		Describe("cleanup 'deleted'", func() {
@@ -313,6 +448,26 @@ func getMockGetFSStats(currentFilesNum int) func(string) (uint64, uint64, int64,
 	}
 }
 
+// disk usage computed from the files currently present in the given directories
+// (and the capacity of `currentFilesNum` files at initialDiskUsagePct)
+func getDirsFSStats(currentFilesNum int, dirs ...string) func(string) (uint64, uint64, int64, error) {
+	return func(string) (blocks, bavail uint64, bsize int64, err error) {
+		var used int64
+		for _, dir := range dirs {
+			entries, _ := os.ReadDir(dir)
+			for _, e := range entries {
+				if finfo, err := e.Info(); err == nil {
+					used += finfo.Size()
+				}
+			}
+		}
+		bsize = blockSize
+		blocks = uint64(float64(currentFilesNum*fileSize/blockSize) / initialDiskUsagePct)
+		bavail = blocks - uint64(used/blockSize)
+		return
+	}
+}
+
 func newTargetLRUMock() *mock.TargetMock {
 	// Bucket owner mock, required for LOM
 	var (
@@ -321,11 +476,38 @@ func newTargetLRUMock() *mock.TargetMock {
 				bucketName, apc.AIS, cmn.NsGlobal,
 				&cmn.Bprops{
 					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
-					LRU:    cmn.LRUConf{Enabled: true},
+					LRU:    cmn.LRUConf{Enabled: true, Priority: 1},
 					Access: apc.AccessAll,
 					BID:    0xa7b8c1d2,
 				},
 			),
+			meta.NewBck(
+				bucketNameLow, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true},
+					Access: apc.AccessAll,
+					BID:    0xb1c2d3e4,
+				},
+			),
+			meta.NewBck(
+				bucketNameBackend, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:        cmn.LRUConf{Enabled: true, Priority: 1},
+					Access:     apc.AccessAll,
+					BID:        0xc1d2e3f4,
+					BackendBck: cmn.Bck{Name: "remote", Provider: apc.AWS, Ns: cmn.NsGlobal},
+				},
+			),
+			meta.NewBck(
+				"remote", apc.AWS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					Access: apc.AccessAll,
+					BID:    0xd1e2f3a4,
+				},
+			),
 			meta.NewBck(
 				bucketNameAnother, apc.AIS, cmn.NsGlobal,
 				&cmn.Bprops{
@@ -369,6 +551,8 @@ func initConfig() {
 	config.Space.HighWM = hwm
 	config.Space.LowWM = lwm
 	config.LRU.Enabled = true
+	config.LRU.Policy = ""
+	config.LRU.RemoteOnly = false
 	config.Log.Level = "3"
 	cmn.GCO.CommitUpdate(config)
 }
@@ -387,6 +571,11 @@ func getRandomFileName(fileCounter int) string {
 }
 
 func saveRandomFile(filename string, size int64) {
+	saveRandomFileAcnt(filename, size, 0)
+}
+
+// with a given access count (LFU)
+func saveRandomFileAcnt(filename string, size int64, acnt int) {
 	buff := make([]byte, size)
 	_, err := cos.SaveReader(filename, rand.Reader, buff, cos.ChecksumNone, size)
 	Expect(err).NotTo(HaveOccurred())
@@ -396,6 +585,9 @@ func saveRandomFile(filename string, size int64) {
 	lom.SetSize(size)
 	lom.IncVersion()
 	lom.SetAtimeUnix(time.Now().UnixNano())
+	for range acnt {
+		lom.IncAccessCount()
+	}
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -47,6 +48,9 @@ type (
 
 		// for aistore modules, to add their respective metrics
 		RegExtMetric(node *meta.Snode, name, kind string, extra *Extra)
+
+		// per-bucket (and per-user) metrics
+		AddBck(op BckOp, bck *cmn.Bck, user string, size, latency int64)
 	}
 )
 
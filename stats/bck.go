@@ -0,0 +1,143 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sync"
+	ratomic "sync/atomic"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Per-bucket (and, optionally, per-user) metrics:
+// - target only; enabled and configured via cluster config (see cmn.BckMetricsConf);
+// - GET, cold GET, PUT, and DELETE counts, sizes, and latency histograms
+//   labeled by bucket (and user);
+// - to cap the cardinality, buckets (users) that show up after the configured maximum
+//   number of label sets is reached are all accounted as `BckOther`;
+// - exported to Prometheus (common_prom.go) or StatsD (common_statsd.go).
+
+// BckOp enumerates per-bucket operations
+type BckOp int
+
+const (
+	BckGet     BckOp = iota
+	BckGetCold       // remote GET followed by storing the object locally (counted in addition to BckGet)
+	BckPut
+	BckDelete
+
+	numBckOps
+)
+
+var bckOpNames = [numBckOps]string{"get", "get_cold", "put", "del"}
+
+// label value when over the cap
+const BckOther = "other"
+
+type (
+	bckKey struct {
+		ns       cmn.Ns
+		name     string
+		provider string
+		user     string
+	}
+	bckOpStats struct {
+		lat  *histogram
+		n    int64
+		size int64
+		// StatsD only: values sent last time
+		prev struct {
+			n, size, lat int64
+		}
+	}
+	bckStats struct {
+		bname string // label: bucket's cname, e.g. "s3://abc"
+		user  string // label: AuthN user ID, if any
+		ops   [numBckOps]bckOpStats
+	}
+	bckTracker struct {
+		m  map[bckKey]*bckStats
+		mu sync.RWMutex
+	}
+)
+
+func (op BckOp) String() string { return bckOpNames[op] }
+
+func (op BckOp) hasSize() bool { return op != BckDelete }
+
+func newBckTracker() *bckTracker {
+	return &bckTracker{m: make(map[bckKey]*bckStats, 32)}
+}
+
+func newBckStats(bname, user string) *bckStats {
+	bs := &bckStats{bname: bname, user: user}
+	for op := range bs.ops {
+		bs.ops[op].lat = newHistogram(bckLatBounds)
+	}
+	return bs
+}
+
+func (bt *bckTracker) get(bck *cmn.Bck, user string, maxLabels int) *bckStats {
+	key := bckKey{ns: bck.Ns, name: bck.Name, provider: bck.Provider, user: user}
+	bt.mu.RLock()
+	bs := bt.m[key]
+	bt.mu.RUnlock()
+	if bs != nil {
+		return bs
+	}
+
+	bt.mu.Lock()
+	if bs = bt.m[key]; bs == nil {
+		bname := bck.Cname("")
+		if len(bt.m) >= maxLabels {
+			key = bckKey{name: BckOther}
+			bname, user = BckOther, ""
+			bs = bt.m[key]
+		}
+		if bs == nil {
+			bs = newBckStats(bname, user)
+			bt.m[key] = bs
+		}
+	}
+	bt.mu.Unlock()
+	return bs
+}
+
+func (bt *bckTracker) reset() {
+	bt.mu.Lock()
+	clear(bt.m)
+	bt.mu.Unlock()
+}
+
+// under read lock
+func (bt *bckTracker) each(cb func(bs *bckStats)) {
+	bt.mu.RLock()
+	for _, bs := range bt.m {
+		cb(bs)
+	}
+	bt.mu.RUnlock()
+}
+
+////////////
+// runner //
+////////////
+
+// AddBck updates per-bucket metrics; `latency` is in nanoseconds
+func (r *runner) AddBck(op BckOp, bck *cmn.Bck, user string, size, latency int64) {
+	conf := cmn.Rom.BckMetrics()
+	if r.bck == nil || !conf.Enabled {
+		return
+	}
+	if !conf.PerUser {
+		user = ""
+	}
+	bs := &r.bck.get(bck, user, conf.Max()).ops[op]
+	ratomic.AddInt64(&bs.n, 1)
+	if size > 0 {
+		ratomic.AddInt64(&bs.size, size)
+	}
+	bs.lat.observe(latency)
+}
@@ -0,0 +1,96 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestBckTrackerCap(t *testing.T) {
+	var (
+		bt   = newBckTracker()
+		bck1 = &cmn.Bck{Name: "b1", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bck2 = &cmn.Bck{Name: "b2", Provider: apc.AWS, Ns: cmn.NsGlobal}
+		bck3 = &cmn.Bck{Name: "b3", Provider: apc.AIS, Ns: cmn.NsGlobal}
+	)
+	bs1 := bt.get(bck1, "alice", 3)
+	bs2 := bt.get(bck2, "alice", 3)
+	bs3 := bt.get(bck1, "bob", 3)
+	if bs1 == bs2 || bs1 == bs3 || bs2 == bs3 {
+		t.Fatal("expected distinct label sets")
+	}
+	if bs2.bname != bck2.Cname("") || bs3.user != "bob" {
+		t.Fatalf("unexpected labels (%q, %q), (%q, %q)", bs2.bname, bs2.user, bs3.bname, bs3.user)
+	}
+
+	// over the cap: new buckets and users fold into the single overflow label set
+	other := bt.get(bck3, "alice", 3)
+	if other.bname != BckOther || other.user != "" {
+		t.Fatalf("expected overflow labels, got (%q, %q)", other.bname, other.user)
+	}
+	for _, bs := range []*bckStats{bt.get(bck1, "carol", 3), bt.get(bck3, "", 3), bt.get(bck2, "bob", 3)} {
+		if bs != other {
+			t.Fatalf("expected overflow, got (%q, %q)", bs.bname, bs.user)
+		}
+	}
+	// existing label sets remain
+	if bt.get(bck1, "alice", 3) != bs1 || bt.get(bck1, "bob", 3) != bs3 {
+		t.Fatal("expected existing label sets")
+	}
+	if l := len(bt.m); l != 4 {
+		t.Fatalf("expected 3 label sets plus overflow, got %d", l)
+	}
+
+	bt.reset()
+	if bs := bt.get(bck3, "alice", 3); bs.bname != bck3.Cname("") {
+		t.Fatalf("expected %q upon reset, got %q", bck3.Cname(""), bs.bname)
+	}
+}
+
+func TestAddBck(t *testing.T) {
+	rom := cmn.Rom
+	defer func() { cmn.Rom = rom }()
+	cc := cmn.GCO.Get().ClusterConfig
+	cc.BckMetrics = cmn.BckMetricsConf{Enabled: true, MaxLabels: 1}
+	cmn.Rom.Set(&cc)
+
+	var (
+		r    = &runner{bck: newBckTracker()}
+		bck1 = &cmn.Bck{Name: "b1", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bck2 = &cmn.Bck{Name: "b2", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		lat  = int64(3 * time.Millisecond)
+	)
+	r.AddBck(BckGet, bck1, "alice", 100, lat)
+	r.AddBck(BckGet, bck1, "bob", 200, lat) // (not per-user: same label set)
+	r.AddBck(BckGet, bck2, "", 300, lat)    // over the cap
+	r.AddBck(BckDelete, bck2, "", 0, lat)
+
+	var found int
+	r.bck.each(func(bs *bckStats) {
+		get, del := &bs.ops[BckGet], &bs.ops[BckDelete]
+		switch bs.bname {
+		case bck1.Cname(""):
+			found++
+			if bs.user != "" || get.n != 2 || get.size != 300 || del.n != 0 {
+				t.Errorf("%s: unexpected (%q, %d, %d, %d)", bs.bname, bs.user, get.n, get.size, del.n)
+			}
+		case BckOther:
+			found++
+			if get.n != 1 || get.size != 300 || del.n != 1 {
+				t.Errorf("%s: unexpected (%d, %d, %d)", bs.bname, get.n, get.size, del.n)
+			}
+		default:
+			t.Errorf("unexpected label %q", bs.bname)
+		}
+	})
+	if found != 2 {
+		t.Fatalf("expected 2 label sets, got %d", found)
+	}
+}
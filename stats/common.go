@@ -94,6 +94,10 @@ const (
 	ErrDownloadCount  = errPrefix + "dl.n"
 	ErrPutMirrorCount = errPrefix + "put.mirror.n"
 
+	// rate limiting (requests rejected with HTTP 429)
+	RateLimitBckCount  = "ratelim.bck.n"
+	RateLimitUserCount = "ratelim.user.n"
+
 	// KindLatency
 	// latency stats have numSamples used to compute average latency
 	GetLatency         = "get.ns"
@@ -134,6 +138,7 @@ type (
 		stopCh    chan struct{}
 		ticker    *time.Ticker
 		core      *coreStats
+		bck       *bckTracker // per-bucket metrics (target only)
 		ctracker  copyTracker // to avoid making it at runtime
 		sorted    []string    // sorted names
 		name      string      // this stats-runner's name
@@ -255,6 +260,18 @@ func (r *runner) regCommon(snode *meta.Snode) {
 		},
 	)
 
+	// rate limiting
+	r.reg(snode, RateLimitBckCount, KindCounter,
+		&Extra{
+			Help: "number of requests rejected (throttled) by per-bucket rate limits",
+		},
+	)
+	r.reg(snode, RateLimitUserCount, KindCounter,
+		&Extra{
+			Help: "number of requests rejected (throttled) by per-user rate limits",
+		},
+	)
+
 	// basic latencies
 	r.reg(snode, GetLatency, KindLatency,
 		&Extra{
@@ -521,6 +538,9 @@ func (r *runner) GetStatsV322() (out *NodeV322) {
 
 func (r *runner) ResetStats(errorsOnly bool) {
 	r.core.reset(errorsOnly)
+	if r.bck != nil && !errorsOnly {
+		r.bck.reset()
+	}
 }
 
 func (r *runner) GetMetricNames() cos.StrKVs {
@@ -666,7 +686,8 @@ func hkLogs(int64) time.Duration {
 		finfos  = make([]iofs.FileInfo, 0, nn)
 		verbose = cmn.Rom.FastV(4, cos.SmoduleStats)
 	)
-	for i, logtype := range []string{".INFO.", ".ERROR."} {
+	logtypes := []string{".INFO.", ".ERROR.", ".AUDIT."}
+	for i, logtype := range logtypes {
 		finfos, tot = _sizeLogs(dentries, logtype, finfos)
 		l := len(finfos)
 		switch {
@@ -676,7 +697,7 @@ func hkLogs(int64) time.Duration {
 			}
 		case l > 1:
 			go _rmLogs(tot, maxtotal, logdir, logtype, finfos)
-			if i == 0 {
+			if i < len(logtypes)-1 {
 				finfos = make([]iofs.FileInfo, 0, nn)
 			}
 		default:
@@ -692,7 +713,7 @@ func hkLogs(int64) time.Duration {
 }
 
 // e.g. name: ais.ip-10-0-2-19.root.log.INFO.20180404-031540.2249
-// see also: nlog.InfoLogName, nlog.ErrLogName
+// see also: nlog.InfoLogName, nlog.ErrLogName, nlog.AuditLogName
 func _sizeLogs(dentries []os.DirEntry, logtype string, finfos []iofs.FileInfo) (_ []iofs.FileInfo, tot int64) {
 	clear(finfos)
 	finfos = finfos[:0]
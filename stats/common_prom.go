@@ -28,15 +28,21 @@ type (
 
 	// Stats are tracked via a map of stats names (key) and statsValue (values).
 	statsValue struct {
-		kind       string // enum { KindCounter, ..., KindSpecial }
-		Value      int64  `json:"v,string"`
-		numSamples int64  // (average latency over stats_time)
-		cumulative int64  // REST API
+		kind       string          // enum { KindCounter, ..., KindSpecial }
+		Value      int64           `json:"v,string"`
+		numSamples int64           // (average latency over stats_time)
+		cumulative int64           // REST API
+		hist       *histogram      // KindLatency only
+		pctls      [numPctls]int64 // ditto: percentiles over the last stats_time interval
 	}
 
 	coreStats struct {
-		Tracker   map[string]*statsValue
-		promDesc  promDesc
+		Tracker  map[string]*statsValue
+		promDesc promDesc
+		histDesc promDesc // KindLatency histograms
+		bckDesc  struct {
+			n, size, lat [numBckOps]*prometheus.Desc
+		}
 		sgl       *memsys.SGL
 		statsTime time.Duration
 		cmu       sync.RWMutex // ctracker vs Prometheus Collect()
@@ -56,6 +62,7 @@ var (
 func (s *coreStats) init(size int) {
 	s.Tracker = make(map[string]*statsValue, size)
 	s.promDesc = make(promDesc, size)
+	s.histDesc = make(promDesc, 16)
 
 	s.sgl = memsys.PageMM().NewSGL(memsys.DefaultBufSize)
 }
@@ -98,6 +105,7 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	switch v.kind {
 	case KindLatency:
 		ratomic.AddInt64(&v.numSamples, 1)
+		v.hist.observe(nv.Value)
 		fallthrough
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
@@ -125,6 +133,7 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 				}
 			}
 			out[name] = copyValue{lat}
+			v.hist.percentiles(&v.pctls)
 		case KindThroughput:
 			var throughput int64
 			if throughput = ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
@@ -174,6 +183,7 @@ func (s *coreStats) copyCumulative(ctracker copyTracker) {
 		switch v.kind {
 		case KindLatency:
 			ctracker[name] = copyValue{ratomic.LoadInt64(&v.cumulative)}
+			v.copyPctls(name, ctracker)
 		case KindThroughput:
 			val := copyValue{ratomic.LoadInt64(&v.cumulative)}
 			ctracker[name] = val
@@ -208,6 +218,7 @@ func (s *coreStats) reset(errorsOnly bool) {
 		switch v.kind {
 		case KindLatency:
 			ratomic.StoreInt64(&v.numSamples, 0)
+			v.resetHist()
 			fallthrough
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
@@ -231,6 +242,9 @@ var (
 func (r *runner) reg(snode *meta.Snode, name, kind string, extra *Extra) {
 	v := &statsValue{kind: kind}
 	r.core.Tracker[name] = v
+	if kind == KindLatency {
+		v.hist = newHistogram(latBounds)
+	}
 
 	var (
 		metricName  string
@@ -270,14 +284,57 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, extra *Extra) {
 
 	fullqn := prometheus.BuildFQName("ais" /*namespace*/, snode.Type() /*subsystem*/, metricName)
 	r.core.promDesc[name] = prometheus.NewDesc(fullqn, help, nil /*variableLabels*/, constLabels)
+
+	// in addition, latency histogram, e.g. "ais_target_get_latency_seconds"
+	if kind == KindLatency {
+		hname := strings.ReplaceAll(strings.TrimSuffix(name, ".ns"), ".", "_") + "_latency_seconds"
+		hhelp := name + ": latency histogram (seconds)"
+		if i := strings.IndexByte(help, ':'); i > 0 {
+			hhelp = help[:i] + ": latency histogram (seconds)"
+		}
+		fullqn = prometheus.BuildFQName("ais", snode.Type(), hname)
+		r.core.histDesc[name] = prometheus.NewDesc(fullqn, hhelp, nil, constLabels)
+	}
+}
+
+// per-bucket metrics, e.g.: ais_target_bucket_get_count{bucket="s3://abc",user=""}
+func (s *coreStats) regBck(snode *meta.Snode) {
+	labels := []string{"bucket", "user"}
+	for op := BckGet; op < numBckOps; op++ {
+		name := "bucket_" + op.String()
+		s.bckDesc.n[op] = prometheus.NewDesc(prometheus.BuildFQName("ais", snode.Type(), name+"_count"),
+			op.String()+": number of requests, per bucket", labels, dfltLabels)
+		if op.hasSize() {
+			s.bckDesc.size[op] = prometheus.NewDesc(prometheus.BuildFQName("ais", snode.Type(), name+"_bytes"),
+				op.String()+": total cumulative size (bytes), per bucket", labels, dfltLabels)
+		}
+		s.bckDesc.lat[op] = prometheus.NewDesc(prometheus.BuildFQName("ais", snode.Type(), name+"_latency_seconds"),
+			op.String()+": latency histogram (seconds), per bucket", labels, dfltLabels)
+	}
 }
 
+// empty stab (StatsD only)
+func (*coreStats) statsdBck(*bckTracker) {}
+
 func (*runner) IsPrometheus() bool { return true }
 
 func (r *runner) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range r.core.promDesc {
 		ch <- desc
 	}
+	for _, desc := range r.core.histDesc {
+		ch <- desc
+	}
+	if r.bck == nil {
+		return
+	}
+	for op := BckGet; op < numBckOps; op++ {
+		ch <- r.core.bckDesc.n[op]
+		if op.hasSize() {
+			ch <- r.core.bckDesc.size[op]
+		}
+		ch <- r.core.bckDesc.lat[op]
+	}
 }
 
 func (r *runner) Collect(ch chan<- prometheus.Metric) {
@@ -328,7 +385,54 @@ func (r *runner) Collect(ch chan<- prometheus.Metric) {
 		debug.AssertNoErr(err)
 		ch <- m
 	}
+	for name, desc := range r.core.histDesc {
+		v := r.core.Tracker[name]
+		buckets, count, sum := v.hist.promBuckets(promLatStep)
+		if count == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstHistogram(desc, count, sum, buckets)
+	}
 	r.core.promRUnlock()
+
+	if r.bck != nil {
+		r.collectBck(ch)
+	}
+}
+
+func (r *runner) collectBck(ch chan<- prometheus.Metric) {
+	desc := &r.core.bckDesc
+	r.bck.each(func(bs *bckStats) {
+		for op := BckGet; op < numBckOps; op++ {
+			s := &bs.ops[op]
+			n := ratomic.LoadInt64(&s.n)
+			if n == 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(desc.n[op], prometheus.CounterValue, float64(n), bs.bname, bs.user)
+			if op.hasSize() {
+				size := ratomic.LoadInt64(&s.size)
+				ch <- prometheus.MustNewConstMetric(desc.size[op], prometheus.CounterValue, float64(size), bs.bname, bs.user)
+			}
+			buckets, count, sum := s.lat.promBuckets(1)
+			ch <- prometheus.MustNewConstHistogram(desc.lat[op], count, sum, buckets, bs.bname, bs.user)
+		}
+	})
+}
+
+// cumulative counts at every `step`-th bound (seconds), total count, and sum (seconds)
+// NOTE: returns new map each time (const histogram does not copy it)
+func (h *histogram) promBuckets(step int) (buckets map[float64]uint64, count uint64, sum float64) {
+	var (
+		cumul []uint64
+		isum  int64
+	)
+	cumul, count, isum = h.cumulative(make([]uint64, 0, len(h.bounds)))
+	buckets = make(map[float64]uint64, len(h.bounds)/step+1)
+	for i := 0; i < len(h.bounds); i += step {
+		buckets[time.Duration(h.bounds[i]).Seconds()] = cumul[i]
+	}
+	return buckets, count, time.Duration(isum).Seconds()
 }
 
 func (r *runner) Stop(err error) {
@@ -0,0 +1,42 @@
+//go:build !statsd
+
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromBuckets(t *testing.T) {
+	h := newHistogram(latBounds)
+	h.observe(int64(latBoundMin))
+	h.observe(int64(time.Millisecond))
+	h.observe(int64(time.Hour)) // +Inf
+
+	buckets, count, sum := h.promBuckets(promLatStep)
+	if count != 3 {
+		t.Fatalf("expected count 3, got %d", count)
+	}
+	if want := (latBoundMin + time.Millisecond + time.Hour).Seconds(); sum != want {
+		t.Fatalf("expected sum %v, got %v", want, sum)
+	}
+	if l := len(buckets); l != (numLatBounds+promLatStep-1)/promLatStep {
+		t.Fatalf("unexpected number of buckets %d", l)
+	}
+	for bound, want := range map[time.Duration]uint64{
+		16 * time.Microsecond:   1,
+		64 * time.Microsecond:   1,
+		256 * time.Microsecond:  1,
+		1024 * time.Microsecond: 2, // cumulative
+		time.Duration(latBounds[(numLatBounds-1)/promLatStep*promLatStep]): 2, // (the last one exported)
+	} {
+		if got, ok := buckets[bound.Seconds()]; !ok || got != want {
+			t.Errorf("bucket %v: expected %d, got %d (%t)", bound, want, got, ok)
+		}
+	}
+}
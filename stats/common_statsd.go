@@ -34,20 +34,27 @@ type (
 		label struct {
 			comm string // common part of the metric label (as in: <prefix> . comm . <suffix>)
 			stpr string // StatsD _or_ Prometheus label (depending on build tag)
+			pctl [numPctls]string
 		}
-		Value      int64 `json:"v,string"`
-		numSamples int64 // (average latency over stats_time)
-		cumulative int64 // REST API
+		Value      int64           `json:"v,string"`
+		numSamples int64           // (average latency over stats_time)
+		cumulative int64           // REST API
+		hist       *histogram      // KindLatency only
+		pctls      [numPctls]int64 // ditto: percentiles over the last stats_time interval
 	}
 
 	coreStats struct {
 		Tracker   map[string]*statsValue
 		statsdC   *statsd.Client
 		sgl       *memsys.SGL
+		bckPrefix string // per-bucket metrics
 		statsTime time.Duration
 	}
 )
 
+// StatsD-safe bucket and user labels
+var bckLabelRepl = strings.NewReplacer("://", ".", ".", "_", ":", "_", "/", "_", "|", "_", "@", "_", "#", "_")
+
 // interface guard
 var (
 	_ Tracker = (*Prunner)(nil)
@@ -131,6 +138,7 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	switch v.kind {
 	case KindLatency:
 		ratomic.AddInt64(&v.numSamples, 1)
+		v.hist.observe(nv.Value)
 		fallthrough
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
@@ -171,6 +179,14 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 			if !s.statsdDisabled() && millis > 0 {
 				s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: v.label.stpr, Value: float64(millis)}, s.sgl)
 			}
+			// and percentiles (milliseconds, as gauges)
+			v.hist.percentiles(&v.pctls)
+			if !s.statsdDisabled() && lat > 0 {
+				for i := range v.pctls {
+					pms := float64(v.pctls[i]) / float64(time.Millisecond)
+					s.statsdC.AppMetric(metric{Type: statsd.Gauge, Name: v.label.pctl[i], Value: pms}, s.sgl)
+				}
+			}
 		case KindThroughput:
 			var throughput int64
 			if throughput = ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
@@ -245,6 +261,7 @@ func (s *coreStats) copyCumulative(ctracker copyTracker) {
 		switch v.kind {
 		case KindLatency:
 			ctracker[name] = copyValue{ratomic.LoadInt64(&v.cumulative)}
+			v.copyPctls(name, ctracker)
 		case KindThroughput:
 			val := copyValue{ratomic.LoadInt64(&v.cumulative)}
 			ctracker[name] = val
@@ -279,6 +296,7 @@ func (s *coreStats) reset(errorsOnly bool) {
 		switch v.kind {
 		case KindLatency:
 			ratomic.StoreInt64(&v.numSamples, 0)
+			v.resetHist()
 			fallthrough
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
@@ -290,6 +308,49 @@ func (s *coreStats) reset(errorsOnly bool) {
 	}
 }
 
+// per-bucket metrics, e.g.: "aistarget.<ID>.bucket.s3.abc.get.count"
+func (s *coreStats) regBck(snode *meta.Snode) {
+	s.bckPrefix = "ais" + snode.Type() + "." + snode.ID() + ".bucket."
+}
+
+// per-bucket counts, sizes, and average latencies over the last "periodic.stats_time" interval
+// (only the stats runner updates `prev` values)
+func (s *coreStats) statsdBck(bt *bckTracker) {
+	if s.statsdDisabled() || bt == nil {
+		return
+	}
+	s.sgl.Reset()
+	bt.each(func(bs *bckStats) {
+		label := s.bckPrefix + bckLabelRepl.Replace(bs.bname)
+		if bs.user != "" {
+			label += "." + bckLabelRepl.Replace(bs.user)
+		}
+		for op := BckGet; op < numBckOps; op++ {
+			var (
+				v  = &bs.ops[op]
+				n  = ratomic.LoadInt64(&v.n)
+				dn = n - v.prev.n
+			)
+			if dn <= 0 {
+				continue
+			}
+			var (
+				lat  = ratomic.LoadInt64(&v.lat.sum)
+				size = ratomic.LoadInt64(&v.size)
+				pfx  = label + "." + op.String()
+			)
+			s.statsdC.AppMetric(metric{Type: statsd.Counter, Name: pfx + ".count", Value: dn}, s.sgl)
+			if op.hasSize() {
+				s.statsdC.AppMetric(metric{Type: statsd.Counter, Name: pfx + ".bytes", Value: size - v.prev.size}, s.sgl)
+			}
+			millis := cos.DivRound((lat-v.prev.lat)/dn, int64(time.Millisecond))
+			s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: pfx + ".ms", Value: float64(millis)}, s.sgl)
+			v.prev.n, v.prev.size, v.prev.lat = n, size, lat
+		}
+	})
+	s.statsdC.SendSGL(s.sgl)
+}
+
 ////////////
 // runner //
 ////////////
@@ -320,6 +381,10 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, _ *Extra) {
 		v.label.comm = strings.TrimSuffix(name, ".ns")
 		v.label.comm = strings.ReplaceAll(v.label.comm, ":", "_")
 		v.label.stpr = f("ms")
+		for i, sfx := range PctlSuffixes {
+			v.label.pctl[i] = f(sfx[1:]) // e.g. "aistarget.<ID>.get.p99"
+		}
+		v.hist = newHistogram(latBounds)
 	case KindThroughput, KindComputedThroughput:
 		debug.Assert(strings.HasSuffix(name, ".bps"), name)
 		v.label.comm = strings.TrimSuffix(name, ".bps")
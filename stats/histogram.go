@@ -0,0 +1,166 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math"
+	"sort"
+	"strings"
+	ratomic "sync/atomic"
+	"time"
+)
+
+// fixed-bucket (lockless) latency histogram
+type histogram struct {
+	bounds []int64 // bucket upper bounds (nanoseconds), in increasing order
+	counts []int64 // (not cumulative) len(bounds) + 1, with the last one being +Inf
+	sum    int64   // total nanoseconds
+	// percentiles over the last "periodic.stats_time" interval (stats runner only)
+	prev  []int64
+	delta []int64
+}
+
+// node-level latencies (KindLatency): log-scale buckets, 4 per doubling, from 16us to ~9 minutes,
+// to compute percentiles with (worst-case) ~20% precision;
+// Prometheus gets every 8th bound (16us, 64us, 256us, 1ms, 4ms, ..., ~4.5min)
+const (
+	latBoundMin  = 16 * time.Microsecond
+	latPerDouble = 4
+	numLatBounds = 25*latPerDouble + 1
+	promLatStep  = 2 * latPerDouble
+)
+
+var latBounds = func() (bounds []int64) {
+	bounds = make([]int64, numLatBounds)
+	for i := range bounds {
+		bounds[i] = int64(math.Round(float64(latBoundMin) * math.Exp2(float64(i)/latPerDouble)))
+	}
+	return bounds
+}()
+
+// reported latency percentiles, e.g. "get.ns.p99" (see also `IsPctlMetric`)
+const numPctls = 4
+
+var (
+	pctls        = [numPctls]float64{0.5, 0.95, 0.99, 0.999}
+	PctlSuffixes = [numPctls]string{".p50", ".p95", ".p99", ".p999"}
+)
+
+func IsPctlMetric(name string) bool {
+	if i := strings.LastIndexByte(name, '.'); i > 0 && strings.HasSuffix(name[:i], ".ns") {
+		for _, sfx := range PctlSuffixes {
+			if name[i:] == sfx {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// default per-bucket latency buckets
+var bckLatBounds = []int64{
+	int64(time.Millisecond),
+	int64(2500 * time.Microsecond),
+	int64(5 * time.Millisecond),
+	int64(10 * time.Millisecond),
+	int64(25 * time.Millisecond),
+	int64(50 * time.Millisecond),
+	int64(100 * time.Millisecond),
+	int64(250 * time.Millisecond),
+	int64(500 * time.Millisecond),
+	int64(time.Second),
+	int64(2500 * time.Millisecond),
+	int64(5 * time.Second),
+	int64(10 * time.Second),
+}
+
+func newHistogram(bounds []int64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]int64, len(bounds)+1)}
+}
+
+func (h *histogram) observe(ns int64) {
+	i := sort.Search(len(h.bounds), func(i int) bool { return ns <= h.bounds[i] })
+	ratomic.AddInt64(&h.counts[i], 1)
+	ratomic.AddInt64(&h.sum, ns)
+}
+
+// returns cumulative (less-or-equal) counts, one per bound, total count, and sum
+func (h *histogram) cumulative(out []uint64) (_ []uint64, count uint64, sum int64) {
+	out = out[:0]
+	for i := range h.bounds {
+		count += uint64(ratomic.LoadInt64(&h.counts[i]))
+		out = append(out, count)
+	}
+	count += uint64(ratomic.LoadInt64(&h.counts[len(h.bounds)]))
+	return out, count, ratomic.LoadInt64(&h.sum)
+}
+
+// REST API: (non-zero) percentiles, e.g. "get.ns.p99"
+func (v *statsValue) copyPctls(name string, ctracker copyTracker) {
+	for i, sfx := range PctlSuffixes {
+		if p := ratomic.LoadInt64(&v.pctls[i]); p > 0 {
+			ctracker[name+sfx] = copyValue{p}
+		}
+	}
+}
+
+func (v *statsValue) resetHist() {
+	v.hist.reset()
+	for i := range v.pctls {
+		ratomic.StoreInt64(&v.pctls[i], 0)
+	}
+}
+
+func (h *histogram) reset() {
+	for i := range h.counts {
+		ratomic.StoreInt64(&h.counts[i], 0)
+	}
+	ratomic.StoreInt64(&h.sum, 0)
+}
+
+// computes percentiles of the samples observed since the previous call
+// (linearly interpolating within buckets); zeros when there are none
+func (h *histogram) percentiles(out *[numPctls]int64) {
+	if h.prev == nil {
+		h.prev, h.delta = make([]int64, len(h.counts)), make([]int64, len(h.counts))
+	}
+	var total int64
+	for i := range h.counts {
+		c := ratomic.LoadInt64(&h.counts[i])
+		if c >= h.prev[i] {
+			h.delta[i] = c - h.prev[i]
+		} else {
+			h.delta[i] = c // reset in between
+		}
+		h.prev[i] = c
+		total += h.delta[i]
+	}
+	for j, q := range pctls {
+		var (
+			p    int64
+			cum  int64
+			rank = q * float64(total)
+		)
+		for i, d := range h.delta {
+			if d == 0 || float64(cum+d) < rank {
+				cum += d
+				continue
+			}
+			var lo, hi int64
+			if i > 0 {
+				lo = h.bounds[i-1]
+			}
+			if i < len(h.bounds) {
+				hi = h.bounds[i]
+			} else {
+				hi = lo // +Inf
+			}
+			p = lo + int64((rank-float64(cum))/float64(d)*float64(hi-lo))
+			break
+		}
+		ratomic.StoreInt64(&out[j], p)
+	}
+}
@@ -0,0 +1,90 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+)
+
+func TestPercentiles(t *testing.T) {
+	var (
+		h   = newHistogram([]int64{10, 20, 30, 40})
+		out [numPctls]int64
+	)
+	check := func(tag string, want [numPctls]int64) {
+		t.Helper()
+		h.percentiles(&out)
+		if out != want {
+			t.Errorf("%s: expected %v, got %v", tag, want, out)
+		}
+	}
+
+	check("empty", [numPctls]int64{})
+
+	// 50 samples in (0, 10] and 50 in (10, 20]: interpolate within buckets
+	for range 50 {
+		h.observe(5)
+		h.observe(15)
+	}
+	check("interpolated", [numPctls]int64{10, 19, 19, 19})
+
+	// only the samples observed since the previous call
+	check("no new samples", [numPctls]int64{})
+	for range 100 {
+		h.observe(25)
+	}
+	check("single bucket", [numPctls]int64{25, 29, 29, 29})
+
+	// +Inf bucket: the largest bound
+	for range 10 {
+		h.observe(1000)
+	}
+	check("+Inf", [numPctls]int64{40, 40, 40, 40})
+
+	// reset in between
+	h.reset()
+	for range 4 {
+		h.observe(35)
+	}
+	check("upon reset", [numPctls]int64{35, 39, 39, 39})
+}
+
+func TestLatBounds(t *testing.T) {
+	if latBounds[0] != int64(latBoundMin) || latBounds[latPerDouble] != 2*int64(latBoundMin) {
+		t.Fatalf("unexpected bounds %v", latBounds[:latPerDouble+1])
+	}
+	for i := 1; i < len(latBounds); i++ {
+		if latBounds[i] <= latBounds[i-1] {
+			t.Fatalf("bounds must be increasing: [%d]=%d, [%d]=%d", i-1, latBounds[i-1], i, latBounds[i])
+		}
+	}
+}
+
+func TestIsPctlMetric(t *testing.T) {
+	for name, is := range map[string]bool{
+		"get.ns.p50":       true,
+		"get.ns.p99":       true,
+		"put.ns.p999":      true,
+		"ec.encode.ns.p95": true,
+		"get.ns.p90":       false, // (not reported)
+		"get.p99":          false,
+		"get.ns":           false,
+		"get.ns.total":     false,
+		"get.n":            false,
+		"p99":              false,
+		"lst.ns.p99.extra": false,
+	} {
+		if IsPctlMetric(name) != is {
+			t.Errorf("%q: expected %t", name, is)
+		}
+	}
+	// all reported percentiles are recognized as such
+	for _, sfx := range PctlSuffixes {
+		if !IsPctlMetric("get.ns" + sfx) {
+			t.Errorf("%q: expected percentile", "get.ns"+sfx)
+		}
+	}
+}
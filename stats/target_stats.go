@@ -150,6 +150,7 @@ func (r *Trunner) Init() *atomic.Bool {
 	r.core = &coreStats{}
 
 	r.core.init(numTargetStats)
+	r.bck = newBckTracker()
 
 	r.regCommon(r.t.Snode())
 
@@ -451,6 +452,9 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 			Help: "number of times a LOM from cache was written to stable storage (core, internal)",
 		},
 	)
+
+	// per-bucket
+	r.core.regBck(snode)
 }
 
 func (r *Trunner) RegDiskMetrics(snode *meta.Snode, disk string) {
@@ -575,6 +579,7 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 	s.promLock()
 	idle := s.copyT(r.ctracker, config.Disk.DiskUtilLowWM)
 	s.promUnlock()
+	s.statsdBck(r.bck)
 
 	if now >= r.next || !idle {
 		s.sgl.Reset() // sharing w/ CoreStats.copyT
@@ -170,6 +170,9 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{}, true)
 	fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{}, true)
+	fs.CSM.Reg(fs.MptType, &fs.MptContentResolver{}, true)
+	fs.CSM.Reg(fs.PriorVerType, &fs.PriorVerContentResolver{}, true)
+	fs.CSM.Reg(fs.TarIdxType, &fs.TarIdxContentResolver{}, true)
 
 	dir := t.TempDir()
 
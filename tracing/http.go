@@ -0,0 +1,158 @@
+// Package tracing provides distributed tracing for aistore: W3C trace-context propagation,
+// OpenTelemetry spans, and OTLP/HTTP export.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const hdrTraceparent = "traceparent"
+
+// captures response status
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// NewHandler wraps http handler to start server span for each incoming request that:
+// a) carries trace context either in the (W3C) header or in the redirect URL (see QparamTraceparent), or
+// b) comes from outside the cluster (a new trace, subject to sampling);
+// intra-cluster requests with no trace context are not traced
+func NewHandler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		st := cur.Load()
+		if st == nil || r.Method == http.MethodConnect /*hijacking*/ {
+			h(w, r)
+			return
+		}
+		ctx, ok := parentCtx(r)
+		if !ok {
+			h(w, r)
+			return
+		}
+		ctx, span := st.tracer.Start(ctx, r.Method+" "+route(r.URL.Path),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("client.address", r.RemoteAddr),
+			),
+		)
+		sw := &statusWriter{ResponseWriter: w}
+		h(sw, r.WithContext(ctx))
+		EndHTTP(span, sw.status, nil)
+	}
+}
+
+func parentCtx(r *http.Request) (context.Context, bool) {
+	ctx := r.Context()
+	if strings.Contains(r.URL.RawQuery, apc.QparamTraceparent+"=") {
+		if q, err := url.ParseQuery(r.URL.RawQuery); err == nil {
+			if tp := q.Get(apc.QparamTraceparent); tp != "" {
+				return extract(ctx, tp), true
+			}
+		}
+	}
+	if tp := r.Header.Get(hdrTraceparent); tp != "" {
+		return extract(ctx, tp), true
+	}
+	return ctx, r.Header.Get(apc.HdrCallerID) == ""
+}
+
+// StartClient starts client span for the outgoing (intra-cluster) request, provided
+// the request carries trace context (see Inject), and replaces the latter with
+// the context of the new span
+func StartClient(req *http.Request) trace.Span {
+	st := cur.Load()
+	if st == nil {
+		return noopSpan
+	}
+	tp := req.Header.Get(hdrTraceparent)
+	if tp == "" {
+		return noopSpan
+	}
+	ctx, span := st.tracer.Start(extract(context.Background(), tp), req.Method+" "+route(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	prop.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return span
+}
+
+// EndHTTP ends server or client span given the response status and/or error
+func EndHTTP(span trace.Span, status int, err error) {
+	if !span.IsRecording() {
+		return
+	}
+	if status == 0 && err == nil {
+		status = http.StatusOK
+	}
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	switch {
+	case err != nil:
+		span.SetStatus(codes.Error, err.Error())
+	case status >= http.StatusInternalServerError:
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	span.End()
+}
+
+// low-cardinality span name, e.g. "/v1/objects" or "/s3"
+func route(path string) string {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if parts[0] == apc.Version && len(parts) > 1 {
+		return "/" + parts[0] + "/" + parts[1]
+	}
+	return "/" + parts[0]
+}
+
+//////////////////
+// statusWriter //
+//////////////////
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// to keep using the underlying (sendfile-capable) io.ReaderFrom
+func (sw *statusWriter) ReadFrom(r io.Reader) (int64, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	if rf, ok := sw.ResponseWriter.(io.ReaderFrom); ok {
+		return rf.ReadFrom(r)
+	}
+	return io.Copy(struct{ io.Writer }{sw.ResponseWriter}, r)
+}
+
+// (see http.ResponseController)
+func (sw *statusWriter) Unwrap() http.ResponseWriter { return sw.ResponseWriter }
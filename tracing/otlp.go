@@ -0,0 +1,248 @@
+// Package tracing provides distributed tracing for aistore: W3C trace-context propagation,
+// OpenTelemetry spans, and OTLP/HTTP export.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OTLP/HTTP exporter that uses JSON encoding of the OTLP protocol
+// (see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding)
+// to keep gRPC and protobuf dependencies out of aisnode.
+
+const (
+	otlpPath    = "/v1/traces"
+	otlpTimeout = 10 * time.Second
+)
+
+type (
+	exporter struct {
+		client *http.Client
+		url    string
+		auth   string
+	}
+
+	// OTLP JSON (subset)
+	otlpReq struct {
+		ResourceSpans []*otlpRS `json:"resourceSpans"`
+	}
+	otlpRS struct {
+		Resource   otlpRes   `json:"resource"`
+		ScopeSpans []*otlpSS `json:"scopeSpans"`
+	}
+	otlpRes struct {
+		Attributes []otlpKV `json:"attributes,omitempty"`
+	}
+	otlpSS struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID      string      `json:"traceId"`
+		SpanID       string      `json:"spanId"`
+		ParentSpanID string      `json:"parentSpanId,omitempty"`
+		Name         string      `json:"name"`
+		Start        string      `json:"startTimeUnixNano"`
+		End          string      `json:"endTimeUnixNano"`
+		Attributes   []otlpKV    `json:"attributes,omitempty"`
+		Events       []otlpEvent `json:"events,omitempty"`
+		Status       otlpStatus  `json:"status"`
+		Kind         int         `json:"kind"`
+	}
+	otlpEvent struct {
+		Time       string   `json:"timeUnixNano"`
+		Name       string   `json:"name"`
+		Attributes []otlpKV `json:"attributes,omitempty"`
+	}
+	otlpStatus struct {
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code,omitempty"`
+	}
+	otlpKV struct {
+		Key   string  `json:"key"`
+		Value otlpAny `json:"value"`
+	}
+	otlpAny struct {
+		StringValue *string    `json:"stringValue,omitempty"`
+		BoolValue   *bool      `json:"boolValue,omitempty"`
+		IntValue    *string    `json:"intValue,omitempty"`
+		DoubleValue *float64   `json:"doubleValue,omitempty"`
+		ArrayValue  *otlpArray `json:"arrayValue,omitempty"`
+	}
+	otlpArray struct {
+		Values []otlpAny `json:"values"`
+	}
+)
+
+// interface guard
+var _ sdktrace.SpanExporter = (*exporter)(nil)
+
+func newExporter(conf *cmn.TracingConf) *exporter {
+	var (
+		client *http.Client
+		cargs  = cmn.TransportArgs{Timeout: otlpTimeout}
+	)
+	if conf.SkipVerify {
+		client = cmn.NewClientTLS(cargs, cmn.TLSArgs{SkipVerify: true}, false /*intra-cluster*/)
+	} else {
+		client = cmn.NewClient(cargs)
+	}
+	u := strings.TrimSuffix(conf.ExporterEndpoint, "/")
+	if !strings.HasSuffix(u, otlpPath) {
+		u += otlpPath
+	}
+	return &exporter{client: client, url: u, auth: conf.ExporterAuth}
+}
+
+func (e *exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	b, err := jsoniter.Marshal(encode(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	if e.auth != "" {
+		req.Header.Set(apc.HdrAuthorization, e.auth)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("tracing: failed to export %d span(s) to %s: %s", len(spans), e.url, resp.Status)
+	}
+	return nil
+}
+
+func (e *exporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+//
+// encoding
+//
+
+func encode(spans []sdktrace.ReadOnlySpan) *otlpReq {
+	var (
+		req = &otlpReq{}
+		rss = make(map[*resource.Resource]*otlpRS, 1)
+	)
+	for _, s := range spans {
+		rs, ok := rss[s.Resource()]
+		if !ok {
+			rs = &otlpRS{Resource: otlpRes{Attributes: encAttrs(s.Resource().Attributes())}}
+			rss[s.Resource()] = rs
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+		}
+		scope := s.InstrumentationScope()
+		var ss *otlpSS
+		for _, x := range rs.ScopeSpans {
+			if x.Scope.Name == scope.Name && x.Scope.Version == scope.Version {
+				ss = x
+				break
+			}
+		}
+		if ss == nil {
+			ss = &otlpSS{Scope: otlpScope{Name: scope.Name, Version: scope.Version}}
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, encSpan(s))
+	}
+	return req
+}
+
+func encSpan(s sdktrace.ReadOnlySpan) otlpSpan {
+	sc := s.SpanContext()
+	span := otlpSpan{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		Name:       s.Name(),
+		Kind:       int(s.SpanKind()), // (same enum values)
+		Start:      encTime(s.StartTime()),
+		End:        encTime(s.EndTime()),
+		Attributes: encAttrs(s.Attributes()),
+	}
+	if parent := s.Parent(); parent.IsValid() {
+		span.ParentSpanID = parent.SpanID().String()
+	}
+	for _, ev := range s.Events() {
+		span.Events = append(span.Events,
+			otlpEvent{Time: encTime(ev.Time), Name: ev.Name, Attributes: encAttrs(ev.Attributes)})
+	}
+	status := s.Status()
+	switch status.Code {
+	case codes.Error:
+		span.Status = otlpStatus{Code: 2, Message: status.Description}
+	case codes.Ok:
+		span.Status = otlpStatus{Code: 1}
+	}
+	return span
+}
+
+func encTime(t time.Time) string { return strconv.FormatInt(t.UnixNano(), 10) }
+
+func encAttrs(attrs []attribute.KeyValue) []otlpKV {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKV, 0, len(attrs))
+	for _, kv := range attrs {
+		kvs = append(kvs, otlpKV{Key: string(kv.Key), Value: encValue(kv.Value)})
+	}
+	return kvs
+}
+
+func encValue(v attribute.Value) (a otlpAny) {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		a.BoolValue = &b
+	case attribute.INT64:
+		s := strconv.FormatInt(v.AsInt64(), 10)
+		a.IntValue = &s
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		a.DoubleValue = &f
+	case attribute.STRINGSLICE:
+		arr := &otlpArray{}
+		for _, s := range v.AsStringSlice() {
+			arr.Values = append(arr.Values, encValue(attribute.StringValue(s)))
+		}
+		a.ArrayValue = arr
+	default:
+		s := v.Emit()
+		a.StringValue = &s
+	}
+	return a
+}
@@ -0,0 +1,200 @@
+// Package tracing provides distributed tracing for aistore: W3C trace-context propagation,
+// OpenTelemetry spans, and OTLP/HTTP export.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing is disabled by default, in which case all the APIs below are (almost) free no-ops.
+// When enabled:
+// - each node starts a server span for every incoming request that either carries
+//   W3C trace context (see `NewHandler`) or comes from outside the cluster;
+// - the context is propagated through the proxy => target redirect (via `QparamTraceparent`),
+//   intra-cluster calls (via `Inject`), and transport sends;
+// - spans are batched and exported to the configured collector (see otlp.go).
+
+const (
+	tracerName   = "github.com/NVIDIA/aistore"
+	serviceName  = "aistore"
+	shutdownTout = 4 * time.Second
+)
+
+type tstate struct {
+	tp     *sdktrace.TracerProvider
+	tracer trace.Tracer
+	conf   cmn.TracingConf
+}
+
+var (
+	cur   atomic.Pointer[tstate] // nil when disabled
+	mu    sync.Mutex             // serializes Init, Update, and Shutdown
+	snode *meta.Snode
+	texp  sdktrace.SpanExporter // when non-nil, overrides OTLP exporter (testing)
+
+	prop     propagation.TraceContext
+	noopSpan = trace.SpanFromContext(context.Background())
+)
+
+// Init is called once upon node startup; `exp` is optional (nil: OTLP per config)
+func Init(conf *cmn.TracingConf, si *meta.Snode, exp sdktrace.SpanExporter) {
+	mu.Lock()
+	snode, texp = si, exp
+	mu.Unlock()
+	Update(conf)
+}
+
+// Update (re)starts or stops tracing when the respective configuration changes
+func Update(conf *cmn.TracingConf) {
+	mu.Lock()
+	defer mu.Unlock()
+	old := cur.Load()
+	if old != nil && old.conf == *conf {
+		return
+	}
+	if old == nil && !conf.Enabled {
+		return
+	}
+	var nst *tstate
+	if conf.Enabled {
+		nst = newState(conf)
+		nlog.Infoln("tracing: exporting to", conf.ExporterEndpoint)
+	} else {
+		nlog.Infoln("tracing: disabled")
+	}
+	cur.Store(nst)
+	if old != nil {
+		go stop(old) // flush the spans in progress
+	}
+}
+
+// Shutdown flushes pending spans and disables tracing
+func Shutdown() {
+	mu.Lock()
+	old := cur.Swap(nil)
+	mu.Unlock()
+	if old != nil {
+		stop(old)
+	}
+}
+
+func newState(conf *cmn.TracingConf) *tstate {
+	exp := texp
+	if exp == nil {
+		exp = newExporter(conf)
+	}
+	sampler := sdktrace.AlwaysSample()
+	if p := conf.SamplerProbability; p > 0 && p < 1 {
+		sampler = sdktrace.TraceIDRatioBased(p)
+	}
+	attrs := []attribute.KeyValue{attribute.String("service.name", serviceName)}
+	if snode != nil {
+		attrs = append(attrs,
+			attribute.String("service.instance.id", snode.ID()),
+			attribute.String("ais.node.type", snode.Type()),
+		)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+	)
+	return &tstate{tp: tp, tracer: tp.Tracer(tracerName), conf: *conf}
+}
+
+func stop(st *tstate) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTout)
+	if err := st.tp.Shutdown(ctx); err != nil {
+		nlog.Warningln("tracing: shutdown:", err)
+	}
+	cancel()
+}
+
+func IsEnabled() bool { return cur.Load() != nil }
+
+// Start starts a new internal span; callers must always `End` it
+// (the returned span is a no-op when tracing is disabled)
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	st := cur.Load()
+	if st == nil {
+		return ctx, noopSpan
+	}
+	return st.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChild is Start that does nothing (and returns no-op span) unless the context
+// carries a span - to avoid creating orphaned root spans by the flows that are
+// not always traced
+func StartChild(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, noopSpan
+	}
+	return Start(ctx, name, attrs...)
+}
+
+// StartObj is StartChild that also names the object in question
+func StartObj(ctx context.Context, name string, obj interface{ Cname() string }) (context.Context, trace.Span) {
+	if cur.Load() == nil || ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, noopSpan
+	}
+	return Start(ctx, name, attribute.String("ais.object", obj.Cname()))
+}
+
+// End ends the span, recording the error, if any
+func End(span trace.Span, err error) {
+	if !span.IsRecording() {
+		return
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a new background context that carries the span (if any) of the given
+// context but not its cancellation or deadline - for the work that may outlive the request
+func Detach(ctx context.Context) context.Context {
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return context.Background()
+	}
+	return trace.ContextWithSpan(context.Background(), span)
+}
+
+// Inject adds W3C trace context (traceparent) of the current span, if any, to the headers
+func Inject(ctx context.Context, hdr http.Header) {
+	if IsEnabled() {
+		prop.Inject(ctx, propagation.HeaderCarrier(hdr))
+	}
+}
+
+// Traceparent returns W3C traceparent of the current span, or empty string
+func Traceparent(ctx context.Context) string {
+	if !IsEnabled() {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+	return carrier[hdrTraceparent]
+}
+
+func extract(ctx context.Context, traceparent string) context.Context {
+	return prop.Extract(ctx, propagation.MapCarrier{hdrTraceparent: traceparent})
+}
@@ -0,0 +1,146 @@
+// Package tracing provides distributed tracing for aistore: W3C trace-context propagation,
+// OpenTelemetry spans, and OTLP/HTTP export.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tracing"
+)
+
+const (
+	clientTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	clientSpanID  = "00f067aa0ba902b7"
+	proxyTraceID  = "0af7651916cd43dd8448eb211c80319c"
+	proxySpanID   = "b7ad6b7169203331"
+)
+
+type (
+	rspan struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+		Status       struct {
+			Code int `json:"code"`
+		} `json:"status"`
+		Kind int `json:"kind"`
+	}
+	// in-process OTLP/HTTP (JSON) collector
+	collector struct {
+		spans []rspan
+		mu    sync.Mutex
+	}
+)
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []rspan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if r.URL.Path != "/v1/traces" || json.NewDecoder(r.Body).Decode(&req) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	c.mu.Unlock()
+}
+
+func (c *collector) find(name string) (found []rspan) {
+	for _, s := range c.spans {
+		if s.Name == name {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+func TestPropagation(t *testing.T) {
+	coll := &collector{}
+	srv := httptest.NewServer(coll)
+	defer srv.Close()
+
+	tracing.Init(&cmn.TracingConf{Enabled: true, ExporterEndpoint: srv.URL}, nil, nil)
+	tassert.Fatalf(t, tracing.IsEnabled(), "expecting tracing enabled")
+
+	var injected http.Header
+	handler := tracing.NewHandler(func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracing.StartChild(r.Context(), "lom-load")
+		tracing.End(span, nil)
+		if r.Method == http.MethodGet {
+			injected = http.Header{}
+			tracing.Inject(r.Context(), injected)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	// 1. client's W3C traceparent
+	r := httptest.NewRequest(http.MethodGet, "/v1/objects/abc/obj", http.NoBody)
+	r.Header.Set("traceparent", "00-"+clientTraceID+"-"+clientSpanID+"-01")
+	handler(httptest.NewRecorder(), r)
+	tp := injected.Get("traceparent")
+	tassert.Fatalf(t, strings.Contains(tp, clientTraceID), "expecting client's trace ID in %q", tp)
+
+	// 2. intra-cluster call w/o trace context - not traced
+	r = httptest.NewRequest(http.MethodPut, "/v1/health", http.NoBody)
+	r.Header.Set(apc.HdrCallerID, "t1")
+	handler(httptest.NewRecorder(), r)
+
+	// 3. redirected: proxy's trace context (query) takes precedence over the header
+	r = httptest.NewRequest(http.MethodPut,
+		"/v1/objects/abc/obj?"+apc.QparamTraceparent+"=00-"+proxyTraceID+"-"+proxySpanID+"-01", http.NoBody)
+	r.Header.Set("traceparent", "00-"+clientTraceID+"-"+clientSpanID+"-01")
+	handler(httptest.NewRecorder(), r)
+
+	tracing.Shutdown() // flush
+	tassert.Fatalf(t, !tracing.IsEnabled(), "expecting tracing disabled")
+
+	coll.mu.Lock()
+	defer coll.mu.Unlock()
+	tassert.Fatalf(t, len(coll.spans) == 4, "expecting 4 spans, got %d: %+v", len(coll.spans), coll.spans)
+
+	gets := coll.find("GET /v1/objects")
+	tassert.Fatalf(t, len(gets) == 1, "expecting one GET span, got %+v", coll.spans)
+	get := gets[0]
+	tassert.Errorf(t, get.TraceID == clientTraceID && get.ParentSpanID == clientSpanID, "wrong GET parent: %+v", get)
+	tassert.Errorf(t, get.Kind == 2 /*server*/ && get.Status.Code == 2 /*error*/, "wrong GET kind or status: %+v", get)
+	tassert.Errorf(t, strings.Contains(tp, get.SpanID), "expecting injected %q to refer to the GET span %s", tp, get.SpanID)
+
+	puts := coll.find("PUT /v1/objects")
+	tassert.Fatalf(t, len(puts) == 1, "expecting one PUT span, got %+v", coll.spans)
+	put := puts[0]
+	tassert.Errorf(t, put.TraceID == proxyTraceID && put.ParentSpanID == proxySpanID, "wrong PUT parent: %+v", put)
+
+	for _, s := range coll.find("lom-load") {
+		tassert.Errorf(t, s.ParentSpanID == get.SpanID || s.ParentSpanID == put.SpanID, "orphaned child span %+v", s)
+	}
+}
+
+func TestNoopWhenDisabled(t *testing.T) {
+	tracing.Init(&cmn.TracingConf{}, nil, nil)
+	tassert.Fatalf(t, !tracing.IsEnabled(), "expecting tracing disabled")
+	_, span := tracing.Start(context.Background(), "x")
+	tassert.Errorf(t, !span.IsRecording(), "expecting no-op span")
+	tracing.End(span, nil)
+	tassert.Errorf(t, tracing.Traceparent(context.Background()) == "", "expecting no trace context")
+}
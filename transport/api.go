@@ -6,6 +6,7 @@
 package transport
 
 import (
+	"context"
 	"io"
 	"math"
 	"runtime"
@@ -19,6 +20,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 ///////////////////
@@ -73,10 +76,12 @@ type (
 	}
 	// object to transmit
 	Obj struct {
-		Reader   io.ReadCloser // reader (to read the object, and close when done)
-		CmplArg  any           // optional context passed to the ObjSentCB callback
-		Callback ObjSentCB     // called when the last byte is sent _or_ when the stream terminates (see term.reason)
-		prc      *atomic.Int64 // private; if present, ref-counts so that we call ObjSentCB only once
+		Reader   io.ReadCloser   // reader (to read the object, and close when done)
+		CmplArg  any             // optional context passed to the ObjSentCB callback
+		Callback ObjSentCB       // called when the last byte is sent _or_ when the stream terminates (see term.reason)
+		Ctx      context.Context // optional; when traced, parent of the span that measures the send (see tracing)
+		prc      *atomic.Int64   // private; if present, ref-counts so that we call ObjSentCB only once
+		span     trace.Span      // private; (see Ctx)
 		Hdr      ObjHdr
 	}
 
@@ -153,6 +158,9 @@ func NewObjStream(client Client, dstURL, dstID string, extra *Extra) (s *Stream)
 //     stream(s).
 func (s *Stream) Send(obj *Obj) (err error) {
 	debug.Assertf(len(obj.Hdr.Opaque) < len(s.maxhdr)-sizeofh, "(%d, %d)", len(obj.Hdr.Opaque), len(s.maxhdr))
+	if obj.Ctx != nil && obj.span == nil {
+		_, obj.span = tracing.StartObj(obj.Ctx, "transport.send", &obj.Hdr)
+	}
 	if err = s.startSend(obj); err != nil {
 		s.doCmpl(obj, err) // take a shortcut
 		return
@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -145,6 +146,9 @@ func (s *Stream) doCmpl(obj *Obj, err error) {
 			cos.Close(obj.Reader) // otherwise, always closing
 		}
 	}
+	if obj.span != nil {
+		tracing.End(obj.span, err)
+	}
 	// SCQ completion callback
 	if rc == 0 {
 		if obj.Callback != nil {
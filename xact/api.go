@@ -120,6 +120,8 @@ var Table = map[string]Descriptor{
 
 	apc.ActETLInline: {Scope: ScopeG, Startable: false, AbortRebRes: true},
 
+	apc.ActGetBatch: {Scope: ScopeG, Startable: false},
+
 	// (one bucket) | (all buckets)
 	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true},
 	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true},
@@ -219,6 +221,15 @@ var Table = map[string]Descriptor{
 		RefreshCap:     true,
 		ConflictRebRes: true,
 	},
+	apc.ActECScrub: {
+		DisplayName:    "ec-scrub",
+		Scope:          ScopeB,
+		Access:         apc.AccessRW,
+		Startable:      true,
+		RefreshCap:     true,
+		ConflictRebRes: true,
+		ExtendedStats:  true,
+	},
 	apc.ActMakeNCopies: {
 		DisplayName: "mirror",
 		Scope:       ScopeB,
@@ -257,6 +268,14 @@ var Table = map[string]Descriptor{
 
 	apc.ActList: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false, Metasync: false, Idles: true},
 
+	apc.ActLifecycle: {
+		DisplayName: "lifecycle",
+		Scope:       ScopeB,
+		Access:      apc.AceObjDELETE,
+		Startable:   true,
+		RefreshCap:  true,
+	},
+
 	// cache management, internal usage
 	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true},
 	apc.ActInvalListCache: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false},
@@ -49,6 +49,14 @@ type (
 		Msg *apc.LsoMsg
 		Hdr http.Header
 	}
+	LifecycleArgs struct {
+		AbortMpt func(bck *meta.Bck) int // aborts stale multipart uploads, returns the number aborted
+	}
+	GetBatchArgs struct {
+		Msg *apc.GetBatchMsg // with all the entries' buckets filled-in
+		DT  string           // designated target (the one that assembles the response)
+		Idx []int            // DT: none; other targets: positions of the entries they must send
+	}
 )
 
 //////////////
@@ -73,6 +81,10 @@ func RenewECEncode(bck *meta.Bck, uuid, phase string) RenewRes {
 	return RenewBucketXact(apc.ActECEncode, bck, Args{Custom: &ECEncodeArgs{Phase: phase}, UUID: uuid})
 }
 
+func RenewECScrub(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActECScrub, bck, Args{UUID: uuid})
+}
+
 func RenewMakeNCopies(uuid, tag string) {
 	var (
 		cfg      = cmn.GCO.Get()
@@ -116,6 +128,10 @@ func RenewBckLoadLomCache(uuid string, bck *meta.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{UUID: uuid})
 }
 
+func RenewLifecycle(uuid string, bck *meta.Bck, custom *LifecycleArgs) RenewRes {
+	return RenewBucketXact(apc.ActLifecycle, bck, Args{Custom: custom, UUID: uuid})
+}
+
 func RenewPutMirror(lom *core.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{Custom: lom})
 }
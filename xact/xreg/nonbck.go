@@ -54,6 +54,11 @@ func RenewETL(msg any, xid string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewGetBatch(uuid string, custom *GetBatchArgs) RenewRes {
+	e := dreg.nonbckXacts[apc.ActGetBatch].New(Args{UUID: uuid, Custom: custom}, nil)
+	return dreg.renewByID(e, nil)
+}
+
 func RenewBckSummary(bck *meta.Bck, msg *apc.BsummCtrlMsg) RenewRes {
 	e := dreg.nonbckXacts[apc.ActSummaryBck].New(Args{UUID: msg.UUID, Custom: msg}, bck)
 	return dreg.renew(e, bck)
@@ -529,7 +529,9 @@ func (wi *archwi) openTarForAppend() (err error) {
 	// open (rw) lom itself
 	wi.wfh, wi.tarFormat, wi.appendPos, err = archive.OpenTarForAppend(wi.archlom.Cname(), wi.fqn)
 	if err == nil {
-		return nil // can append
+		// can append (and invalidate tar index, if any)
+		wi.archlom.RemoveTarIdx()
+		return nil
 	}
 
 	// back
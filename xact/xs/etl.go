@@ -23,6 +23,7 @@ type (
 		xctn *xactETL
 	}
 	xactETL struct {
+		ext core.SnapExt // e.g., ETL pipeline's per-stage stats
 		xact.Base
 	}
 )
@@ -40,6 +41,9 @@ func (*etlFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
 func (p *etlFactory) Start() error {
 	debug.Assert(cos.IsValidUUID(p.Args.UUID), p.Args.UUID)
 	p.xctn = newETL(p.Args.UUID, p.Kind())
+	if ext, ok := p.Args.Custom.(core.SnapExt); ok {
+		p.xctn.ext = ext
+	}
 	return nil
 }
 
@@ -65,5 +69,8 @@ func (r *xactETL) Snap() (snap *core.Snap) {
 	r.ToSnap(snap)
 
 	snap.IdleX = r.IsIdle()
+	if r.ext != nil {
+		snap.Ext = r.ext.SnapExt()
+	}
 	return
 }
@@ -80,7 +80,7 @@ func (r *evictDelete) do(lom *core.LOM, lrit *lrit) {
 		r.ObjsAdd(1, lom.Lsize(true))
 		return
 	}
-	if cos.IsNotExist(err, ecode) || cmn.IsErrObjNought(err) {
+	if cos.IsNotExist(err, ecode) || cmn.IsErrObjNought(err) || cmn.IsErrObjLocked(err) {
 		if lrit.lrp == lrpList {
 			goto eret // unlike range and prefix
 		}
@@ -0,0 +1,679 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/transport/bundle"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// get-batch (see apc.GetBatchMsg):
+// - one xaction per request, with the same ID on all participating targets;
+// - designated target (DT) assembles the response in the order of the request's entries:
+//   reads its own entries directly and waits for the rest to arrive;
+// - the other targets (only those that have some of the entries) read their entries,
+//   in order, and send them to DT via transport stream bundle;
+// - entries that could not be read are sent (and then written) as missing/error markers;
+// - backpressure: DT buffers up to gbRxBudget bytes received ahead of the entry it is
+//   currently writing - beyond that, receiving blocks (and so do the senders' streams)
+//   until the buffered entries get written (see rxwait).
+
+const (
+	gbOpcodeMissing = iota + 31415
+	gbOpcodeErr
+)
+
+const gbRxBudget = 256 * cos.MiB
+
+type (
+	gbFactory struct {
+		xreg.RenewBase
+		xctn *XactGetBatch
+	}
+	gbRecv struct {
+		sgl   *memsys.SGL
+		err   error
+		atime int64
+		size  int64 // counted against gbRxBudget
+	}
+	XactGetBatch struct {
+		args     *xreg.GetBatchArgs
+		bcks     []*meta.Bck   // the entries' buckets
+		owners   []*meta.Snode // DT only: targets that have the entries (by HRW)
+		dt       *meta.Snode
+		dm       *bundle.DataMover
+		rxs      []chan *gbRecv // DT only: entries received from other targets
+		wg       sync.WaitGroup // other targets: pending transmissions
+		inflight struct {       // DT only: backpressure
+			cond sync.Cond
+			mu   sync.Mutex
+			size int64 // bytes received and not yet written
+			next int   // entry Assemble is currently writing
+		}
+		xact.Base
+	}
+	ErrGetBatchMissing struct {
+		err error
+	}
+	gbWriter struct {
+		w io.Writer
+		n int64
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactGetBatch)(nil)
+	_ xreg.Renewable = (*gbFactory)(nil)
+)
+
+///////////////
+// gbFactory //
+///////////////
+
+func (*gbFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &gbFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *gbFactory) Start() error {
+	args, ok := p.Args.Custom.(*xreg.GetBatchArgs)
+	debug.Assert(ok)
+	r, err := newGetBatch(p.UUID(), args)
+	if err != nil {
+		return err
+	}
+	p.xctn = r
+	if !r.isDT() {
+		xact.GoRunW(r)
+	}
+	return nil
+}
+
+func (*gbFactory) Kind() string     { return apc.ActGetBatch }
+func (p *gbFactory) Get() core.Xact { return p.xctn }
+
+func (*gbFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprKeepAndStartNew, nil
+}
+
+//////////////////
+// XactGetBatch //
+//////////////////
+
+func newGetBatch(uuid string, args *xreg.GetBatchArgs) (*XactGetBatch, error) {
+	var (
+		smap = core.T.Sowner().Get()
+		r    = &XactGetBatch{args: args}
+		msg  = args.Msg
+	)
+	if r.dt = smap.GetActiveNode(args.DT); r.dt == nil || !r.dt.IsTarget() {
+		return nil, fmt.Errorf("%s: designated target %s is not present (or not active) in %s", apc.ActGetBatch, args.DT, smap)
+	}
+	r.InitBase(uuid, apc.ActGetBatch, nil)
+
+	// resolve buckets
+	var (
+		bcks = make(map[string]*meta.Bck, 2)
+		bmd  = core.T.Bowner()
+	)
+	r.bcks = make([]*meta.Bck, len(msg.In))
+	for i := range msg.In {
+		in := &msg.In[i]
+		key := in.Provider + apc.BckProviderSeparator + in.Bucket
+		bck, ok := bcks[key]
+		if !ok {
+			bck = meta.NewBck(in.Bucket, in.Provider, cmn.NsGlobal) // (namespaces not supported - see gbNormalize)
+			if err := bck.Init(bmd); err != nil {
+				return nil, err
+			}
+			bcks[key] = bck
+		}
+		r.bcks[i] = bck
+	}
+
+	var (
+		config = cmn.GCO.Get()
+		dmxtra = bundle.Extra{Config: config, Multiplier: 1}
+		err    error
+	)
+	if !r.isDT() {
+		r.dm, err = bundle.NewDataMover(uuid /*trname*/, nil, cmn.OwtNone, dmxtra)
+		if err != nil {
+			return nil, err
+		}
+		r.dm.SetXact(r)
+		r.dm.Open()
+		return r, nil
+	}
+
+	// DT
+	var remote bool
+	r.owners = make([]*meta.Snode, len(msg.In))
+	for i := range msg.In {
+		tsi, err := smap.HrwName2T(r.bcks[i].MakeUname(msg.In[i].ObjName))
+		if err != nil {
+			return nil, err
+		}
+		r.owners[i] = tsi
+		remote = remote || tsi.ID() != r.dt.ID()
+	}
+	if !remote {
+		return r, nil
+	}
+	r.inflight.cond.L = &r.inflight.mu
+	r.rxs = make([]chan *gbRecv, len(msg.In))
+	for i, tsi := range r.owners {
+		if tsi.ID() != r.dt.ID() {
+			r.rxs[i] = make(chan *gbRecv, 1)
+		}
+	}
+	r.dm, err = bundle.NewDataMover(uuid /*trname*/, r.recv, cmn.OwtNone, dmxtra)
+	if err != nil {
+		return nil, err
+	}
+	r.dm.SetXact(r)
+	if err := r.dm.RegRecv(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *XactGetBatch) isDT() bool { return r.dt.ID() == core.T.SID() }
+
+// DT only: positions of the entries that must be sent by other targets, by target ID
+func (r *XactGetBatch) PeerIdx() map[string][]int {
+	m := make(map[string][]int, 4)
+	for i, tsi := range r.owners {
+		if tsi.ID() != r.dt.ID() {
+			m[tsi.ID()] = append(m[tsi.ID()], i)
+		}
+	}
+	return m
+}
+
+// DT only: fail the entries that were supposed to be sent by a given target
+func (r *XactGetBatch) PeerFailed(tid string, err error) {
+	for i, tsi := range r.owners {
+		if tsi.ID() != tid {
+			continue
+		}
+		select {
+		case r.rxs[i] <- &gbRecv{err: err}:
+		default:
+		}
+	}
+}
+
+func (r *XactGetBatch) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name(), "=>", r.dt.StringEx())
+	for _, i := range r.args.Idx {
+		if r.IsAborted() {
+			break
+		}
+		if i < 0 || i >= len(r.args.Msg.In) {
+			r.AddErr(fmt.Errorf("%s: entry index %d out of range", r, i))
+			break
+		}
+		r.send(i)
+	}
+	r.wg.Wait()
+	r.dm.Close(r.AbortErr())
+	r.Finish()
+}
+
+// read a given local entry and send it to DT
+func (r *XactGetBatch) send(i int) {
+	var (
+		in  = &r.args.Msg.In[i]
+		o   = transport.AllocSend()
+		hdr = &o.Hdr
+	)
+	hdr.Bck.Copy(r.bcks[i].Bucket())
+	hdr.ObjName = in.ObjName
+	pack := cos.NewPacker(nil, cos.SizeofI64)
+	pack.WriteInt64(int64(i))
+	hdr.Opaque = pack.Bytes()
+	o.Callback = r.sent
+
+	var (
+		roc cos.ReadOpenCloser
+		err error
+	)
+	if in.ArchPath == "" {
+		var oah cos.OAH
+		lom := core.AllocLOM(in.ObjName)
+		if err = lom.InitBck(&hdr.Bck); err == nil {
+			roc, oah, err = (&core.LDP{}).Reader(lom, false /*latest*/, false /*sync*/)
+		}
+		if err == nil && oah.Lsize() < 0 {
+			// (remote reader of unknown size)
+			sgl := core.T.PageMM().NewSGL(0)
+			_, err = io.Copy(sgl, roc)
+			roc.Close()
+			if err != nil {
+				sgl.Free()
+			} else {
+				roc, o.CmplArg = memsys.NewReader(sgl), sgl
+				hdr.ObjAttrs.Size = sgl.Size()
+			}
+		} else if err == nil {
+			hdr.ObjAttrs.Size = oah.Lsize()
+		}
+		if err == nil {
+			hdr.ObjAttrs.Atime = oah.AtimeUnix()
+		}
+		core.FreeLOM(lom)
+	} else {
+		var sgl *memsys.SGL
+		sgl, hdr.ObjAttrs.Atime, err = r.extract(i)
+		if err == nil {
+			roc, o.CmplArg = memsys.NewReader(sgl), sgl
+			hdr.ObjAttrs.Size = sgl.Size()
+		}
+	}
+	if err != nil {
+		// send the marker instead (the error message being the payload)
+		hdr.Opcode = gbOpcodeErr
+		if cos.IsNotExist(err, 0) {
+			hdr.Opcode = gbOpcodeMissing
+		}
+		bt := cos.UnsafeB(err.Error())
+		roc = cos.NewByteHandle(bt)
+		hdr.ObjAttrs.Size = int64(len(bt))
+	}
+
+	r.wg.Add(1)
+	if err := r.dm.Send(o, roc, r.dt); err != nil {
+		r.Abort(err)
+	}
+}
+
+func (r *XactGetBatch) sent(hdr *transport.ObjHdr, _ io.ReadCloser, arg any, err error) {
+	if sgl, ok := arg.(*memsys.SGL); ok {
+		sgl.Free()
+	}
+	if err != nil {
+		r.AddErr(fmt.Errorf("%s: failed to send %s: %w", r, hdr.Cname(), err))
+	} else if hdr.Opcode == 0 {
+		r.ObjsAdd(1, hdr.ObjAttrs.Size)
+	}
+	r.wg.Done()
+}
+
+// DT: receive entries from other targets
+func (r *XactGetBatch) recv(hdr *transport.ObjHdr, reader io.Reader, err error) error {
+	if err != nil && !cos.IsEOF(err) {
+		r.AddErr(err)
+		return err
+	}
+	unpacker := cos.NewUnpacker(hdr.Opaque)
+	idx, err := unpacker.ReadInt64()
+	if err != nil || idx < 0 || int(idx) >= len(r.rxs) || r.rxs[idx] == nil {
+		err = fmt.Errorf("%s: unexpected entry %s from %s (%d, %v)", r, hdr.Cname(), meta.Tname(hdr.SID), idx, err)
+		r.AddErr(err)
+		return err
+	}
+	size := max(hdr.ObjAttrs.Size, 0)
+	r.rxwait(int(idx), size)
+	sgl := core.T.PageMM().NewSGL(size)
+	if _, err := io.Copy(sgl, reader); err != nil {
+		sgl.Free()
+		r.rxfree(size)
+		err = fmt.Errorf("%s: failed to receive %s: %w", r, hdr.Cname(), err)
+		select {
+		case r.rxs[idx] <- &gbRecv{err: err}:
+		default:
+		}
+		return err
+	}
+	rx := &gbRecv{sgl: sgl, atime: hdr.ObjAttrs.Atime, size: size}
+	switch hdr.Opcode {
+	case gbOpcodeMissing:
+		rx.err = &ErrGetBatchMissing{errors.New(string(sgl.ReadAll()))}
+	case gbOpcodeErr:
+		rx.err = errors.New(string(sgl.ReadAll()))
+	}
+	if rx.err != nil {
+		sgl.Free()
+		r.rxfree(size)
+		rx.sgl, rx.size = nil, 0
+	}
+	select {
+	case r.rxs[idx] <- rx:
+	default:
+		// duplicate (not expected) or the peer has already failed (see PeerFailed)
+		if rx.sgl != nil {
+			rx.sgl.Free()
+			r.rxfree(rx.size)
+		}
+	}
+	return nil
+}
+
+// DT: block while the entries received ahead of the one being written take more than
+// gbRxBudget - but never the entry Assemble is waiting for (or has already given up on),
+// and never when nothing is buffered (entries larger than the budget)
+func (r *XactGetBatch) rxwait(idx int, size int64) {
+	r.inflight.mu.Lock()
+	for idx > r.inflight.next && r.inflight.size > 0 && r.inflight.size+size > gbRxBudget {
+		r.inflight.cond.Wait()
+	}
+	r.inflight.size += size
+	r.inflight.mu.Unlock()
+}
+
+func (r *XactGetBatch) rxfree(size int64) {
+	if size == 0 {
+		return
+	}
+	r.inflight.mu.Lock()
+	r.inflight.size -= size
+	r.inflight.cond.Broadcast()
+	r.inflight.mu.Unlock()
+}
+
+func (r *XactGetBatch) rxadvance(i int) {
+	r.inflight.mu.Lock()
+	r.inflight.next = i
+	r.inflight.cond.Broadcast()
+	r.inflight.mu.Unlock()
+}
+
+// DT: write all entries, in order, formatted as one of the supported archives
+// returns the number of bytes written (so that the caller could tell if it's too late
+// to respond with an error)
+func (r *XactGetBatch) Assemble(w io.Writer, mime string) (int64, error) {
+	var (
+		msg     = r.args.Msg
+		gbw     = &gbWriter{w: w}
+		aw      = archive.NewWriter(mime, gbw, nil /*checksum*/, nil /*opts*/)
+		timeout = cmn.GCO.Get().Timeout.SendFile.D()
+		err     error
+	)
+	for i := range msg.In {
+		if err = r.AbortErr(); err != nil {
+			break
+		}
+		name := r.entryName(i)
+		if r.rxs != nil {
+			r.rxadvance(i)
+		}
+		if r.owners[i].ID() == r.dt.ID() {
+			err = r.writeLocal(aw, i, name)
+		} else {
+			err = r.writeRecv(aw, i, name, timeout)
+		}
+		if err == nil {
+			continue
+		}
+		if !msg.ContinueOnError || isErrWrite(err) {
+			break
+		}
+		err = r.writeMarker(aw, name, err)
+		if err != nil {
+			break
+		}
+	}
+	aw.Fini()
+	if r.rxs != nil {
+		r.rxadvance(len(msg.In)) // (unblock receiving - see rxwait)
+	}
+
+	if err != nil {
+		r.Abort(err)
+	}
+	if r.dm == nil {
+		r.Finish()
+	} else {
+		go r.cleanup() // (quiesce without delaying the response)
+	}
+	return gbw.n, err
+}
+
+// <bucket>/<objname>[/<archpath>] or <objname>[/<archpath>]
+func (r *XactGetBatch) entryName(i int) string {
+	var (
+		msg  = r.args.Msg
+		in   = &msg.In[i]
+		name = in.ObjName
+	)
+	if !msg.OnlyObjName {
+		name = path.Join(r.bcks[i].Name, name)
+	}
+	if in.ArchPath != "" {
+		name = path.Join(name, in.ArchPath)
+	}
+	return name
+}
+
+func (r *XactGetBatch) writeLocal(aw archive.Writer, i int, name string) error {
+	in := &r.args.Msg.In[i]
+	if in.ArchPath != "" {
+		sgl, atime, err := r.extract(i)
+		if err != nil {
+			return err
+		}
+		err = r.write(aw, name, &cos.SimpleOAH{Size: sgl.Size(), Atime: atime}, sgl)
+		sgl.Free()
+		return err
+	}
+
+	lom := core.AllocLOM(in.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(r.bcks[i].Bucket()); err != nil {
+		return err
+	}
+	roc, oah, err := (&core.LDP{}).Reader(lom, false /*latest*/, false /*sync*/)
+	if err != nil {
+		return err
+	}
+	if oah.Lsize() < 0 {
+		// (remote reader of unknown size)
+		sgl := core.T.PageMM().NewSGL(0)
+		_, err = io.Copy(sgl, roc)
+		roc.Close()
+		if err == nil {
+			err = r.write(aw, name, &cos.SimpleOAH{Size: sgl.Size(), Atime: oah.AtimeUnix()}, sgl)
+		}
+		sgl.Free()
+		return err
+	}
+	err = r.write(aw, name, oah, roc)
+	roc.Close()
+	return err
+}
+
+func (r *XactGetBatch) writeRecv(aw archive.Writer, i int, name string, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case rx := <-r.rxs[i]:
+		if rx.err != nil {
+			return rx.err
+		}
+		err := r.write(aw, name, &cos.SimpleOAH{Size: rx.sgl.Size(), Atime: rx.atime}, rx.sgl)
+		rx.sgl.Free()
+		r.rxfree(rx.size)
+		return err
+	case <-timer.C:
+		err := fmt.Errorf("%s: timed out waiting for %s from %s", r, name, r.owners[i].StringEx())
+		// fail the rest of this peer's entries right away, rather than waiting (and timing out) on each
+		r.PeerFailed(r.owners[i].ID(), err)
+		return err
+	case err := <-r.ChanAbort():
+		return err
+	}
+}
+
+func (r *XactGetBatch) write(aw archive.Writer, name string, oah cos.OAH, reader io.Reader) error {
+	if err := aw.Write(name, oah, reader); err != nil {
+		return &errWrite{err}
+	}
+	r.ObjsAdd(1, oah.Lsize())
+	return nil
+}
+
+func (r *XactGetBatch) writeMarker(aw archive.Writer, name string, err error) error {
+	var (
+		dir = apc.GetBatchErrDir
+		bt  = cos.UnsafeB(err.Error())
+	)
+	if IsErrGetBatchMissing(err) || cos.IsNotExist(err, 0) {
+		dir, bt = apc.GetBatchMissingDir, nil
+	}
+	oah := &cos.SimpleOAH{Size: int64(len(bt)), Atime: time.Now().UnixNano()}
+	if cmn.Rom.FastV(4, cos.SmoduleXs) {
+		nlog.Infoln(r.Name(), name, "=>", dir, err)
+	}
+	return r.write(aw, path.Join(dir, name), oah, strings.NewReader(string(bt)))
+}
+
+// extract archived file from a local object (shard) into SGL
+func (r *XactGetBatch) extract(i int) (*memsys.SGL, int64, error) {
+	var (
+		in  = &r.args.Msg.In[i]
+		lom = core.AllocLOM(in.ObjName)
+	)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(r.bcks[i].Bucket()); err != nil {
+		return nil, 0, err
+	}
+	lom.Lock(false)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if cos.IsNotExist(err, 0) && lom.Bck().IsRemote() {
+		lom.Unlock(false)
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return nil, 0, err
+		}
+		lom.Lock(false)
+		err = lom.Load(false, true)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		return nil, 0, err
+	}
+	defer lom.Unlock(false)
+
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cos.Close(fh)
+	mime, err := archive.MimeFile(fh, core.T.PageMM(), "", lom.ObjName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var csl cos.ReadCloseSizer
+	// .tar: seek directly via (persistent) tar index, if available (compare with getOI._txarch)
+	if mime == archive.ExtTar {
+		idx, err := lom.TarIndex(fh)
+		if err == nil {
+			if csl = idx.ReadOne(fh, in.ArchPath); csl == nil {
+				return nil, 0, cos.NewErrNotFound(core.T, in.ArchPath+" in "+lom.Cname())
+			}
+		} else {
+			if cmn.Rom.FastV(4, cos.SmoduleXs) {
+				nlog.Infoln("tar index of", lom.Cname(), "is not available:", err)
+			}
+			if _, err := fh.Seek(0, io.SeekStart); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	if csl == nil {
+		ar, err := archive.NewReader(mime, fh, lom.Lsize())
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+		}
+		if csl, err = ar.ReadOne(in.ArchPath); err != nil {
+			return nil, 0, cmn.NewErrFailedTo(core.T, "extract "+in.ArchPath+" from", lom.Cname(), err)
+		}
+		if csl == nil {
+			return nil, 0, cos.NewErrNotFound(core.T, in.ArchPath+" in "+lom.Cname())
+		}
+	}
+	sgl := core.T.PageMM().NewSGL(csl.Size())
+	_, err = io.Copy(sgl, csl)
+	csl.Close()
+	if err != nil {
+		sgl.Free()
+		return nil, 0, err
+	}
+	return sgl, lom.AtimeUnix(), nil
+}
+
+// DT: stop receiving, free the entries that were received but not written, and finish
+func (r *XactGetBatch) cleanup() {
+	r.dm.UnregRecv()
+	for _, ch := range r.rxs {
+		if ch == nil {
+			continue
+		}
+		select {
+		case rx := <-ch:
+			if rx.sgl != nil {
+				rx.sgl.Free()
+			}
+		default:
+		}
+	}
+	r.Finish()
+}
+
+func (r *XactGetBatch) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+	return
+}
+
+//
+// errors and helpers
+//
+
+type errWrite struct{ err error }
+
+func (e *errWrite) Error() string { return e.err.Error() }
+func (e *errWrite) Unwrap() error { return e.err }
+
+func isErrWrite(err error) bool {
+	var e *errWrite
+	return errors.As(err, &e)
+}
+
+func (e *ErrGetBatchMissing) Error() string { return e.err.Error() }
+
+func IsErrGetBatchMissing(err error) bool {
+	var e *ErrGetBatchMissing
+	return errors.As(err, &e)
+}
+
+func (gbw *gbWriter) Write(b []byte) (n int, err error) {
+	n, err = gbw.w.Write(b)
+	gbw.n += int64(n)
+	return n, err
+}
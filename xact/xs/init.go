@@ -27,9 +27,11 @@ func Xreg(xeleOnly bool) {
 	xreg.RegBckXact(&prfFactory{})
 
 	xreg.RegNonBckXact(&nsummFactory{})
+	xreg.RegNonBckXact(&gbFactory{})
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&lcFactory{})
 
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
@@ -0,0 +1,145 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Bucket lifecycle: a) abort stale multipart uploads and b) walk the bucket
+// to delete expired objects, as per bucket's lifecycle rules (see cmn/lifecycle.go).
+// Runs periodically on every target (each target taking care of its own content)
+// and can be started on demand via xaction start API.
+
+type (
+	lcFactory struct {
+		xreg.RenewBase
+		xctn *xactLifecycle
+	}
+	xactLifecycle struct {
+		args *xreg.LifecycleArgs
+		conf cmn.LifecycleConf // rules at the start time
+		now  time.Time
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*xactLifecycle)(nil)
+	_ xreg.Renewable = (*lcFactory)(nil)
+)
+
+///////////////
+// lcFactory //
+///////////////
+
+func (*lcFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &lcFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *lcFactory) Start() error {
+	args, _ := p.Args.Custom.(*xreg.LifecycleArgs)
+	xctn := newXactLifecycle(p.UUID(), p.Bck, args)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*lcFactory) Kind() string     { return apc.ActLifecycle }
+func (p *lcFactory) Get() core.Xact { return p.xctn }
+
+func (*lcFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// xactLifecycle //
+///////////////////
+
+func newXactLifecycle(uuid string, bck *meta.Bck, args *xreg.LifecycleArgs) (r *xactLifecycle) {
+	r = &xactLifecycle{args: args, conf: bck.Props.Lifecycle, now: time.Now()}
+	mpopts := &mpather.JgroupOpts{
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              r.visitObj,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActLifecycle, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *xactLifecycle) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+	if r.args != nil && r.args.AbortMpt != nil {
+		if n := r.args.AbortMpt(r.Bck()); n > 0 {
+			nlog.Infoln(r.Name(), "aborted", n, "stale multipart upload(s)")
+		}
+	}
+	if r.expires() {
+		r.BckJog.Run()
+		if err := r.BckJog.Wait(); err != nil {
+			r.AddErr(err)
+		}
+	}
+	r.Finish()
+}
+
+// (any enabled rule) expires objects
+func (r *xactLifecycle) expires() bool {
+	for i := range r.conf.Rules {
+		if rule := &r.conf.Rules[i]; rule.Enabled && rule.Expire > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *xactLifecycle) visitObj(lom *core.LOM, _ []byte) error {
+	var tags cos.StrKVs
+	if s, ok := lom.GetCustomKey(cmn.TaggingObjMD); ok {
+		tags, _ = cmn.ParseObjTags(s)
+	}
+	expire := r.conf.Expire(lom.ObjName, tags)
+	if expire == 0 {
+		return nil
+	}
+	_, _, mtime, err := lom.Fstat(false /*get-atime*/)
+	if err != nil || r.now.Sub(mtime) < expire {
+		return nil
+	}
+	ecode, err := core.T.DeleteObject(lom, false /*evict*/)
+	if err == nil {
+		r.ObjsAdd(1, lom.Lsize(true))
+		return nil
+	}
+	// (objects under retention or legal hold do not expire)
+	if !cos.IsNotExist(err, ecode) && !cmn.IsErrObjNought(err) && !cmn.IsErrObjLocked(err) {
+		r.AddErr(err, 5, cos.SmoduleXs)
+	}
+	return nil
+}
+
+func (r *xactLifecycle) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -19,6 +19,7 @@ import (
 )
 
 // Assorted multi-object (list/range templated) xactions: evict, delete, prefetch multiple objects
+// (all optionally filtered by object tags - see apc.ListRange.Tags)
 //
 // Supported range syntax includes:
 //   1. bash-extension style: `file-{0..100}`
@@ -334,6 +335,9 @@ func (r *lrit) do(lom *core.LOM, wi lrwi, smap *meta.Smap) (bool /*this lom done
 			return true, nil
 		}
 	}
+	if len(r.msg.Tags) > 0 && !r.tagged(lom) {
+		return true, nil
+	}
 
 	if r.workers == nil {
 		wi.do(lom, r)
@@ -343,6 +347,24 @@ func (r *lrit) do(lom *core.LOM, wi lrwi, smap *meta.Smap) (bool /*this lom done
 	return false, nil
 }
 
+// filter by object tags (see apc.ListRange.Tags);
+// objects that are not present in the cluster do not match
+func (r *lrit) tagged(lom *core.LOM) bool {
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return false
+	}
+	s, ok := lom.GetCustomKey(cmn.TaggingObjMD)
+	if !ok {
+		return false
+	}
+	tags, err := cmn.ParseObjTags(s)
+	if err != nil {
+		nlog.Warningln(lom.Cname(), err)
+		return false
+	}
+	return cmn.MatchObjTags(tags, r.msg.Tags)
+}
+
 //////////////
 // lrworker //
 //////////////
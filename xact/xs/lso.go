@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -625,7 +626,7 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 
 	// ls arch
 	// looking only at the file extension - not reading ("detecting") file magic (TODO: add lsmsg flag)
-	archList, err := archive.List(fqn)
+	archList, err := r.listArch(fqn)
 	if err != nil {
 		if archive.IsErrUnknownFileExt(err) {
 			// skip and keep going
@@ -650,6 +651,34 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+// .tar: via (persistent) tar index that also gets built upon the first listing
+// (see core.LOM.TarIndex); other formats: read the archive
+func (r *LsoXact) listArch(fqn string) ([]*archive.Entry, error) {
+	if mime, err := archive.Mime("", fqn); err != nil || mime != archive.ExtTar {
+		return archive.List(fqn)
+	}
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, r.Bck().Bucket()); err != nil {
+		return archive.List(fqn)
+	}
+	// (the index may get built and stored - must be protected from concurrent writes)
+	if !lom.TryLock(false) {
+		return archive.List(fqn)
+	}
+	defer lom.Unlock(false)
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(fh)
+	idx, err := lom.TarIndex(fh)
+	if err != nil {
+		return archive.List(fqn)
+	}
+	return idx.List(), nil
+}
+
 func (r *LsoXact) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
@@ -7,9 +7,11 @@ package xs
 
 import (
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -32,8 +34,20 @@ type (
 	}
 
 	Rebalance struct {
+		walked  atomic.Int64 // objects walked by this rebalance
+		total   atomic.Int64 // objects to walk (zero while counting)
+		resumed atomic.Int64 // objects walked prior to restart or abort (see reb/checkpoint.go)
+		counted atomic.Bool  // total is known
 		xact.Base
 	}
+	// rebalance progress (this target)
+	ExtRebStats struct {
+		Walked  int64        `json:"reb.walked.n,string"`
+		Total   int64        `json:"reb.total.n,string"`
+		Resumed int64        `json:"reb.resumed.n,string"`
+		Pct     int          `json:"reb.pct"` // percent complete: (resumed + walked) vs (resumed + total)
+		ETA     cos.Duration `json:"reb.eta"` // estimated time to complete the walk (zero when unknown)
+	}
 	Resilver struct {
 		xact.Base
 	}
@@ -104,9 +118,38 @@ func (xreb *Rebalance) Snap() (snap *core.Snap) {
 	// (TODO: revisit)
 	snap.Stats.Objs = snap.Stats.OutObjs
 	snap.Stats.Bytes = snap.Stats.OutBytes
+
+	snap.Ext = xreb.progress()
 	return
 }
 
+func (xreb *Rebalance) IncWalked()         { xreb.walked.Inc() }
+func (xreb *Rebalance) SetTotal(n int64)   { xreb.total.Store(n); xreb.counted.Store(true) }
+func (xreb *Rebalance) SetResumed(n int64) { xreb.resumed.Store(n) }
+
+func (xreb *Rebalance) progress() *ExtRebStats {
+	ext := &ExtRebStats{Walked: xreb.walked.Load(), Total: xreb.total.Load(), Resumed: xreb.resumed.Load()}
+	switch {
+	case xreb.Finished():
+		if !xreb.IsAborted() {
+			ext.Pct = 100
+		}
+		return ext
+	case !xreb.counted.Load():
+		return ext // still counting
+	case ext.Total == 0:
+		ext.Pct = 100 // nothing to walk
+		return ext
+	}
+	walked := min(ext.Walked, ext.Total) // (not counting new objects that show up during the walk)
+	ext.Pct = int((ext.Resumed + walked) * 100 / (ext.Resumed + ext.Total))
+	if walked > 0 && walked < ext.Total {
+		elapsed := float64(time.Since(xreb.StartTime()))
+		ext.ETA = cos.Duration(elapsed * float64(ext.Total-walked) / float64(walked))
+	}
+	return ext
+}
+
 //////////////
 // Resilver //
 //////////////
@@ -379,5 +379,8 @@ func (r *XactTCB) Snap() (snap *core.Snap) {
 	snap.IdleX = r.IsIdle()
 	f, t := r.FromTo()
 	snap.SrcBck, snap.DstBck = f.Clone(), t.Clone()
+	if ext, ok := r.p.args.DP.(core.SnapExt); ok {
+		snap.Ext = ext.SnapExt()
+	}
 	return
 }
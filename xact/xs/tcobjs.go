@@ -131,6 +131,9 @@ func (r *XactTCObjs) Snap() (snap *core.Snap) {
 	snap.IdleX = r.IsIdle()
 	f, t := r.FromTo()
 	snap.SrcBck, snap.DstBck = f.Clone(), t.Clone()
+	if ext, ok := r.args.DP.(core.SnapExt); ok {
+		snap.Ext = ext.SnapExt()
+	}
 	return
 }
 
@@ -333,3 +333,37 @@ func TestBeid(t *testing.T) {
 		fmt.Printf("Warning: failed to reproduce %d time%s out of %d\n", cnt, cos.Plural(cnt), num)
 	}
 }
+
+func TestRebalanceProgress(t *testing.T) {
+	xreb := xs.NewRebalance(xact.RebID2S(7), apc.ActRebalance)
+	progress := func() *xs.ExtRebStats {
+		ext, ok := xreb.Snap().Ext.(*xs.ExtRebStats)
+		tassert.Fatalf(t, ok, "expecting rebalance stats, got %T", xreb.Snap().Ext)
+		return ext
+	}
+
+	// still counting
+	for range 10 {
+		xreb.IncWalked()
+	}
+	ext := progress()
+	tassert.Errorf(t, ext.Walked == 10 && ext.Pct == 0 && ext.ETA == 0, "unexpected progress while counting: %+v", ext)
+
+	// 30 objects walked prior to restart, 10 out of 70 walked since
+	xreb.SetResumed(30)
+	xreb.SetTotal(70)
+	ext = progress()
+	tassert.Errorf(t, ext.Pct == 40, "expected 40%% complete, got %+v", ext)
+	tassert.Errorf(t, ext.ETA > 0, "expected non-zero ETA, got %+v", ext)
+
+	// new objects during the walk
+	for range 70 {
+		xreb.IncWalked()
+	}
+	ext = progress()
+	tassert.Errorf(t, ext.Pct == 100 && ext.ETA == 0, "expected 100%% complete, got %+v", ext)
+
+	xreb.Finish()
+	ext = progress()
+	tassert.Errorf(t, ext.Pct == 100, "expected 100%% upon completion, got %+v", ext)
+}